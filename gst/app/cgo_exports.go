@@ -13,7 +13,7 @@ func getSinkCbsFromPtr(userData C.gpointer) *SinkCallbacks {
 	ptr := gopointer.Restore(unsafe.Pointer(userData))
 	cbs, ok := ptr.(*SinkCallbacks)
 	if !ok {
-		gopointer.Unref(unsafe.Pointer(userData))
+		gst.UnrefPointerUnsafe(unsafe.Pointer(userData))
 		return nil
 	}
 	return cbs
@@ -23,7 +23,7 @@ func getSrcCbsFromPtr(userData C.gpointer) *SourceCallbacks {
 	ptr := gopointer.Restore(unsafe.Pointer(userData))
 	cbs, ok := ptr.(*SourceCallbacks)
 	if !ok {
-		gopointer.Unref(unsafe.Pointer(userData))
+		gst.UnrefPointerUnsafe(unsafe.Pointer(userData))
 		return nil
 	}
 	return cbs
@@ -39,6 +39,7 @@ func wrapCSource(src *C.GstAppSrc) *Source {
 
 //export goNeedDataCb
 func goNeedDataCb(src *C.GstAppSrc, length C.guint, userData C.gpointer) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(src), nil)
 	cbs := getSrcCbsFromPtr(userData)
 	if cbs == nil {
 		return
@@ -51,6 +52,7 @@ func goNeedDataCb(src *C.GstAppSrc, length C.guint, userData C.gpointer) {
 
 //export goEnoughDataDb
 func goEnoughDataDb(src *C.GstAppSrc, userData C.gpointer) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(src), nil)
 	cbs := getSrcCbsFromPtr(userData)
 	if cbs == nil {
 		return
@@ -62,7 +64,8 @@ func goEnoughDataDb(src *C.GstAppSrc, userData C.gpointer) {
 }
 
 //export goSeekDataCb
-func goSeekDataCb(src *C.GstAppSrc, offset C.guint64, userData C.gpointer) C.gboolean {
+func goSeekDataCb(src *C.GstAppSrc, offset C.guint64, userData C.gpointer) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(src), func() { ret = gboolean(false) })
 	cbs := getSrcCbsFromPtr(userData)
 	if cbs == nil {
 		return gboolean(false)
@@ -75,6 +78,7 @@ func goSeekDataCb(src *C.GstAppSrc, offset C.guint64, userData C.gpointer) C.gbo
 
 //export goSinkEOSCb
 func goSinkEOSCb(sink *C.GstAppSink, userData C.gpointer) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(sink), nil)
 	cbs := getSinkCbsFromPtr(userData)
 	if cbs == nil {
 		return
@@ -86,7 +90,8 @@ func goSinkEOSCb(sink *C.GstAppSink, userData C.gpointer) {
 }
 
 //export goSinkNewPrerollCb
-func goSinkNewPrerollCb(sink *C.GstAppSink, userData C.gpointer) C.GstFlowReturn {
+func goSinkNewPrerollCb(sink *C.GstAppSink, userData C.gpointer) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(sink), func() { ret = C.GstFlowReturn(gst.FlowError) })
 	cbs := getSinkCbsFromPtr(userData)
 	if cbs == nil {
 		return C.GstFlowReturn(gst.FlowError)
@@ -98,7 +103,8 @@ func goSinkNewPrerollCb(sink *C.GstAppSink, userData C.gpointer) C.GstFlowReturn
 }
 
 //export goSinkNewSampleCb
-func goSinkNewSampleCb(sink *C.GstAppSink, userData C.gpointer) C.GstFlowReturn {
+func goSinkNewSampleCb(sink *C.GstAppSink, userData C.gpointer) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(sink), func() { ret = C.GstFlowReturn(gst.FlowError) })
 	cbs := getSinkCbsFromPtr(userData)
 	if cbs == nil {
 		return C.GstFlowReturn(gst.FlowError)
@@ -111,5 +117,5 @@ func goSinkNewSampleCb(sink *C.GstAppSink, userData C.gpointer) C.GstFlowReturn
 
 //export goAppGDestroyNotifyFunc
 func goAppGDestroyNotifyFunc(ptr C.gpointer) {
-	gopointer.Unref(unsafe.Pointer(ptr))
+	gst.UnrefPointerUnsafe(unsafe.Pointer(ptr))
 }
@@ -22,7 +22,6 @@ import (
 	"time"
 	"unsafe"
 
-	gopointer "github.com/mattn/go-pointer"
 	"github.com/tinyzimmer/go-gst/gst"
 )
 
@@ -71,7 +70,7 @@ func (a *Sink) GetCaps() *gst.Caps {
 	if caps == nil {
 		return nil
 	}
-	return gst.FromGstCapsUnsafe(unsafe.Pointer(caps))
+	return gst.FromGstCapsUnsafeFull(unsafe.Pointer(caps))
 }
 
 // GetDrop checks if appsink will drop old buffers when the maximum amount of queued buffers is reached.
@@ -116,7 +115,7 @@ func (a *Sink) PullPreroll() *gst.Sample {
 	if smpl == nil {
 		return nil
 	}
-	return gst.FromGstSampleUnsafe(unsafe.Pointer(smpl))
+	return gst.FromGstSampleUnsafeFull(unsafe.Pointer(smpl))
 }
 
 // PullSample blocks until a sample or EOS becomes available or the appsink element is set to the READY/NULL state.
@@ -131,7 +130,7 @@ func (a *Sink) PullSample() *gst.Sample {
 	if smpl == nil {
 		return nil
 	}
-	return gst.FromGstSampleUnsafe(unsafe.Pointer(smpl))
+	return gst.FromGstSampleUnsafeFull(unsafe.Pointer(smpl))
 }
 
 // SetBufferListSupport instructs appsink to enable or disable buffer list support.
@@ -148,7 +147,7 @@ func (a *Sink) SetBufferListSupport(enabled bool) {
 //
 // Before 1.16.3 it was not possible to change the callbacks in a thread-safe way.
 func (a *Sink) SetCallbacks(cbs *SinkCallbacks) {
-	ptr := gopointer.Save(cbs)
+	ptr := gst.SavePointerUnsafe(cbs)
 	appSinkCallbacks := &C.GstAppSinkCallbacks{
 		eos:         (*[0]byte)(unsafe.Pointer(C.cgoSinkEOSCb)),
 		new_preroll: (*[0]byte)(unsafe.Pointer(C.cgoSinkNewPrerollCb)),
@@ -209,7 +208,7 @@ func (a *Sink) TryPullPreroll(timeout time.Duration) *gst.Sample {
 	if smpl == nil {
 		return nil
 	}
-	return gst.FromGstSampleUnsafe(unsafe.Pointer(smpl))
+	return gst.FromGstSampleUnsafeFull(unsafe.Pointer(smpl))
 }
 
 // TryPullSample blocks until a sample or EOS becomes available or the appsink element is set to the READY/NULL state or the timeout expires.
@@ -225,5 +224,5 @@ func (a *Sink) TryPullSample(timeout time.Duration) *gst.Sample {
 	if smpl == nil {
 		return nil
 	}
-	return gst.FromGstSampleUnsafe(unsafe.Pointer(smpl))
+	return gst.FromGstSampleUnsafeFull(unsafe.Pointer(smpl))
 }
@@ -21,7 +21,6 @@ import (
 	"time"
 	"unsafe"
 
-	gopointer "github.com/mattn/go-pointer"
 	"github.com/tinyzimmer/go-gst/gst"
 )
 
@@ -79,7 +78,7 @@ func (a *Source) GetCaps() *gst.Caps {
 	if caps == nil {
 		return nil
 	}
-	return gst.FromGstCapsUnsafe(unsafe.Pointer(caps))
+	return gst.FromGstCapsUnsafeFull(unsafe.Pointer(caps))
 }
 
 // GetCurrentLevelBytes gets the number of currently queued bytes inside appsrc.
@@ -128,7 +127,7 @@ func (a *Source) GetStreamType() StreamType {
 func (a *Source) PushBuffer(buf *gst.Buffer) gst.FlowReturn {
 	ret := C.gst_app_src_push_buffer(
 		(*C.GstAppSrc)(a.Instance()),
-		(*C.GstBuffer)(unsafe.Pointer(buf.Instance())),
+		(*C.GstBuffer)(buf.TransferUnsafe()),
 	)
 	return gst.FlowReturn(ret)
 }
@@ -139,7 +138,7 @@ func (a *Source) PushBuffer(buf *gst.Buffer) gst.FlowReturn {
 // When the block property is TRUE, this function can block until free space becomes available in the queue.
 func (a *Source) PushBufferList(bufList *gst.BufferList) gst.FlowReturn {
 	return gst.FlowReturn(C.gst_app_src_push_buffer_list(
-		a.Instance(), (*C.GstBufferList)(unsafe.Pointer(bufList.Instance())),
+		a.Instance(), (*C.GstBufferList)(bufList.TransferUnsafe()),
 	))
 }
 
@@ -164,7 +163,7 @@ func (a *Source) PushSample(sample *gst.Sample) gst.FlowReturn {
 //
 // Before 1.16.3 it was not possible to change the callbacks in a thread-safe way.
 func (a *Source) SetCallbacks(cbs *SourceCallbacks) {
-	ptr := gopointer.Save(cbs)
+	ptr := gst.SavePointerUnsafe(cbs)
 	appSrcCallbacks := &C.GstAppSrcCallbacks{
 		need_data:   (*[0]byte)(unsafe.Pointer(C.cgoNeedDataCb)),
 		enough_data: (*[0]byte)(unsafe.Pointer(C.cgoEnoughDataCb)),
@@ -0,0 +1,13 @@
+package audio
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+func TestMain(m *testing.M) {
+	gst.Init(nil)
+	os.Exit(m.Run())
+}
@@ -0,0 +1,47 @@
+package audio
+
+/*
+#include "gst.go.h"
+*/
+import "C"
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+func fromCoreCaps(caps *gst.Caps) *C.GstCaps {
+	return (*C.GstCaps)(unsafe.Pointer(caps.Instance()))
+}
+
+func durationToClockTime(d time.Duration) C.GstClockTime {
+	if d.Nanoseconds() < 0 {
+		return C.GstClockTime(gst.ClockTimeNone)
+	}
+	return C.GstClockTime(d.Nanoseconds())
+}
+
+// unsafeBytes returns a byte slice of size bytes sharing the memory at ptr, without copying it.
+func unsafeBytes(ptr unsafe.Pointer, size int) []byte {
+	if ptr == nil || size <= 0 {
+		return nil
+	}
+	var out []byte
+	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&out))
+	hdr.Data = uintptr(ptr)
+	hdr.Len = size
+	hdr.Cap = size
+	return out
+}
+
+func gobool(b C.gboolean) bool { return int(b) > 0 }
+
+func gboolean(b bool) C.gboolean {
+	if b {
+		return C.gboolean(1)
+	}
+	return C.gboolean(0)
+}
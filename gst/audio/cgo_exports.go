@@ -0,0 +1,92 @@
+package audio
+
+/*
+#include "gst.go.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+//export goAudioEncoderStart
+func goAudioEncoderStart(enc *C.GstAudioEncoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).StartFunc(wrapCEncoder(enc)))
+}
+
+//export goAudioEncoderStop
+func goAudioEncoderStop(enc *C.GstAudioEncoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).StopFunc(wrapCEncoder(enc)))
+}
+
+//export goAudioEncoderSetFormat
+func goAudioEncoderSetFormat(enc *C.GstAudioEncoder, info *C.GstAudioInfo) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).SetFormatFunc(wrapCEncoder(enc), FromGstAudioInfoUnsafe(unsafe.Pointer(info))))
+}
+
+//export goAudioEncoderHandleFrame
+func goAudioEncoderHandleFrame(enc *C.GstAudioEncoder, buffer *C.GstBuffer) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = C.GstFlowReturn(gst.FlowError) })
+	var buf *gst.Buffer
+	if buffer != nil {
+		buf = gst.FromGstBufferUnsafe(unsafe.Pointer(buffer))
+	}
+	return C.GstFlowReturn(getEncoderCallbacks(enc).HandleFrameFunc(wrapCEncoder(enc), buf))
+}
+
+//export goAudioEncoderFlush
+func goAudioEncoderFlush(enc *C.GstAudioEncoder) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), nil)
+	getEncoderCallbacks(enc).FlushFunc(wrapCEncoder(enc))
+}
+
+//export goAudioEncoderNegotiate
+func goAudioEncoderNegotiate(enc *C.GstAudioEncoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).NegotiateFunc(wrapCEncoder(enc)))
+}
+
+//export goAudioDecoderStart
+func goAudioDecoderStart(dec *C.GstAudioDecoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).StartFunc(wrapCDecoder(dec)))
+}
+
+//export goAudioDecoderStop
+func goAudioDecoderStop(dec *C.GstAudioDecoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).StopFunc(wrapCDecoder(dec)))
+}
+
+//export goAudioDecoderSetFormat
+func goAudioDecoderSetFormat(dec *C.GstAudioDecoder, caps *C.GstCaps) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).SetFormatFunc(wrapCDecoder(dec), gst.FromGstCapsUnsafe(unsafe.Pointer(caps))))
+}
+
+//export goAudioDecoderHandleFrame
+func goAudioDecoderHandleFrame(dec *C.GstAudioDecoder, buffer *C.GstBuffer) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = C.GstFlowReturn(gst.FlowError) })
+	var buf *gst.Buffer
+	if buffer != nil {
+		buf = gst.FromGstBufferUnsafe(unsafe.Pointer(buffer))
+	}
+	return C.GstFlowReturn(getDecoderCallbacks(dec).HandleFrameFunc(wrapCDecoder(dec), buf))
+}
+
+//export goAudioDecoderFlush
+func goAudioDecoderFlush(dec *C.GstAudioDecoder, hard C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), nil)
+	getDecoderCallbacks(dec).FlushFunc(wrapCDecoder(dec), gobool(hard))
+}
+
+//export goAudioDecoderNegotiate
+func goAudioDecoderNegotiate(dec *C.GstAudioDecoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).NegotiateFunc(wrapCDecoder(dec)))
+}
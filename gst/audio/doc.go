@@ -0,0 +1,5 @@
+// Package audio contains bindings for the gstaudio C API.
+//
+// This library should be linked to by getting cflags and libs from gstreamer-plugins-base-1.0.pc
+// and adding -lgstaudio-1.0 to the library flags.
+package audio
@@ -0,0 +1,2 @@
+#include <stdlib.h>
+#include <gst/audio/audio.h>
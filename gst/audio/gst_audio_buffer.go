@@ -0,0 +1,282 @@
+package audio
+
+/*
+#include <gst/audio/audio.h>
+
+GstAudioBuffer * newAudioBuffer       (void)                         { return g_new0(GstAudioBuffer, 1); }
+GstAudioInfo *   audioBufferInfo      (GstAudioBuffer * buf)         { return &buf->info; }
+gsize            audioBufferNSamples  (GstAudioBuffer * buf)         { return GST_AUDIO_BUFFER_N_SAMPLES(buf); }
+gint             audioBufferNPlanes   (GstAudioBuffer * buf)         { return GST_AUDIO_BUFFER_N_PLANES(buf); }
+gsize            audioBufferPlaneSize (GstAudioBuffer * buf)         { return GST_AUDIO_BUFFER_PLANE_SIZE(buf); }
+gpointer         audioBufferPlaneData (GstAudioBuffer * buf, gint i) { return GST_AUDIO_BUFFER_PLANE_DATA(buf, i); }
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// maxSamples is the largest number of samples a typed slice over a mapped plane can hold.
+const maxSamples = 1 << 27
+
+// Buffer is a go representation of a GstAudioBuffer. It is a gst.Buffer mapped for access to its samples
+// as described by an Info. Both interleaved buffers and non-interleaved buffers, whose planes are described
+// by a Meta, are supported.
+//
+// The typed accessors, e.g. S16 and F32, return slices pointing directly at the mapped memory. They are only
+// valid until Unmap is called.
+//
+//   sample := appsink.PullSample()
+//   buf, err := audio.MapSample(sample, gst.MapRead)
+//   if err != nil {
+//       panic(err)
+//   }
+//   defer buf.Unmap()
+//
+//   planes, err := buf.F32()
+//   if err != nil {
+//       panic(err)
+//   }
+//   for _, sample := range planes[0] {
+//       fmt.Println(sample)
+//   }
+type Buffer struct {
+	ptr *C.GstAudioBuffer
+}
+
+// MapBuffer maps the buffer with the given info and flags. If the buffer has a Meta, the info and plane
+// offsets of the meta are used instead. Unmap after usage.
+func MapBuffer(info *Info, buffer *gst.Buffer, flags gst.MapFlags) (*Buffer, error) {
+	ptr := C.newAudioBuffer()
+	if !gobool(C.gst_audio_buffer_map(
+		ptr,
+		info.instance(),
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.GstMapFlags(flags),
+	)) {
+		C.g_free((C.gpointer)(unsafe.Pointer(ptr)))
+		return nil, errors.New("Failed to map audio buffer")
+	}
+	return &Buffer{ptr: ptr}, nil
+}
+
+// MapSample maps the buffer of the sample using the audio info of its caps. Unmap after usage.
+func MapSample(sample *gst.Sample, flags gst.MapFlags) (*Buffer, error) {
+	cSample := (*C.GstSample)(unsafe.Pointer(sample.Instance()))
+	caps := C.gst_sample_get_caps(cSample)
+	buffer := C.gst_sample_get_buffer(cSample)
+	if caps == nil || buffer == nil {
+		return nil, errors.New("Sample has no caps or buffer")
+	}
+	info := NewInfo()
+	if !gobool(C.gst_audio_info_from_caps(info.instance(), caps)) {
+		return nil, errors.New("Sample does not contain raw audio")
+	}
+	return MapBuffer(info, gst.FromGstBufferUnsafe(unsafe.Pointer(buffer)), flags)
+}
+
+// Unmap unmaps the buffer. The slices returned from the buffer can no longer be used afterwards.
+func (b *Buffer) Unmap() {
+	if b.ptr == nil {
+		return
+	}
+	C.gst_audio_buffer_unmap(b.ptr)
+	C.g_free((C.gpointer)(unsafe.Pointer(b.ptr)))
+	b.ptr = nil
+}
+
+// Info returns a copy of the info the buffer was mapped with.
+func (b *Buffer) Info() *Info {
+	return wrapInfo(C.gst_audio_info_copy(C.audioBufferInfo(b.ptr)))
+}
+
+// Buffer returns the gst.Buffer that is mapped.
+func (b *Buffer) Buffer() *gst.Buffer {
+	return gst.FromGstBufferUnsafe(unsafe.Pointer(b.ptr.buffer))
+}
+
+// NumSamples returns the number of samples per channel in the buffer.
+func (b *Buffer) NumSamples() int { return int(C.audioBufferNSamples(b.ptr)) }
+
+// NumPlanes returns the number of planes in the buffer. This is 1 for interleaved buffers, and the number
+// of channels for non-interleaved ones.
+func (b *Buffer) NumPlanes() int { return int(C.audioBufferNPlanes(b.ptr)) }
+
+// PlaneSize returns the size of each plane in bytes.
+func (b *Buffer) PlaneSize() int { return int(C.audioBufferPlaneSize(b.ptr)) }
+
+// Plane returns the bytes of the plane at the given index.
+func (b *Buffer) Plane(idx int) []byte {
+	return unsafeBytes(b.planeData(idx), b.PlaneSize())
+}
+
+// Planes returns the bytes of every plane.
+func (b *Buffer) Planes() [][]byte {
+	out := make([][]byte, b.NumPlanes())
+	for i := range out {
+		out[i] = b.Plane(i)
+	}
+	return out
+}
+
+func (b *Buffer) planeData(idx int) unsafe.Pointer {
+	if idx < 0 || idx >= b.NumPlanes() {
+		panic(fmt.Sprintf("Plane %d out of range for buffer with %d planes", idx, b.NumPlanes()))
+	}
+	return unsafe.Pointer(C.audioBufferPlaneData(b.ptr, C.gint(idx)))
+}
+
+// planeLen returns the number of values of the given size in each plane, after checking that the buffer
+// is of the given format.
+func (b *Buffer) planeLen(format Format, size int) (int, error) {
+	if actual := Format(C.audioBufferInfo(b.ptr).finfo.format); actual != format {
+		return 0, fmt.Errorf("Buffer format is %s, not %s", actual, format)
+	}
+	return b.PlaneSize() / size, nil
+}
+
+// U8 returns the samples of each plane of a buffer of FormatU8. For interleaved buffers there is a single
+// plane holding the samples of all channels one after the other, otherwise there is one plane per channel.
+func (b *Buffer) U8() ([][]uint8, error) {
+	n, err := b.planeLen(FormatU8, 1)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]uint8, b.NumPlanes())
+	for i := range out {
+		out[i] = (*[maxSamples]uint8)(b.planeData(i))[:n:n]
+	}
+	return out, nil
+}
+
+// S16 returns the samples of each plane of a buffer of FormatS16. See U8 for the layout of the planes.
+func (b *Buffer) S16() ([][]int16, error) {
+	n, err := b.planeLen(FormatS16, 2)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]int16, b.NumPlanes())
+	for i := range out {
+		out[i] = (*[maxSamples]int16)(b.planeData(i))[:n:n]
+	}
+	return out, nil
+}
+
+// S32 returns the samples of each plane of a buffer of FormatS32. See U8 for the layout of the planes.
+func (b *Buffer) S32() ([][]int32, error) {
+	n, err := b.planeLen(FormatS32, 4)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]int32, b.NumPlanes())
+	for i := range out {
+		out[i] = (*[maxSamples]int32)(b.planeData(i))[:n:n]
+	}
+	return out, nil
+}
+
+// F32 returns the samples of each plane of a buffer of FormatF32. See U8 for the layout of the planes.
+func (b *Buffer) F32() ([][]float32, error) {
+	n, err := b.planeLen(FormatF32, 4)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]float32, b.NumPlanes())
+	for i := range out {
+		out[i] = (*[maxSamples]float32)(b.planeData(i))[:n:n]
+	}
+	return out, nil
+}
+
+// F64 returns the samples of each plane of a buffer of FormatF64. See U8 for the layout of the planes.
+func (b *Buffer) F64() ([][]float64, error) {
+	n, err := b.planeLen(FormatF64, 8)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]float64, b.NumPlanes())
+	for i := range out {
+		out[i] = (*[maxSamples]float64)(b.planeData(i))[:n:n]
+	}
+	return out, nil
+}
+
+// ClipBuffer clips the buffer to the given segment, which must be in gst.FormatTime or gst.FormatDefault.
+// The timestamp, duration, offset and offset end of the buffer are adjusted accordingly. The buffer is
+// taken, and the clipped buffer is returned, which may be the same buffer. nil is returned if the buffer is
+// completely outside of the segment.
+func ClipBuffer(buffer *gst.Buffer, segment *gst.Segment, rate, bpf int) *gst.Buffer {
+	clipped := C.gst_audio_buffer_clip(
+		(*C.GstBuffer)(buffer.TransferUnsafe()),
+		(*C.GstSegment)(unsafe.Pointer(segment.Instance())),
+		C.gint(rate),
+		C.gint(bpf),
+	)
+	if clipped == nil {
+		return nil
+	}
+	return gst.FromGstBufferUnsafeFull(unsafe.Pointer(clipped))
+}
+
+// TruncateBuffer truncates the buffer to finally have the given number of samples per channel, removing
+// trim samples from the start. If samples is -1, only trim samples are removed. The timestamp, duration,
+// offset and offset end are not adjusted. The buffer is taken, and the truncated buffer is returned.
+func TruncateBuffer(buffer *gst.Buffer, bpf int, trim, samples int64) *gst.Buffer {
+	truncated := C.gst_audio_buffer_truncate(
+		(*C.GstBuffer)(buffer.TransferUnsafe()),
+		C.gint(bpf),
+		C.gsize(trim),
+		C.gsize(samples),
+	)
+	if truncated == nil {
+		return nil
+	}
+	return gst.FromGstBufferUnsafeFull(unsafe.Pointer(truncated))
+}
+
+// ReorderBufferChannels reorders the channels of the buffer from the from positions to the to positions.
+// The buffer must be writable and both position slices must have the same length as the number of
+// channels.
+func ReorderBufferChannels(buffer *gst.Buffer, format Format, from, to []ChannelPosition) error {
+	if len(from) != len(to) || len(from) == 0 {
+		return errors.New("Channel positions must be of the same, non-zero, length")
+	}
+	cFrom, cTo := toCPositions(from), toCPositions(to)
+	if !gobool(C.gst_audio_buffer_reorder_channels(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.GstAudioFormat(format),
+		C.gint(len(from)),
+		&cFrom[0],
+		&cTo[0],
+	)) {
+		return errors.New("Failed to reorder channels")
+	}
+	return nil
+}
+
+// ReorderChannels reorders the channels of the interleaved samples in data from the from positions to
+// the to positions, in place.
+func ReorderChannels(data []byte, format Format, from, to []ChannelPosition) error {
+	if len(from) != len(to) || len(from) == 0 {
+		return errors.New("Channel positions must be of the same, non-zero, length")
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	cFrom, cTo := toCPositions(from), toCPositions(to)
+	if !gobool(C.gst_audio_reorder_channels(
+		C.gpointer(unsafe.Pointer(&data[0])),
+		C.gsize(len(data)),
+		C.GstAudioFormat(format),
+		C.gint(len(from)),
+		&cFrom[0],
+		&cTo[0],
+	)) {
+		return errors.New("Failed to reorder channels")
+	}
+	return nil
+}
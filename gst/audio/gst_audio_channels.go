@@ -0,0 +1,207 @@
+package audio
+
+// #include <gst/audio/audio.h>
+import "C"
+
+import "errors"
+
+// ChannelPosition is the audio channel position. These are the channels defined in SMPTE 2036-2-2008
+// Table 1 for 22.2 audio systems with the Surround and Wide channels from DTS Coherent Acoustics (v.1.3.1)
+// and 10.2 and 7.1 layouts.
+type ChannelPosition int
+
+// Type castings
+const (
+	ChannelPositionNone               ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_NONE                  // (-3) – used for position-less channels, e.g. from a sound card that records 1024 channels; mutually exclusive with any other channel position
+	ChannelPositionMono               ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_MONO                  // (-2) – Mono without direction; can only be used with 1 channel
+	ChannelPositionInvalid            ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_INVALID               // (-1) – invalid position
+	ChannelPositionFrontLeft          ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_FRONT_LEFT            // (0) – Front left
+	ChannelPositionFrontRight         ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_FRONT_RIGHT           // (1) – Front right
+	ChannelPositionFrontCenter        ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_FRONT_CENTER          // (2) – Front center
+	ChannelPositionLFE1               ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_LFE1                  // (3) – Low-frequency effects 1 (subwoofer)
+	ChannelPositionRearLeft           ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_REAR_LEFT             // (4) – Rear left
+	ChannelPositionRearRight          ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_REAR_RIGHT            // (5) – Rear right
+	ChannelPositionFrontLeftOfCenter  ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_FRONT_LEFT_OF_CENTER  // (6) – Front left of center
+	ChannelPositionFrontRightOfCenter ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_FRONT_RIGHT_OF_CENTER // (7) – Front right of center
+	ChannelPositionRearCenter         ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_REAR_CENTER           // (8) – Rear center
+	ChannelPositionLFE2               ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_LFE2                  // (9) – Low-frequency effects 2 (subwoofer)
+	ChannelPositionSideLeft           ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_SIDE_LEFT             // (10) – Side left
+	ChannelPositionSideRight          ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_SIDE_RIGHT            // (11) – Side right
+	ChannelPositionTopFrontLeft       ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_FRONT_LEFT        // (12) – Top front left
+	ChannelPositionTopFrontRight      ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_FRONT_RIGHT       // (13) – Top front right
+	ChannelPositionTopFrontCenter     ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_FRONT_CENTER      // (14) – Top front center
+	ChannelPositionTopCenter          ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_CENTER            // (15) – Top center
+	ChannelPositionTopRearLeft        ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_REAR_LEFT         // (16) – Top rear left
+	ChannelPositionTopRearRight       ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_REAR_RIGHT        // (17) – Top rear right
+	ChannelPositionTopSideLeft        ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_SIDE_LEFT         // (18) – Top side left
+	ChannelPositionTopSideRight       ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_SIDE_RIGHT        // (19) – Top side right
+	ChannelPositionTopRearCenter      ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_TOP_REAR_CENTER       // (20) – Top rear center
+	ChannelPositionBottomFrontCenter  ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_BOTTOM_FRONT_CENTER   // (21) – Bottom front center
+	ChannelPositionBottomFrontLeft    ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_BOTTOM_FRONT_LEFT     // (22) – Bottom front left
+	ChannelPositionBottomFrontRight   ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_BOTTOM_FRONT_RIGHT    // (23) – Bottom front right
+	ChannelPositionWideLeft           ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_WIDE_LEFT             // (24) – Wide left (between front left and side left)
+	ChannelPositionWideRight          ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_WIDE_RIGHT            // (25) – Wide right (between front right and side right)
+	ChannelPositionSurroundLeft       ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_SURROUND_LEFT         // (26) – Surround left (between rear left and side left)
+	ChannelPositionSurroundRight      ChannelPosition = C.GST_AUDIO_CHANNEL_POSITION_SURROUND_RIGHT        // (27) – Surround right (between rear right and side right)
+)
+
+// Mask returns the bit of the position in a channel mask, or 0 for positions that cannot be part of one.
+func (c ChannelPosition) Mask() uint64 {
+	if c < ChannelPositionFrontLeft {
+		return 0
+	}
+	return uint64(1) << uint(c)
+}
+
+// String implements a stringer on a ChannelPosition.
+func (c ChannelPosition) String() string {
+	switch c {
+	case ChannelPositionNone:
+		return "NONE"
+	case ChannelPositionMono:
+		return "MONO"
+	case ChannelPositionInvalid:
+		return "INVALID"
+	case ChannelPositionFrontLeft:
+		return "FRONT_LEFT"
+	case ChannelPositionFrontRight:
+		return "FRONT_RIGHT"
+	case ChannelPositionFrontCenter:
+		return "FRONT_CENTER"
+	case ChannelPositionLFE1:
+		return "LFE1"
+	case ChannelPositionRearLeft:
+		return "REAR_LEFT"
+	case ChannelPositionRearRight:
+		return "REAR_RIGHT"
+	case ChannelPositionFrontLeftOfCenter:
+		return "FRONT_LEFT_OF_CENTER"
+	case ChannelPositionFrontRightOfCenter:
+		return "FRONT_RIGHT_OF_CENTER"
+	case ChannelPositionRearCenter:
+		return "REAR_CENTER"
+	case ChannelPositionLFE2:
+		return "LFE2"
+	case ChannelPositionSideLeft:
+		return "SIDE_LEFT"
+	case ChannelPositionSideRight:
+		return "SIDE_RIGHT"
+	case ChannelPositionTopFrontLeft:
+		return "TOP_FRONT_LEFT"
+	case ChannelPositionTopFrontRight:
+		return "TOP_FRONT_RIGHT"
+	case ChannelPositionTopFrontCenter:
+		return "TOP_FRONT_CENTER"
+	case ChannelPositionTopCenter:
+		return "TOP_CENTER"
+	case ChannelPositionTopRearLeft:
+		return "TOP_REAR_LEFT"
+	case ChannelPositionTopRearRight:
+		return "TOP_REAR_RIGHT"
+	case ChannelPositionTopSideLeft:
+		return "TOP_SIDE_LEFT"
+	case ChannelPositionTopSideRight:
+		return "TOP_SIDE_RIGHT"
+	case ChannelPositionTopRearCenter:
+		return "TOP_REAR_CENTER"
+	case ChannelPositionBottomFrontCenter:
+		return "BOTTOM_FRONT_CENTER"
+	case ChannelPositionBottomFrontLeft:
+		return "BOTTOM_FRONT_LEFT"
+	case ChannelPositionBottomFrontRight:
+		return "BOTTOM_FRONT_RIGHT"
+	case ChannelPositionWideLeft:
+		return "WIDE_LEFT"
+	case ChannelPositionWideRight:
+		return "WIDE_RIGHT"
+	case ChannelPositionSurroundLeft:
+		return "SURROUND_LEFT"
+	case ChannelPositionSurroundRight:
+		return "SURROUND_RIGHT"
+	}
+	return ""
+}
+
+// toCPositions converts the given positions to a C array that can be passed to GStreamer.
+func toCPositions(positions []ChannelPosition) []C.GstAudioChannelPosition {
+	out := make([]C.GstAudioChannelPosition, len(positions))
+	for i, p := range positions {
+		out[i] = C.GstAudioChannelPosition(p)
+	}
+	return out
+}
+
+func fromCPositions(positions []C.GstAudioChannelPosition) []ChannelPosition {
+	out := make([]ChannelPosition, len(positions))
+	for i, p := range positions {
+		out[i] = ChannelPosition(p)
+	}
+	return out
+}
+
+// ChannelPositionsToMask converts the positions to a channel mask, as used in the channel-mask field of
+// caps. If forceOrder is true, the positions must be in the valid order of GStreamer, see
+// ChannelPositionsToValidOrder.
+func ChannelPositionsToMask(positions []ChannelPosition, forceOrder bool) (uint64, error) {
+	if len(positions) == 0 {
+		return 0, nil
+	}
+	var mask C.guint64
+	cPositions := toCPositions(positions)
+	if !gobool(C.gst_audio_channel_positions_to_mask(&cPositions[0], C.gint(len(cPositions)), gboolean(forceOrder), &mask)) {
+		return 0, errors.New("Invalid channel positions")
+	}
+	return uint64(mask), nil
+}
+
+// ChannelPositionsFromMask converts the channel mask to positions for the given number of channels.
+func ChannelPositionsFromMask(channels int, mask uint64) ([]ChannelPosition, error) {
+	if channels <= 0 {
+		return nil, nil
+	}
+	cPositions := make([]C.GstAudioChannelPosition, channels)
+	if !gobool(C.gst_audio_channel_positions_from_mask(C.gint(channels), C.guint64(mask), &cPositions[0])) {
+		return nil, errors.New("Invalid channel mask")
+	}
+	return fromCPositions(cPositions), nil
+}
+
+// ChannelPositionsToValidOrder returns the positions reordered to the order GStreamer expects for the
+// channels of raw audio.
+func ChannelPositionsToValidOrder(positions []ChannelPosition) ([]ChannelPosition, error) {
+	if len(positions) == 0 {
+		return nil, nil
+	}
+	cPositions := toCPositions(positions)
+	if !gobool(C.gst_audio_channel_positions_to_valid_order(&cPositions[0], C.gint(len(cPositions)))) {
+		return nil, errors.New("Invalid channel positions")
+	}
+	return fromCPositions(cPositions), nil
+}
+
+// CheckValidChannelPositions returns true if the positions are valid. If forceOrder is true, they also
+// need to be in the valid order of GStreamer.
+func CheckValidChannelPositions(positions []ChannelPosition, forceOrder bool) bool {
+	if len(positions) == 0 {
+		return false
+	}
+	cPositions := toCPositions(positions)
+	return gobool(C.gst_audio_check_valid_channel_positions(&cPositions[0], C.gint(len(cPositions)), gboolean(forceOrder)))
+}
+
+// GetFallbackChannelMask returns the channel mask GStreamer assumes for the given number of channels when
+// the caps do not contain one.
+func GetFallbackChannelMask(channels int) uint64 {
+	return uint64(C.gst_audio_channel_get_fallback_mask(C.gint(channels)))
+}
+
+// ChannelPositionsString returns a string representation of the positions, for debugging.
+func ChannelPositionsString(positions []ChannelPosition) string {
+	if len(positions) == 0 {
+		return "[]"
+	}
+	cPositions := toCPositions(positions)
+	str := C.gst_audio_channel_positions_to_string(&cPositions[0], C.gint(len(cPositions)))
+	defer C.g_free((C.gpointer)(str))
+	return C.GoString(str)
+}
@@ -0,0 +1,418 @@
+package audio
+
+/*
+#include <stdlib.h>
+#include <gst/audio/audio.h>
+
+GType resamplerMethodType    (void) { return GST_TYPE_AUDIO_RESAMPLER_METHOD; }
+GType ditherMethodType       (void) { return GST_TYPE_AUDIO_DITHER_METHOD; }
+GType noiseShapingMethodType (void) { return GST_TYPE_AUDIO_NOISE_SHAPING_METHOD; }
+
+void setConfigEnum (GstStructure * config, const gchar * name, GType type, gint value)
+{
+	gst_structure_set(config, name, type, value, NULL);
+}
+
+void setConfigUint (GstStructure * config, const gchar * name, guint value)
+{
+	gst_structure_set(config, name, G_TYPE_UINT, value, NULL);
+}
+
+void setConfigMixMatrix (GstStructure * config, gfloat * matrix, gint outChannels, gint inChannels)
+{
+	GValue mix = G_VALUE_INIT;
+	gint i, j;
+
+	g_value_init(&mix, GST_TYPE_ARRAY);
+	for (i = 0; i < outChannels; i++) {
+		GValue row = G_VALUE_INIT;
+		g_value_init(&row, GST_TYPE_ARRAY);
+		for (j = 0; j < inChannels; j++) {
+			GValue val = G_VALUE_INIT;
+			g_value_init(&val, G_TYPE_FLOAT);
+			g_value_set_float(&val, matrix[i * inChannels + j]);
+			gst_value_array_append_and_take_value(&row, &val);
+		}
+		gst_value_array_append_and_take_value(&mix, &row);
+	}
+	gst_structure_take_value(config, GST_AUDIO_CONVERTER_OPT_MIX_MATRIX, &mix);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// DitherMethod is the dither method to use when converting to a lower sample depth.
+type DitherMethod int
+
+// Type castings
+const (
+	DitherNone   DitherMethod = C.GST_AUDIO_DITHER_NONE    // (0) – No dithering
+	DitherRPDF   DitherMethod = C.GST_AUDIO_DITHER_RPDF    // (1) – Rectangular dithering
+	DitherTPDF   DitherMethod = C.GST_AUDIO_DITHER_TPDF    // (2) – Triangular dithering (default)
+	DitherTPDFHF DitherMethod = C.GST_AUDIO_DITHER_TPDF_HF // (3) – High frequency triangular dithering
+)
+
+// NoiseShapingMethod is the noise shaping method to use when converting to a lower sample depth.
+type NoiseShapingMethod int
+
+// Type castings
+const (
+	NoiseShapingNone          NoiseShapingMethod = C.GST_AUDIO_NOISE_SHAPING_NONE           // (0) – No noise shaping (default)
+	NoiseShapingErrorFeedback NoiseShapingMethod = C.GST_AUDIO_NOISE_SHAPING_ERROR_FEEDBACK // (1) – Error feedback
+	NoiseShapingSimple        NoiseShapingMethod = C.GST_AUDIO_NOISE_SHAPING_SIMPLE         // (2) – Simple 2-pole noise shaping
+	NoiseShapingMedium        NoiseShapingMethod = C.GST_AUDIO_NOISE_SHAPING_MEDIUM         // (3) – Medium 5-pole noise shaping
+	NoiseShapingHigh          NoiseShapingMethod = C.GST_AUDIO_NOISE_SHAPING_HIGH           // (4) – High 8-pole noise shaping
+)
+
+// ConverterFlags are extra flags passed to NewConverter.
+type ConverterFlags int
+
+// Type castings
+const (
+	ConverterFlagNone         ConverterFlags = C.GST_AUDIO_CONVERTER_FLAG_NONE          // (0) – no flag
+	ConverterFlagInWritable   ConverterFlags = C.GST_AUDIO_CONVERTER_FLAG_IN_WRITABLE   // (1) – the input sample arrays are writable and can be used as temporary storage during conversion.
+	ConverterFlagVariableRate ConverterFlags = C.GST_AUDIO_CONVERTER_FLAG_VARIABLE_RATE // (2) – allow arbitrary rate updates with UpdateConfig.
+)
+
+// ConverterConfig holds the options of a Converter. Options that are not set keep their defaults. It
+// can be built by chaining the setters to NewConverterConfig.
+//
+//   config := audio.NewConverterConfig().
+//       WithDitherMethod(audio.DitherNone).
+//       WithResamplerMethod(audio.ResamplerMethodKaiser).
+//       WithResamplerQuality(audio.ResamplerQualityMax)
+type ConverterConfig struct {
+	resamplerMethod  *ResamplerMethod
+	resamplerQuality *int
+	ditherMethod     *DitherMethod
+	noiseShaping     *NoiseShapingMethod
+	quantization     *uint
+	mixMatrix        [][]float32
+}
+
+// NewConverterConfig returns a new empty ConverterConfig.
+func NewConverterConfig() *ConverterConfig { return &ConverterConfig{} }
+
+// WithResamplerMethod sets the method used for resampling.
+func (c *ConverterConfig) WithResamplerMethod(method ResamplerMethod) *ConverterConfig {
+	c.resamplerMethod = &method
+	return c
+}
+
+// WithResamplerQuality sets the quality of the resampler, from ResamplerQualityMin to ResamplerQualityMax.
+func (c *ConverterConfig) WithResamplerQuality(quality int) *ConverterConfig {
+	c.resamplerQuality = &quality
+	return c
+}
+
+// WithDitherMethod sets the dither method used when converting to a lower sample depth.
+func (c *ConverterConfig) WithDitherMethod(method DitherMethod) *ConverterConfig {
+	c.ditherMethod = &method
+	return c
+}
+
+// WithNoiseShaping sets the noise shaping method used when converting to a lower sample depth.
+func (c *ConverterConfig) WithNoiseShaping(method NoiseShapingMethod) *ConverterConfig {
+	c.noiseShaping = &method
+	return c
+}
+
+// WithQuantization sets the quantization step of the output samples. The default of 1 means no
+// additional quantization.
+func (c *ConverterConfig) WithQuantization(quantization uint) *ConverterConfig {
+	c.quantization = &quantization
+	return c
+}
+
+// WithMixMatrix sets the matrix used to mix the input channels into the output channels. It must have a
+// row for every output channel, each holding the gain of every input channel.
+func (c *ConverterConfig) WithMixMatrix(matrix [][]float32) *ConverterConfig {
+	c.mixMatrix = matrix
+	return c
+}
+
+// toStructure returns a new GstStructure holding the options for converting between the given rates.
+func (c *ConverterConfig) toStructure(inRate, outRate int) (*C.GstStructure, error) {
+	name := C.CString("GstAudioConverter")
+	defer C.free(unsafe.Pointer(name))
+	config := C.gst_structure_new_empty(name)
+	if c == nil {
+		return config, nil
+	}
+	if c.resamplerMethod != nil {
+		setConfigEnum(config, C.GST_AUDIO_CONVERTER_OPT_RESAMPLER_METHOD, C.resamplerMethodType(), int(*c.resamplerMethod))
+	}
+	if c.resamplerQuality != nil {
+		method := ResamplerMethodBlackmanNuttall
+		if c.resamplerMethod != nil {
+			method = *c.resamplerMethod
+		}
+		C.gst_audio_resampler_options_set_quality(
+			C.GstAudioResamplerMethod(method),
+			C.guint(*c.resamplerQuality),
+			C.gint(inRate),
+			C.gint(outRate),
+			config,
+		)
+	}
+	if c.ditherMethod != nil {
+		setConfigEnum(config, C.GST_AUDIO_CONVERTER_OPT_DITHER_METHOD, C.ditherMethodType(), int(*c.ditherMethod))
+	}
+	if c.noiseShaping != nil {
+		setConfigEnum(config, C.GST_AUDIO_CONVERTER_OPT_NOISE_SHAPING_METHOD, C.noiseShapingMethodType(), int(*c.noiseShaping))
+	}
+	if c.quantization != nil {
+		cName := C.CString(C.GST_AUDIO_CONVERTER_OPT_QUANTIZATION)
+		defer C.free(unsafe.Pointer(cName))
+		C.setConfigUint(config, cName, C.guint(*c.quantization))
+	}
+	if len(c.mixMatrix) > 0 {
+		inChannels := len(c.mixMatrix[0])
+		matrix := make([]C.gfloat, 0, len(c.mixMatrix)*inChannels)
+		for _, row := range c.mixMatrix {
+			if len(row) != inChannels || inChannels == 0 {
+				C.gst_structure_free(config)
+				return nil, errors.New("Every row of the mix matrix must have the same, non-zero, length")
+			}
+			for _, gain := range row {
+				matrix = append(matrix, C.gfloat(gain))
+			}
+		}
+		C.setConfigMixMatrix(config, &matrix[0], C.gint(len(c.mixMatrix)), C.gint(inChannels))
+	}
+	return config, nil
+}
+
+func setConfigEnum(config *C.GstStructure, name string, gtype C.GType, value int) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.setConfigEnum(config, cName, gtype, C.gint(value))
+}
+
+// Converter is a go representation of a GstAudioConverter. It converts raw audio between formats,
+// layouts, channel configurations and rates without the need for a pipeline.
+//
+//   in, _ := audio.NewInfo().WithFormat(audio.FormatS16, 48000, 2, nil)
+//   out, _ := audio.NewInfo().WithFormat(audio.FormatF32, 16000, 1, nil)
+//   converter, err := audio.NewConverter(audio.ConverterFlagNone, in, out, nil)
+//   if err != nil {
+//       panic(err)
+//   }
+//   defer converter.Free()
+//
+//   samples, err := converter.ConvertSamples(pcm) // pcm is an []int16 of interleaved samples
+//   if err != nil {
+//       panic(err)
+//   }
+//   mono := samples.([]float32)
+type Converter struct {
+	ptr     *C.GstAudioConverter
+	inInfo  *Info
+	outInfo *Info
+}
+
+// NewConverter creates a new Converter converting from inInfo to outInfo with the given config, which
+// may be nil to use the defaults.
+func NewConverter(flags ConverterFlags, inInfo, outInfo *Info, config *ConverterConfig) (*Converter, error) {
+	cConfig, err := config.toStructure(inInfo.Rate(), outInfo.Rate())
+	if err != nil {
+		return nil, err
+	}
+	conv := C.gst_audio_converter_new(
+		C.GstAudioConverterFlags(flags),
+		inInfo.instance(),
+		outInfo.instance(),
+		cConfig,
+	)
+	if conv == nil {
+		return nil, errors.New("Could not create a converter for the given infos")
+	}
+	converter := &Converter{ptr: conv, inInfo: inInfo.Copy(), outInfo: outInfo.Copy()}
+	runtime.SetFinalizer(converter, (*Converter).Free)
+	return converter, nil
+}
+
+// Free frees the resources of the converter. It cannot be used afterwards.
+func (c *Converter) Free() {
+	if c.ptr == nil {
+		return
+	}
+	C.gst_audio_converter_free(c.ptr)
+	c.ptr = nil
+}
+
+// InInfo returns the info of the input samples of the converter.
+func (c *Converter) InInfo() *Info { return c.inInfo.Copy() }
+
+// OutInfo returns the info of the output samples of the converter.
+func (c *Converter) OutInfo() *Info { return c.outInfo.Copy() }
+
+// GetOutFrames returns the number of output frames produced when converting inFrames input frames.
+func (c *Converter) GetOutFrames(inFrames int64) int64 {
+	return int64(C.gst_audio_converter_get_out_frames(c.ptr, C.gsize(inFrames)))
+}
+
+// GetInFrames returns the number of input frames needed to produce outFrames output frames.
+func (c *Converter) GetInFrames(outFrames int64) int64 {
+	return int64(C.gst_audio_converter_get_in_frames(c.ptr, C.gsize(outFrames)))
+}
+
+// GetMaxLatency returns the maximum number of input frames the converter will need before producing
+// output frames.
+func (c *Converter) GetMaxLatency() int64 {
+	return int64(C.gst_audio_converter_get_max_latency(c.ptr))
+}
+
+// Reset resets the converter to its initial state, dropping any samples it holds on to.
+func (c *Converter) Reset() { C.gst_audio_converter_reset(c.ptr) }
+
+// IsPassthrough returns true if the converter does not modify the samples.
+func (c *Converter) IsPassthrough() bool { return gobool(C.gst_audio_converter_is_passthrough(c.ptr)) }
+
+// SupportsInplace returns true if the converter can convert samples in place.
+func (c *Converter) SupportsInplace() bool {
+	return gobool(C.gst_audio_converter_supports_inplace(c.ptr))
+}
+
+// UpdateConfig sets new rates and a new config on the converter. A rate of 0 keeps the current one, and
+// config may be nil to keep the current options. Changing the rates requires ConverterFlagVariableRate to
+// have been passed to NewConverter. The infos returned by InInfo and OutInfo are updated with the new rates.
+func (c *Converter) UpdateConfig(inRate, outRate int, config *ConverterConfig) error {
+	if inRate <= 0 {
+		inRate = c.inInfo.Rate()
+	}
+	if outRate <= 0 {
+		outRate = c.outInfo.Rate()
+	}
+	var cConfig *C.GstStructure
+	if config != nil {
+		var err error
+		if cConfig, err = config.toStructure(inRate, outRate); err != nil {
+			return err
+		}
+	}
+	if !gobool(C.gst_audio_converter_update_config(c.ptr, C.gint(inRate), C.gint(outRate), cConfig)) {
+		return errors.New("Failed to update the converter config")
+	}
+	c.inInfo.setRate(inRate)
+	c.outInfo.setRate(outRate)
+	return nil
+}
+
+// numPlanes returns the number of planes of samples described by info.
+func numPlanes(info *Info) int {
+	if info.Layout() == LayoutNonInterleaved {
+		return info.Channels()
+	}
+	return 1
+}
+
+// planeSize returns the size in bytes of each plane holding the given number of frames of info.
+func planeSize(info *Info, frames int64) int {
+	if info.Layout() == LayoutNonInterleaved {
+		return int(frames) * info.BPS()
+	}
+	return int(frames) * info.BPF()
+}
+
+// ConvertPlanes converts the planes of input samples and returns the planes of output samples. For
+// interleaved input there must be a single plane, otherwise one plane per channel.
+func (c *Converter) ConvertPlanes(planes [][]byte) ([][]byte, error) {
+	if len(planes) != numPlanes(c.inInfo) {
+		return nil, fmt.Errorf("Expected %d input planes, got %d", numPlanes(c.inInfo), len(planes))
+	}
+	inFrames := int64(len(planes[0]))
+	if c.inInfo.Layout() == LayoutNonInterleaved {
+		inFrames /= int64(c.inInfo.BPS())
+	} else {
+		inFrames = c.inInfo.BytesToSamples(inFrames)
+	}
+	for _, plane := range planes {
+		if len(plane) != planeSize(c.inInfo, inFrames) {
+			return nil, errors.New("Input planes must hold the same number of whole frames")
+		}
+	}
+	outFrames := c.GetOutFrames(inFrames)
+	in := newCPlanes(len(planes), len(planes[0]), planes)
+	defer in.free()
+	out := newCPlanes(numPlanes(c.outInfo), planeSize(c.outInfo, outFrames), nil)
+	defer out.free()
+	if !gobool(C.gst_audio_converter_samples(
+		c.ptr,
+		C.GST_AUDIO_CONVERTER_FLAG_NONE,
+		in.ptr, C.gsize(inFrames),
+		out.ptr, C.gsize(outFrames),
+	)) {
+		return nil, errors.New("Failed to convert samples")
+	}
+	return out.bytes(planeSize(c.outInfo, outFrames)), nil
+}
+
+// Convert converts the bytes of interleaved input samples and returns the bytes of interleaved
+// output samples.
+func (c *Converter) Convert(data []byte) ([]byte, error) {
+	if c.inInfo.Layout() != LayoutInterleaved || c.outInfo.Layout() != LayoutInterleaved {
+		return nil, errors.New("Convert requires interleaved input and output, use ConvertPlanes instead")
+	}
+	out, err := c.ConvertPlanes([][]byte{data})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+// ConvertSamples converts the given samples, which must be a slice of the go type matching the input
+// format for interleaved input, e.g. []int16 for FormatS16, or a slice of such slices with one per channel
+// for non-interleaved input. The output samples are returned the same way, matching the output info.
+// Only FormatU8, FormatS16, FormatS32, FormatF32 and FormatF64 in native endianness have go types.
+func (c *Converter) ConvertSamples(samples interface{}) (interface{}, error) {
+	planes, err := samplesToPlanes(samples, c.inInfo.Format())
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.ConvertPlanes(planes)
+	if err != nil {
+		return nil, err
+	}
+	return planesToSamples(out, c.outInfo.Format(), c.outInfo.Layout())
+}
+
+// ConvertBuffer converts the samples in the given buffer and returns a new buffer holding the output
+// samples. The metadata of the buffer, e.g. its timestamps, is copied to the new buffer, and a Meta is
+// added to it for non-interleaved output.
+func (c *Converter) ConvertBuffer(buffer *gst.Buffer) (*gst.Buffer, error) {
+	mapped, err := MapBuffer(c.inInfo, buffer, gst.MapRead)
+	if err != nil {
+		return nil, err
+	}
+	defer mapped.Unmap()
+	planes, err := c.ConvertPlanes(mapped.Planes())
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, len(planes)*len(planes[0]))
+	for _, plane := range planes {
+		data = append(data, plane...)
+	}
+	out := gst.NewBufferFromBytes(data)
+	cOut := (*C.GstBuffer)(unsafe.Pointer(out.Instance()))
+	C.gst_buffer_copy_into(cOut, (*C.GstBuffer)(unsafe.Pointer(buffer.Instance())), C.GST_BUFFER_COPY_METADATA, 0, ^C.gsize(0))
+	// the audio meta of the input buffer no longer describes the samples
+	if meta := C.gst_buffer_get_audio_meta(cOut); meta != nil {
+		C.gst_buffer_remove_meta(cOut, (*C.GstMeta)(unsafe.Pointer(meta)))
+	}
+	if c.outInfo.Layout() == LayoutNonInterleaved {
+		if _, err := AddMeta(out, c.outInfo, c.outInfo.BytesToSamples(int64(len(data))), nil); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
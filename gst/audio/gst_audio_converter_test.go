@@ -0,0 +1,103 @@
+package audio
+
+import "testing"
+
+func newTestInfo(t *testing.T, format Format, rate, channels int) *Info {
+	t.Helper()
+	info, err := NewInfo().WithFormat(format, rate, channels, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
+
+func TestConverterConvertSamples(t *testing.T) {
+	converter, err := NewConverter(ConverterFlagNone, newTestInfo(t, FormatS16, 48000, 2), newTestInfo(t, FormatF32, 48000, 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer converter.Free()
+
+	out, err := converter.ConvertSamples(make([]int16, 2*480))
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples, ok := out.([]float32)
+	if !ok {
+		t.Fatalf("Expected []float32 samples, got %T", out)
+	}
+	if len(samples) != 480 {
+		t.Fatalf("Expected 480 output samples, got %d", len(samples))
+	}
+}
+
+func TestConverterRejectsMismatchedSamples(t *testing.T) {
+	converter, err := NewConverter(ConverterFlagNone, newTestInfo(t, FormatS16, 48000, 2), newTestInfo(t, FormatF32, 48000, 2), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer converter.Free()
+
+	if _, err := converter.ConvertSamples(make([]float32, 2*480)); err == nil {
+		t.Error("Expected an error for samples not matching the input format")
+	}
+	if _, err := converter.ConvertSamples(make([]string, 2)); err == nil {
+		t.Error("Expected an error for an unsupported sample type")
+	}
+	if _, err := converter.ConvertPlanes([][]byte{make([]byte, 4), make([]byte, 4)}); err == nil {
+		t.Error("Expected an error for too many planes")
+	}
+	if _, err := converter.ConvertPlanes([][]byte{make([]byte, 5)}); err == nil {
+		t.Error("Expected an error for a partial frame")
+	}
+}
+
+func TestConverterUpdateConfigUpdatesInfo(t *testing.T) {
+	converter, err := NewConverter(ConverterFlagVariableRate, newTestInfo(t, FormatF32, 48000, 1), newTestInfo(t, FormatF32, 48000, 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer converter.Free()
+
+	if err := converter.UpdateConfig(0, 16000, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rate := converter.InInfo().Rate(); rate != 48000 {
+		t.Errorf("Expected the input rate to be kept, got %d", rate)
+	}
+	if rate := converter.OutInfo().Rate(); rate != 16000 {
+		t.Errorf("Expected the output rate to be updated, got %d", rate)
+	}
+}
+
+func TestResamplerSamplesAndDrain(t *testing.T) {
+	resampler, err := NewResampler(ResamplerMethodLinear, ResamplerFlagNone, FormatF32, 2, 48000, 16000, ResamplerQualityDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resampler.Free()
+
+	if _, err := resampler.ResampleSamples(make([]int16, 2*480)); err == nil {
+		t.Error("Expected an error for samples not matching the resampler format")
+	}
+	if _, err := resampler.ResampleSamples([][]float32{make([]float32, 480), make([]float32, 480)}); err == nil {
+		t.Error("Expected an error for non-interleaved samples on an interleaved resampler")
+	}
+
+	out, err := resampler.ResampleSamples(make([]float32, 2*480))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if samples := out.([]float32); len(samples)%2 != 0 {
+		t.Fatalf("Expected whole frames of output, got %d samples", len(samples))
+	}
+
+	expected := resampler.GetOutFrames(resampler.GetMaxLatency())
+	drained, err := resampler.DrainSamples()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if samples := drained.([]float32); int64(len(samples)) != 2*expected {
+		t.Fatalf("Expected %d drained samples, got %d", 2*expected, len(samples))
+	}
+}
@@ -0,0 +1,195 @@
+package audio
+
+/*
+#include "gst.go.h"
+
+extern gboolean      goAudioDecoderStart       (GstAudioDecoder * dec);
+extern gboolean      goAudioDecoderStop        (GstAudioDecoder * dec);
+extern gboolean      goAudioDecoderSetFormat   (GstAudioDecoder * dec, GstCaps * caps);
+extern GstFlowReturn goAudioDecoderHandleFrame (GstAudioDecoder * dec, GstBuffer * buffer);
+extern void          goAudioDecoderFlush       (GstAudioDecoder * dec, gboolean hard);
+extern gboolean      goAudioDecoderNegotiate   (GstAudioDecoder * dec);
+
+#define GO_ADEC_PARENT_CLASS (GST_AUDIO_DECODER_CLASS(g_type_class_peek(GST_TYPE_AUDIO_DECODER)))
+
+gboolean parentAudioDecoderNegotiate (GstAudioDecoder * dec) { return GO_ADEC_PARENT_CLASS->negotiate(dec); }
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// DecoderCallbacks are the functions implementing a Decoder registered with RegisterDecoder. Any of them
+// can be nil, in which case the default GstAudioDecoder implementation is used, though every decoder
+// needs at least SetFormatFunc and HandleFrameFunc.
+type DecoderCallbacks struct {
+	// StartFunc is called when the decoder starts processing, before any data is passed to it. It is the
+	// place to allocate the resources of the decoder, e.g. with SetInstanceData.
+	StartFunc func(self *Decoder) bool
+	// StopFunc is called when the decoder stops processing. It is the place to free the resources of the
+	// decoder.
+	StopFunc func(self *Decoder) bool
+	// SetFormatFunc is called with the caps of the encoded input whenever they change. The decoder should
+	// configure itself accordingly and call SetOutputFormat with the info of the decoded samples.
+	SetFormatFunc func(self *Decoder, caps *gst.Caps) bool
+	// HandleFrameFunc decodes the given encoded frame. The decoder does not own the buffer, and should call
+	// FinishFrame with the decoded samples. The buffer is nil when the decoder should drain and finish any
+	// data it still holds on to.
+	HandleFrameFunc func(self *Decoder, buffer *gst.Buffer) gst.FlowReturn
+	// FlushFunc is called when the decoder should drop any data it holds on to, e.g. on a seek. hard is
+	// false when the flush is only due to a discontinuity, in which case the decoder may keep its state.
+	FlushFunc func(self *Decoder, hard bool)
+	// NegotiateFunc negotiates with downstream elements. The default implementation, which can be called
+	// with ParentNegotiate, uses the info set with SetOutputFormat.
+	NegotiateFunc func(self *Decoder) bool
+}
+
+// classInit installs the implemented virtual methods on the class of a registered decoder.
+func (d *DecoderCallbacks) classInit(klass unsafe.Pointer) {
+	class := (*C.GstAudioDecoderClass)(klass)
+	if d.StartFunc != nil {
+		class.start = (*[0]byte)(C.goAudioDecoderStart)
+	}
+	if d.StopFunc != nil {
+		class.stop = (*[0]byte)(C.goAudioDecoderStop)
+	}
+	if d.SetFormatFunc != nil {
+		class.set_format = (*[0]byte)(C.goAudioDecoderSetFormat)
+	}
+	if d.HandleFrameFunc != nil {
+		class.handle_frame = (*[0]byte)(C.goAudioDecoderHandleFrame)
+	}
+	if d.FlushFunc != nil {
+		class.flush = (*[0]byte)(C.goAudioDecoderFlush)
+	}
+	if d.NegotiateFunc != nil {
+		class.negotiate = (*[0]byte)(C.goAudioDecoderNegotiate)
+	}
+}
+
+// Decoder is a go representation of a GstAudioDecoder. It is passed to the DecoderCallbacks of a
+// decoder registered with RegisterDecoder. The base class takes care of timestamps, latency, tags and
+// packet loss concealment.
+type Decoder struct{ *gst.Element }
+
+// RegisterDecoder registers a new decoder element with the given name and rank, implemented by the given
+// callbacks. sinkCaps are the caps of the encoded audio it accepts, and srcCaps the caps of the raw audio
+// it produces. plugin may be nil to register the element statically, as is usual for applications. Once
+// registered, the element can be created by name like any other, e.g. with gst.NewElement, and is picked
+// up by decodebin when its rank is high enough.
+//
+//   audio.RegisterDecoder(nil, "myspeechdec", gst.RankPrimary, &gst.ElementMetadata{
+//       LongName:    "Speech decoder",
+//       Klass:       "Codec/Decoder/Audio",
+//       Description: "Decodes speech encoded with our in-house codec",
+//       Author:      "Jane Doe <jane@example.com>",
+//   }, gst.NewCapsFromString("audio/x-speech"),
+//       gst.NewCapsFromString("audio/x-raw, format=S16LE, rate=16000, channels=1"),
+//       &audio.DecoderCallbacks{
+//           SetFormatFunc: func(self *audio.Decoder, caps *gst.Caps) bool {
+//               info, _ := audio.NewInfo().WithFormat(audio.FormatS16LE, 16000, 1, nil)
+//               return self.SetOutputFormat(info)
+//           },
+//           HandleFrameFunc: func(self *audio.Decoder, buffer *gst.Buffer) gst.FlowReturn {
+//               if buffer == nil {
+//                   return gst.FlowOK
+//               }
+//               return self.FinishFrame(gst.NewBufferFromBytes(decode(buffer.Bytes())), 1)
+//           },
+//       })
+func RegisterDecoder(plugin *gst.Plugin, name string, rank gst.Rank, metadata *gst.ElementMetadata, sinkCaps, srcCaps *gst.Caps, cbs *DecoderCallbacks) error {
+	return gst.RegisterGoElementUnsafe(plugin, name, rank, glib.Type(C.GST_TYPE_AUDIO_DECODER), metadata, sinkCaps, srcCaps, cbs, cbs.classInit)
+}
+
+// DecoderFromElement returns the Decoder of the given element, or nil if it is not a decoder registered
+// with RegisterDecoder.
+func DecoderFromElement(elem *gst.Element) *Decoder {
+	if _, ok := gst.GoElementCallbacksUnsafe(elem.Unsafe()).(*DecoderCallbacks); !ok {
+		return nil
+	}
+	return &Decoder{elem}
+}
+
+func wrapCDecoder(dec *C.GstAudioDecoder) *Decoder {
+	return &Decoder{gst.FromGstElementUnsafe(unsafe.Pointer(dec))}
+}
+
+func getDecoderCallbacks(dec *C.GstAudioDecoder) *DecoderCallbacks {
+	return gst.GoElementCallbacksUnsafe(unsafe.Pointer(dec)).(*DecoderCallbacks)
+}
+
+// Instance returns the underlying GstAudioDecoder instance.
+func (d *Decoder) Instance() *C.GstAudioDecoder { return (*C.GstAudioDecoder)(d.Unsafe()) }
+
+// SetInstanceData stores the given value with this decoder instance, e.g. the state of the codec. Any
+// previous value is released, and the last one is released when the decoder is finalized.
+func (d *Decoder) SetInstanceData(data interface{}) { gst.SetGoElementDataUnsafe(d.Unsafe(), data) }
+
+// InstanceData returns the value stored with SetInstanceData, or nil if there is none.
+func (d *Decoder) InstanceData() interface{} { return gst.GoElementDataUnsafe(d.Unsafe()) }
+
+// GetAudioInfo returns a copy of the info of the output samples.
+func (d *Decoder) GetAudioInfo() *Info {
+	return FromGstAudioInfoUnsafe(unsafe.Pointer(C.gst_audio_decoder_get_audio_info(d.Instance())))
+}
+
+// SetOutputFormat sets the info of the decoded output. Call it from SetFormatFunc, or from
+// HandleFrameFunc once the format is known.
+func (d *Decoder) SetOutputFormat(info *Info) bool {
+	return gobool(C.gst_audio_decoder_set_output_format(d.Instance(), info.instance()))
+}
+
+// FinishFrame pushes the given decoded samples downstream. frames is the number of input frames they
+// were decoded from, which the base class uses to compute their timestamp and duration. The buffer is
+// taken. buffer may be nil to only mark frames as consumed, e.g. when they were skipped.
+func (d *Decoder) FinishFrame(buffer *gst.Buffer, frames int) gst.FlowReturn {
+	var cBuf *C.GstBuffer
+	if buffer != nil {
+		cBuf = (*C.GstBuffer)(buffer.TransferUnsafe())
+	}
+	return gst.FlowReturn(C.gst_audio_decoder_finish_frame(d.Instance(), cBuf, C.gint(frames)))
+}
+
+// SetLatency sets the minimum and maximum latency of the decoder, which is reported in latency queries.
+func (d *Decoder) SetLatency(min, max time.Duration) {
+	C.gst_audio_decoder_set_latency(d.Instance(), durationToClockTime(min), durationToClockTime(max))
+}
+
+// SetPLCAware sets whether the decoder can conceal packet loss when passed nil buffers for lost frames.
+func (d *Decoder) SetPLCAware(plc bool) {
+	C.gst_audio_decoder_set_plc_aware(d.Instance(), gboolean(plc))
+}
+
+// SetDrainable sets whether the decoder can be drained by passing a nil buffer to HandleFrameFunc. This
+// is the default.
+func (d *Decoder) SetDrainable(enabled bool) {
+	C.gst_audio_decoder_set_drainable(d.Instance(), gboolean(enabled))
+}
+
+// SetNeedsFormat sets whether the decoder requires the caps of the input before it can decode, instead of
+// working out the format from the data.
+func (d *Decoder) SetNeedsFormat(enabled bool) {
+	C.gst_audio_decoder_set_needs_format(d.Instance(), gboolean(enabled))
+}
+
+// MergeTags merges the given tags into the tags sent downstream with the given mode. The base class
+// already adds the codec tags of the input.
+func (d *Decoder) MergeTags(tags *gst.TagList, mode gst.TagMergeMode) {
+	var cTags *C.GstTagList
+	if tags != nil {
+		cTags = (*C.GstTagList)(unsafe.Pointer(tags.Instance()))
+	}
+	C.gst_audio_decoder_merge_tags(d.Instance(), cTags, C.GstTagMergeMode(mode))
+}
+
+// Negotiate negotiates with downstream elements the info set with SetOutputFormat. It is called
+// automatically when needed, so it rarely needs to be called explicitly.
+func (d *Decoder) Negotiate() bool { return gobool(C.gst_audio_decoder_negotiate(d.Instance())) }
+
+// ParentNegotiate calls the default Negotiate implementation of the decoder.
+func (d *Decoder) ParentNegotiate() bool { return gobool(C.parentAudioDecoderNegotiate(d.Instance())) }
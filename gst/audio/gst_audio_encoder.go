@@ -0,0 +1,228 @@
+package audio
+
+/*
+#include "gst.go.h"
+
+extern gboolean      goAudioEncoderStart       (GstAudioEncoder * enc);
+extern gboolean      goAudioEncoderStop        (GstAudioEncoder * enc);
+extern gboolean      goAudioEncoderSetFormat   (GstAudioEncoder * enc, GstAudioInfo * info);
+extern GstFlowReturn goAudioEncoderHandleFrame (GstAudioEncoder * enc, GstBuffer * buffer);
+extern void          goAudioEncoderFlush       (GstAudioEncoder * enc);
+extern gboolean      goAudioEncoderNegotiate   (GstAudioEncoder * enc);
+
+#define GO_AENC_PARENT_CLASS (GST_AUDIO_ENCODER_CLASS(g_type_class_peek(GST_TYPE_AUDIO_ENCODER)))
+
+gboolean parentAudioEncoderNegotiate (GstAudioEncoder * enc) { return GO_AENC_PARENT_CLASS->negotiate(enc); }
+
+void setAudioEncoderHeaders (GstAudioEncoder * enc, GstBuffer ** headers, gint n)
+{
+	GList * list = NULL;
+	gint i;
+	for (i = 0; i < n; i++)
+		list = g_list_append(list, headers[i]);
+	gst_audio_encoder_set_headers(enc, list);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// EncoderCallbacks are the functions implementing an Encoder registered with RegisterEncoder. Any of them
+// can be nil, in which case the default GstAudioEncoder implementation is used, though every encoder
+// needs at least SetFormatFunc and HandleFrameFunc.
+type EncoderCallbacks struct {
+	// StartFunc is called when the encoder starts processing, before any data is passed to it. It is the
+	// place to allocate the resources of the encoder, e.g. with SetInstanceData.
+	StartFunc func(self *Encoder) bool
+	// StopFunc is called when the encoder stops processing. It is the place to free the resources of the
+	// encoder.
+	StopFunc func(self *Encoder) bool
+	// SetFormatFunc is called with the info of the input samples whenever it changes. The encoder should
+	// configure itself accordingly and call SetOutputFormat with the caps of the encoded stream.
+	SetFormatFunc func(self *Encoder, info *Info) bool
+	// HandleFrameFunc encodes the given input samples. The encoder does not own the buffer, and should call
+	// FinishFrame for every encoded frame it produces. The buffer is nil when the encoder should drain and
+	// finish any samples it still holds on to.
+	HandleFrameFunc func(self *Encoder, buffer *gst.Buffer) gst.FlowReturn
+	// FlushFunc is called when the encoder should drop any samples it holds on to, e.g. on a seek.
+	FlushFunc func(self *Encoder)
+	// NegotiateFunc negotiates with downstream elements. The default implementation, which can be called
+	// with ParentNegotiate, uses the caps set with SetOutputFormat.
+	NegotiateFunc func(self *Encoder) bool
+}
+
+// classInit installs the implemented virtual methods on the class of a registered encoder.
+func (e *EncoderCallbacks) classInit(klass unsafe.Pointer) {
+	class := (*C.GstAudioEncoderClass)(klass)
+	if e.StartFunc != nil {
+		class.start = (*[0]byte)(C.goAudioEncoderStart)
+	}
+	if e.StopFunc != nil {
+		class.stop = (*[0]byte)(C.goAudioEncoderStop)
+	}
+	if e.SetFormatFunc != nil {
+		class.set_format = (*[0]byte)(C.goAudioEncoderSetFormat)
+	}
+	if e.HandleFrameFunc != nil {
+		class.handle_frame = (*[0]byte)(C.goAudioEncoderHandleFrame)
+	}
+	if e.FlushFunc != nil {
+		class.flush = (*[0]byte)(C.goAudioEncoderFlush)
+	}
+	if e.NegotiateFunc != nil {
+		class.negotiate = (*[0]byte)(C.goAudioEncoderNegotiate)
+	}
+}
+
+// Encoder is a go representation of a GstAudioEncoder. It is passed to the EncoderCallbacks of an
+// encoder registered with RegisterEncoder. The base class takes care of timestamps, latency, tags and
+// the collection of input samples into frames of the configured size.
+type Encoder struct{ *gst.Element }
+
+// RegisterEncoder registers a new encoder element with the given name and rank, implemented by the given
+// callbacks. sinkCaps are the caps of the raw audio it accepts, and srcCaps the caps of the encoded audio
+// it produces. plugin may be nil to register the element statically, as is usual for applications. Once
+// registered, the element can be created by name like any other, e.g. with gst.NewElement, and is picked
+// up by encodebin when its rank is high enough.
+//
+//   audio.RegisterEncoder(nil, "myspeechenc", gst.RankPrimary, &gst.ElementMetadata{
+//       LongName:    "Speech encoder",
+//       Klass:       "Codec/Encoder/Audio",
+//       Description: "Encodes speech with our in-house codec",
+//       Author:      "Jane Doe <jane@example.com>",
+//   }, gst.NewCapsFromString("audio/x-raw, format=S16LE, rate=16000, channels=1"),
+//       gst.NewCapsFromString("audio/x-speech"),
+//       &audio.EncoderCallbacks{
+//           SetFormatFunc: func(self *audio.Encoder, info *audio.Info) bool {
+//               self.SetFrameSamplesMin(320)
+//               self.SetFrameSamplesMax(320)
+//               return self.SetOutputFormat(gst.NewCapsFromString("audio/x-speech"))
+//           },
+//           HandleFrameFunc: func(self *audio.Encoder, buffer *gst.Buffer) gst.FlowReturn {
+//               if buffer == nil {
+//                   return gst.FlowOK
+//               }
+//               return self.FinishFrame(gst.NewBufferFromBytes(encode(buffer.Bytes())), 320)
+//           },
+//       })
+func RegisterEncoder(plugin *gst.Plugin, name string, rank gst.Rank, metadata *gst.ElementMetadata, sinkCaps, srcCaps *gst.Caps, cbs *EncoderCallbacks) error {
+	return gst.RegisterGoElementUnsafe(plugin, name, rank, glib.Type(C.GST_TYPE_AUDIO_ENCODER), metadata, sinkCaps, srcCaps, cbs, cbs.classInit)
+}
+
+// EncoderFromElement returns the Encoder of the given element, or nil if it is not an encoder registered
+// with RegisterEncoder.
+func EncoderFromElement(elem *gst.Element) *Encoder {
+	if _, ok := gst.GoElementCallbacksUnsafe(elem.Unsafe()).(*EncoderCallbacks); !ok {
+		return nil
+	}
+	return &Encoder{elem}
+}
+
+func wrapCEncoder(enc *C.GstAudioEncoder) *Encoder {
+	return &Encoder{gst.FromGstElementUnsafe(unsafe.Pointer(enc))}
+}
+
+func getEncoderCallbacks(enc *C.GstAudioEncoder) *EncoderCallbacks {
+	return gst.GoElementCallbacksUnsafe(unsafe.Pointer(enc)).(*EncoderCallbacks)
+}
+
+// Instance returns the underlying GstAudioEncoder instance.
+func (e *Encoder) Instance() *C.GstAudioEncoder { return (*C.GstAudioEncoder)(e.Unsafe()) }
+
+// SetInstanceData stores the given value with this encoder instance, e.g. the state of the codec. Any
+// previous value is released, and the last one is released when the encoder is finalized.
+func (e *Encoder) SetInstanceData(data interface{}) { gst.SetGoElementDataUnsafe(e.Unsafe(), data) }
+
+// InstanceData returns the value stored with SetInstanceData, or nil if there is none.
+func (e *Encoder) InstanceData() interface{} { return gst.GoElementDataUnsafe(e.Unsafe()) }
+
+// GetAudioInfo returns a copy of the info of the input samples.
+func (e *Encoder) GetAudioInfo() *Info {
+	return FromGstAudioInfoUnsafe(unsafe.Pointer(C.gst_audio_encoder_get_audio_info(e.Instance())))
+}
+
+// SetOutputFormat sets the caps of the encoded output. Call it from SetFormatFunc.
+func (e *Encoder) SetOutputFormat(caps *gst.Caps) bool {
+	return gobool(C.gst_audio_encoder_set_output_format(e.Instance(), fromCoreCaps(caps)))
+}
+
+// FinishFrame pushes the given encoded data downstream. samples is the number of input samples per
+// channel it encodes, which the base class uses to compute the timestamp and duration of the output, or
+// -1 to consider all the input samples handed to HandleFrameFunc so far as encoded. The buffer is taken.
+// buffer may be nil to only mark samples as consumed.
+func (e *Encoder) FinishFrame(buffer *gst.Buffer, samples int) gst.FlowReturn {
+	var cBuf *C.GstBuffer
+	if buffer != nil {
+		cBuf = (*C.GstBuffer)(buffer.TransferUnsafe())
+	}
+	return gst.FlowReturn(C.gst_audio_encoder_finish_frame(e.Instance(), cBuf, C.gint(samples)))
+}
+
+// SetFrameSamplesMin sets the minimum number of samples per channel passed to HandleFrameFunc. With
+// SetFrameSamplesMax set to the same value, frames of a fixed size are passed.
+func (e *Encoder) SetFrameSamplesMin(num int) {
+	C.gst_audio_encoder_set_frame_samples_min(e.Instance(), C.gint(num))
+}
+
+// SetFrameSamplesMax sets the maximum number of samples per channel passed to HandleFrameFunc, or 0 for
+// no maximum.
+func (e *Encoder) SetFrameSamplesMax(num int) {
+	C.gst_audio_encoder_set_frame_samples_max(e.Instance(), C.gint(num))
+}
+
+// SetFrameMax sets the maximum number of frames passed to HandleFrameFunc at once, or 0 for as many as
+// are available.
+func (e *Encoder) SetFrameMax(num int) { C.gst_audio_encoder_set_frame_max(e.Instance(), C.gint(num)) }
+
+// SetHardMin sets whether the encoder requires at least the minimum number of samples, padding the last
+// frame with silence if needed, instead of being passed less on draining.
+func (e *Encoder) SetHardMin(enabled bool) {
+	C.gst_audio_encoder_set_hard_min(e.Instance(), gboolean(enabled))
+}
+
+// SetLookahead sets the number of samples per channel the encoder needs to see before it can produce
+// output for a frame.
+func (e *Encoder) SetLookahead(num int) { C.gst_audio_encoder_set_lookahead(e.Instance(), C.gint(num)) }
+
+// SetLatency sets the minimum and maximum latency of the encoder, which is reported in latency queries.
+func (e *Encoder) SetLatency(min, max time.Duration) {
+	C.gst_audio_encoder_set_latency(e.Instance(), durationToClockTime(min), durationToClockTime(max))
+}
+
+// SetHeaders sets the headers pushed downstream before the first encoded buffer, e.g. codec setup data.
+// The buffers are taken.
+func (e *Encoder) SetHeaders(headers []*gst.Buffer) error {
+	if len(headers) == 0 {
+		return errors.New("At least one header buffer is required")
+	}
+	cHeaders := make([]*C.GstBuffer, len(headers))
+	for i, header := range headers {
+		cHeaders[i] = (*C.GstBuffer)(header.TransferUnsafe())
+	}
+	C.setAudioEncoderHeaders(e.Instance(), &cHeaders[0], C.gint(len(cHeaders)))
+	return nil
+}
+
+// MergeTags merges the given tags into the tags sent downstream with the given mode. The base class
+// already adds the codec and bitrate tags.
+func (e *Encoder) MergeTags(tags *gst.TagList, mode gst.TagMergeMode) {
+	var cTags *C.GstTagList
+	if tags != nil {
+		cTags = (*C.GstTagList)(unsafe.Pointer(tags.Instance()))
+	}
+	C.gst_audio_encoder_merge_tags(e.Instance(), cTags, C.GstTagMergeMode(mode))
+}
+
+// Negotiate negotiates with downstream elements the caps set with SetOutputFormat. It is called
+// automatically when needed, so it rarely needs to be called explicitly.
+func (e *Encoder) Negotiate() bool { return gobool(C.gst_audio_encoder_negotiate(e.Instance())) }
+
+// ParentNegotiate calls the default Negotiate implementation of the encoder.
+func (e *Encoder) ParentNegotiate() bool { return gobool(C.parentAudioEncoderNegotiate(e.Instance())) }
@@ -0,0 +1,196 @@
+package audio
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/tinyzimmer/go-gst/gst"
+	"github.com/tinyzimmer/go-gst/gst/app"
+)
+
+func TestEncoderEncodesThroughPipeline(t *testing.T) {
+	metadata := &gst.ElementMetadata{
+		LongName:    "Test encoder",
+		Klass:       "Codec/Encoder/Audio",
+		Description: "Encodes samples by inverting their bits",
+		Author:      "go-gst",
+	}
+	sinkCaps, srcCaps := gst.NewCapsFromString("audio/x-raw, format=S16LE, channels=1"), gst.NewCapsFromString("audio/x-test")
+	cbs := &EncoderCallbacks{
+		StartFunc: func(self *Encoder) bool {
+			self.SetInstanceData(0)
+			return true
+		},
+		SetFormatFunc: func(self *Encoder, info *Info) bool {
+			return self.SetOutputFormat(gst.NewCapsFromString("audio/x-test"))
+		},
+		HandleFrameFunc: func(self *Encoder, buffer *gst.Buffer) gst.FlowReturn {
+			if buffer == nil {
+				return gst.FlowOK
+			}
+			self.SetInstanceData(self.InstanceData().(int) + 1)
+			data := buffer.Bytes()
+			for i := range data {
+				data[i] = ^data[i]
+			}
+			return self.FinishFrame(gst.NewBufferFromBytes(data), len(data)/2)
+		},
+	}
+	if err := RegisterEncoder(nil, "gotestaudioenc", gst.RankNone, metadata, sinkCaps, srcCaps, cbs); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterEncoder(nil, "gotestaudioenc", gst.RankNone, metadata, sinkCaps, srcCaps, cbs); err == nil {
+		t.Fatal("Expected an error when registering the same encoder twice")
+	}
+
+	before := gst.LiveCallbacks()
+	pipeline, err := gst.NewPipelineFromString(
+		"appsrc name=src format=time caps=audio/x-raw,format=S16LE,rate=8000,channels=1,layout=interleaved " +
+			"! gotestaudioenc name=enc ! appsink name=sink sync=false",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srcElem, _ := pipeline.GetElementByName("src")
+	encElem, _ := pipeline.GetElementByName("enc")
+	sinkElem, _ := pipeline.GetElementByName("sink")
+	if DecoderFromElement(encElem) != nil {
+		t.Fatal("Expected the encoder not to be a go decoder")
+	}
+	enc := EncoderFromElement(encElem)
+	if enc == nil {
+		t.Fatal("Expected the element to be a go encoder")
+	}
+	if enc.Preset() == nil {
+		t.Fatal("Expected the encoder to implement GstPreset")
+	}
+	src, sink := app.SrcFromElement(srcElem), app.SinkFromElement(sinkElem)
+	if err := pipeline.SetState(gst.StatePlaying); err != nil {
+		t.Fatal(err)
+	}
+
+	// two samples per buffer
+	input := [][]byte{{0x00, 0x01, 0x02, 0x03}, {0x10, 0x11, 0x12, 0x13}, {0xf0, 0xf1, 0xf2, 0xf3}}
+	for i, data := range input {
+		buf := gst.NewBufferFromBytes(data)
+		buf.SetPresentationTimestamp(time.Duration(i*2) * time.Second / 8000)
+		buf.SetDuration(2 * time.Second / 8000)
+		if ret := src.PushBuffer(buf); ret != gst.FlowOK {
+			t.Fatalf("Could not push buffer %d: %s", i, ret)
+		}
+	}
+	src.EndStream()
+
+	// the base class may hand several input buffers to HandleFrameFunc at once, so the output is
+	// compared as a whole
+	var expected, got []byte
+	for _, data := range input {
+		for _, b := range data {
+			expected = append(expected, ^b)
+		}
+	}
+	for len(got) < len(expected) {
+		sample := sink.TryPullSample(5 * time.Second)
+		if sample == nil {
+			break
+		}
+		got = append(got, sample.GetBuffer().Bytes()...)
+		sample.Unref()
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Unexpected encoded data: %v, expected %v", got, expected)
+	}
+	if calls, _ := enc.InstanceData().(int); calls == 0 {
+		t.Error("Expected HandleFrameFunc to be called")
+	}
+
+	srcElem.Unref()
+	encElem.Unref()
+	sinkElem.Unref()
+	if err := pipeline.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gst.CheckCallbackLeaks(before, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncoderFromElementRejectsOtherElements(t *testing.T) {
+	elem, err := gst.NewElement("fakesink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer elem.Unref()
+	if EncoderFromElement(elem) != nil {
+		t.Fatal("Expected a C element not to be a go encoder")
+	}
+}
+
+func TestEncoderPresets(t *testing.T) {
+	dir := t.TempDir()
+	if !gst.SetPresetAppDir(dir) {
+		t.Fatal("Could not set the preset app dir")
+	}
+	if got := gst.GetPresetAppDir(); got != dir {
+		t.Fatalf("Expected the preset app dir to be %s, got %s", dir, got)
+	}
+
+	metadata := &gst.ElementMetadata{
+		LongName:    "Preset encoder",
+		Klass:       "Codec/Encoder/Audio",
+		Description: "Encodes nothing",
+		Author:      "go-gst",
+	}
+	sinkCaps, srcCaps := gst.NewCapsFromString("audio/x-raw"), gst.NewCapsFromString("audio/x-test")
+	if err := RegisterEncoder(nil, "gotestpresetenc", gst.RankNone, metadata, sinkCaps, srcCaps, &EncoderCallbacks{}); err != nil {
+		t.Fatal(err)
+	}
+	elem, err := gst.NewElement("gotestpresetenc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer elem.Unref()
+	preset := elem.Preset()
+	if preset == nil {
+		t.Fatal("Expected the encoder to implement GstPreset")
+	}
+	if !preset.IsEditable() {
+		t.Fatal("Expected the presets of the encoder to be editable")
+	}
+
+	// the presets hold the properties of the GstAudioEncoder base class
+	if err := elem.SetProperty("hard-resync", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := preset.SavePreset("resync"); err != nil {
+		t.Fatal(err)
+	}
+	if files, err := ioutil.ReadDir(dir); err != nil || len(files) == 0 {
+		t.Fatalf("Expected the preset to be saved in the app dir, got %v (%v)", files, err)
+	}
+	if names := preset.GetPresetNames(); len(names) != 1 || names[0] != "resync" {
+		t.Fatalf("Unexpected preset names: %v", names)
+	}
+
+	if err := elem.SetProperty("hard-resync", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := preset.LoadPreset("resync"); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := elem.GetProperty("hard-resync"); err != nil || val != true {
+		t.Fatalf("Expected the preset to restore the property, got %v (%v)", val, err)
+	}
+
+	if err := preset.DeletePreset("resync"); err != nil {
+		t.Fatal(err)
+	}
+	if names := preset.GetPresetNames(); len(names) != 0 {
+		t.Errorf("Expected the preset to be deleted, got %v", names)
+	}
+	if err := preset.LoadPreset("resync"); err == nil {
+		t.Error("Expected loading a deleted preset to fail")
+	}
+}
@@ -0,0 +1,217 @@
+package audio
+
+/*
+#include <gst/audio/audio.h>
+
+GstAudioFormat        audioFormatInfoFormat       (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_FORMAT(info); }
+const gchar *         audioFormatInfoName         (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_NAME(info); }
+GstAudioFormatFlags   audioFormatInfoFlags        (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_FLAGS(info); }
+gboolean              audioFormatInfoIsInteger    (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_IS_INTEGER(info); }
+gboolean              audioFormatInfoIsFloat      (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_IS_FLOAT(info); }
+gboolean              audioFormatInfoIsSigned     (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_IS_SIGNED(info); }
+gint                  audioFormatInfoEndianness   (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_ENDIANNESS(info); }
+gboolean              audioFormatInfoIsLE         (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_IS_LITTLE_ENDIAN(info); }
+gboolean              audioFormatInfoIsBE         (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_IS_BIG_ENDIAN(info); }
+gint                  audioFormatInfoWidth        (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_WIDTH(info); }
+gint                  audioFormatInfoDepth        (const GstAudioFormatInfo * info) { return GST_AUDIO_FORMAT_INFO_DEPTH(info); }
+const guint8 *        audioFormatInfoSilence      (const GstAudioFormatInfo * info) { return info->silence; }
+const gchar *         audioFormatInfoDescription  (const GstAudioFormatInfo * info) { return info->description; }
+GstAudioFormat        audioFormatInfoUnpackFormat (const GstAudioFormatInfo * info) { return info->unpack_format; }
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// Format is an enum describing the most common audio formats.
+type Format int
+
+// Type castings
+const (
+	FormatUnknown Format = C.GST_AUDIO_FORMAT_UNKNOWN  // (0) – unknown or unset audio format
+	FormatEncoded Format = C.GST_AUDIO_FORMAT_ENCODED  // (1) – encoded audio format
+	FormatS8      Format = C.GST_AUDIO_FORMAT_S8       // (2) – 8 bits in 8 bits, signed
+	FormatU8      Format = C.GST_AUDIO_FORMAT_U8       // (3) – 8 bits in 8 bits, unsigned
+	FormatS16LE   Format = C.GST_AUDIO_FORMAT_S16LE    // (4) – 16 bits in 16 bits, signed, little endian
+	FormatS16BE   Format = C.GST_AUDIO_FORMAT_S16BE    // (5) – 16 bits in 16 bits, signed, big endian
+	FormatU16LE   Format = C.GST_AUDIO_FORMAT_U16LE    // (6) – 16 bits in 16 bits, unsigned, little endian
+	FormatU16BE   Format = C.GST_AUDIO_FORMAT_U16BE    // (7) – 16 bits in 16 bits, unsigned, big endian
+	FormatS2432LE Format = C.GST_AUDIO_FORMAT_S24_32LE // (8) – 24 bits in 32 bits, signed, little endian
+	FormatS2432BE Format = C.GST_AUDIO_FORMAT_S24_32BE // (9) – 24 bits in 32 bits, signed, big endian
+	FormatU2432LE Format = C.GST_AUDIO_FORMAT_U24_32LE // (10) – 24 bits in 32 bits, unsigned, little endian
+	FormatU2432BE Format = C.GST_AUDIO_FORMAT_U24_32BE // (11) – 24 bits in 32 bits, unsigned, big endian
+	FormatS32LE   Format = C.GST_AUDIO_FORMAT_S32LE    // (12) – 32 bits in 32 bits, signed, little endian
+	FormatS32BE   Format = C.GST_AUDIO_FORMAT_S32BE    // (13) – 32 bits in 32 bits, signed, big endian
+	FormatU32LE   Format = C.GST_AUDIO_FORMAT_U32LE    // (14) – 32 bits in 32 bits, unsigned, little endian
+	FormatU32BE   Format = C.GST_AUDIO_FORMAT_U32BE    // (15) – 32 bits in 32 bits, unsigned, big endian
+	FormatS24LE   Format = C.GST_AUDIO_FORMAT_S24LE    // (16) – 24 bits in 24 bits, signed, little endian
+	FormatS24BE   Format = C.GST_AUDIO_FORMAT_S24BE    // (17) – 24 bits in 24 bits, signed, big endian
+	FormatU24LE   Format = C.GST_AUDIO_FORMAT_U24LE    // (18) – 24 bits in 24 bits, unsigned, little endian
+	FormatU24BE   Format = C.GST_AUDIO_FORMAT_U24BE    // (19) – 24 bits in 24 bits, unsigned, big endian
+	FormatS20LE   Format = C.GST_AUDIO_FORMAT_S20LE    // (20) – 20 bits in 24 bits, signed, little endian
+	FormatS20BE   Format = C.GST_AUDIO_FORMAT_S20BE    // (21) – 20 bits in 24 bits, signed, big endian
+	FormatU20LE   Format = C.GST_AUDIO_FORMAT_U20LE    // (22) – 20 bits in 24 bits, unsigned, little endian
+	FormatU20BE   Format = C.GST_AUDIO_FORMAT_U20BE    // (23) – 20 bits in 24 bits, unsigned, big endian
+	FormatS18LE   Format = C.GST_AUDIO_FORMAT_S18LE    // (24) – 18 bits in 24 bits, signed, little endian
+	FormatS18BE   Format = C.GST_AUDIO_FORMAT_S18BE    // (25) – 18 bits in 24 bits, signed, big endian
+	FormatU18LE   Format = C.GST_AUDIO_FORMAT_U18LE    // (26) – 18 bits in 24 bits, unsigned, little endian
+	FormatU18BE   Format = C.GST_AUDIO_FORMAT_U18BE    // (27) – 18 bits in 24 bits, unsigned, big endian
+	FormatF32LE   Format = C.GST_AUDIO_FORMAT_F32LE    // (28) – 32-bit floating point samples, little endian
+	FormatF32BE   Format = C.GST_AUDIO_FORMAT_F32BE    // (29) – 32-bit floating point samples, big endian
+	FormatF64LE   Format = C.GST_AUDIO_FORMAT_F64LE    // (30) – 64-bit floating point samples, little endian
+	FormatF64BE   Format = C.GST_AUDIO_FORMAT_F64BE    // (31) – 64-bit floating point samples, big endian
+	FormatS16     Format = C.GST_AUDIO_FORMAT_S16      // 16 bits in 16 bits, signed, native endianness
+	FormatU16     Format = C.GST_AUDIO_FORMAT_U16      // 16 bits in 16 bits, unsigned, native endianness
+	FormatS2432   Format = C.GST_AUDIO_FORMAT_S24_32   // 24 bits in 32 bits, signed, native endianness
+	FormatU2432   Format = C.GST_AUDIO_FORMAT_U24_32   // 24 bits in 32 bits, unsigned, native endianness
+	FormatS32     Format = C.GST_AUDIO_FORMAT_S32      // 32 bits in 32 bits, signed, native endianness
+	FormatU32     Format = C.GST_AUDIO_FORMAT_U32      // 32 bits in 32 bits, unsigned, native endianness
+	FormatS24     Format = C.GST_AUDIO_FORMAT_S24      // 24 bits in 24 bits, signed, native endianness
+	FormatU24     Format = C.GST_AUDIO_FORMAT_U24      // 24 bits in 24 bits, unsigned, native endianness
+	FormatS20     Format = C.GST_AUDIO_FORMAT_S20      // 20 bits in 24 bits, signed, native endianness
+	FormatU20     Format = C.GST_AUDIO_FORMAT_U20      // 20 bits in 24 bits, unsigned, native endianness
+	FormatS18     Format = C.GST_AUDIO_FORMAT_S18      // 18 bits in 24 bits, signed, native endianness
+	FormatU18     Format = C.GST_AUDIO_FORMAT_U18      // 18 bits in 24 bits, unsigned, native endianness
+	FormatF32     Format = C.GST_AUDIO_FORMAT_F32      // 32-bit floating point samples, native endianness
+	FormatF64     Format = C.GST_AUDIO_FORMAT_F64      // 64-bit floating point samples, native endianness
+)
+
+// Endianness is the byte order of the samples of a Format.
+type Endianness int
+
+// Type castings
+const (
+	LittleEndian Endianness = C.G_LITTLE_ENDIAN // (1234) – least significant byte first
+	BigEndian    Endianness = C.G_BIG_ENDIAN    // (4321) – most significant byte first
+	NativeEndian Endianness = C.G_BYTE_ORDER    // the byte order of the host
+)
+
+// FormatFlags are the different audio flags that a format info can have.
+type FormatFlags int
+
+// Type castings
+const (
+	FormatFlagInteger FormatFlags = C.GST_AUDIO_FORMAT_FLAG_INTEGER // (1) – integer samples
+	FormatFlagFloat   FormatFlags = C.GST_AUDIO_FORMAT_FLAG_FLOAT   // (2) – float samples
+	FormatFlagSigned  FormatFlags = C.GST_AUDIO_FORMAT_FLAG_SIGNED  // (4) – signed samples
+	FormatFlagComplex FormatFlags = C.GST_AUDIO_FORMAT_FLAG_COMPLEX // (16) – complex layout
+	FormatFlagUnpack  FormatFlags = C.GST_AUDIO_FORMAT_FLAG_UNPACK  // (32) – the format can be used in GstAudioFormatUnpack and GstAudioFormatPack functions
+)
+
+// FormatFromString returns the format for the given string representation, e.g. "S16LE", or FormatUnknown.
+func FormatFromString(format string) Format {
+	cFormat := C.CString(format)
+	defer C.free(unsafe.Pointer(cFormat))
+	return Format(C.gst_audio_format_from_string((*C.gchar)(cFormat)))
+}
+
+// BuildIntegerFormat returns the integer Format matching the given signedness, endianness, width and depth,
+// or FormatUnknown if there is none.
+func BuildIntegerFormat(signed bool, endianness Endianness, width, depth int) Format {
+	return Format(C.gst_audio_format_build_integer(gboolean(signed), C.gint(endianness), C.gint(width), C.gint(depth)))
+}
+
+// RawFormats returns a slice of all the raw audio formats supported by GStreamer.
+func RawFormats() []Format {
+	var size C.guint
+	formats := C.gst_audio_formats_raw(&size)
+	out := make([]Format, uint(size))
+	for i, f := range (*[1 << 30]C.GstAudioFormat)(unsafe.Pointer(formats))[:size:size] {
+		out[i] = Format(f)
+	}
+	return out
+}
+
+// MakeRawCaps returns a generic raw audio caps for the formats defined in formats with the given layout.
+// If formats is empty or nil, returns a caps for all the supported raw audio formats, see RawFormats.
+func MakeRawCaps(formats []Format, layout Layout) *gst.Caps {
+	var caps *C.GstCaps
+	if len(formats) == 0 {
+		caps = C.gst_audio_make_raw_caps(nil, C.guint(0), C.GstAudioLayout(layout))
+	} else {
+		cFormats := make([]C.GstAudioFormat, len(formats))
+		for i, f := range formats {
+			cFormats[i] = C.GstAudioFormat(f)
+		}
+		caps = C.gst_audio_make_raw_caps(&cFormats[0], C.guint(len(cFormats)), C.GstAudioLayout(layout))
+	}
+	return gst.FromGstCapsUnsafeFull(unsafe.Pointer(caps))
+}
+
+// Info returns the FormatInfo for this audio format.
+func (f Format) Info() *FormatInfo {
+	return &FormatInfo{ptr: C.gst_audio_format_get_info(C.GstAudioFormat(f))}
+}
+
+// String implements a stringer on a Format.
+func (f Format) String() string {
+	return C.GoString(C.gst_audio_format_to_string(C.GstAudioFormat(f)))
+}
+
+// FormatInfo contains information for an audio format. It is owned by GStreamer and never freed.
+type FormatInfo struct {
+	ptr *C.GstAudioFormatInfo
+}
+
+func (f *FormatInfo) instance() *C.GstAudioFormatInfo { return f.ptr }
+
+// Format returns the format this info describes.
+func (f *FormatInfo) Format() Format { return Format(C.audioFormatInfoFormat(f.instance())) }
+
+// Name returns the string representation of the format, e.g. "S16LE".
+func (f *FormatInfo) Name() string { return C.GoString(C.audioFormatInfoName(f.instance())) }
+
+// Description returns a user readable description of the format.
+func (f *FormatInfo) Description() string {
+	return C.GoString(C.audioFormatInfoDescription(f.instance()))
+}
+
+// Flags returns the flags on the format.
+func (f *FormatInfo) Flags() FormatFlags { return FormatFlags(C.audioFormatInfoFlags(f.instance())) }
+
+// IsInteger returns true if the samples are integers.
+func (f *FormatInfo) IsInteger() bool { return gobool(C.audioFormatInfoIsInteger(f.instance())) }
+
+// IsFloat returns true if the samples are floating point.
+func (f *FormatInfo) IsFloat() bool { return gobool(C.audioFormatInfoIsFloat(f.instance())) }
+
+// IsSigned returns true if the samples are signed.
+func (f *FormatInfo) IsSigned() bool { return gobool(C.audioFormatInfoIsSigned(f.instance())) }
+
+// Endianness returns the byte order of the samples.
+func (f *FormatInfo) Endianness() Endianness {
+	return Endianness(C.audioFormatInfoEndianness(f.instance()))
+}
+
+// IsLE returns true if the samples are stored in little endian byte order.
+func (f *FormatInfo) IsLE() bool { return gobool(C.audioFormatInfoIsLE(f.instance())) }
+
+// IsBE returns true if the samples are stored in big endian byte order.
+func (f *FormatInfo) IsBE() bool { return gobool(C.audioFormatInfoIsBE(f.instance())) }
+
+// Width returns the amount of bits used for one sample.
+func (f *FormatInfo) Width() int { return int(C.audioFormatInfoWidth(f.instance())) }
+
+// Depth returns the amount of valid bits in a sample, which can be less than Width.
+func (f *FormatInfo) Depth() int { return int(C.audioFormatInfoDepth(f.instance())) }
+
+// Silence returns the bytes of one silent sample, of Width / 8 bytes.
+func (f *FormatInfo) Silence() []byte {
+	return C.GoBytes(unsafe.Pointer(C.audioFormatInfoSilence(f.instance())), C.int(f.Width()/8))
+}
+
+// UnpackFormat returns the format of the samples this format is unpacked to when processed.
+func (f *FormatInfo) UnpackFormat() Format {
+	return Format(C.audioFormatInfoUnpackFormat(f.instance()))
+}
+
+// FillSilence fills dest with silence for the format.
+func (f *FormatInfo) FillSilence(dest []byte) {
+	if len(dest) == 0 {
+		return
+	}
+	C.gst_audio_format_fill_silence(f.instance(), C.gpointer(unsafe.Pointer(&dest[0])), C.gsize(len(dest)))
+}
@@ -0,0 +1,261 @@
+package audio
+
+/*
+#include <gst/audio/audio.h>
+
+const GstAudioFormatInfo * audioInfoFormatInfo     (GstAudioInfo * info)                        { return info->finfo; }
+GstAudioFlags              audioInfoFlags          (GstAudioInfo * info)                        { return GST_AUDIO_INFO_FLAGS(info); }
+gboolean                   audioInfoIsUnpositioned (GstAudioInfo * info)                        { return GST_AUDIO_INFO_IS_UNPOSITIONED(info); }
+GstAudioLayout             audioInfoLayout         (GstAudioInfo * info)                        { return GST_AUDIO_INFO_LAYOUT(info); }
+gint                       audioInfoRate           (GstAudioInfo * info)                        { return GST_AUDIO_INFO_RATE(info); }
+gint                       audioInfoChannels       (GstAudioInfo * info)                        { return GST_AUDIO_INFO_CHANNELS(info); }
+gint                       audioInfoBPF            (GstAudioInfo * info)                        { return GST_AUDIO_INFO_BPF(info); }
+GstAudioChannelPosition    audioInfoPosition       (GstAudioInfo * info, gint i)                { return GST_AUDIO_INFO_POSITION(info, i); }
+void                       audioInfoSetLayout      (GstAudioInfo * info, GstAudioLayout layout) { info->layout = layout; }
+void                       audioInfoSetFlags       (GstAudioInfo * info, GstAudioFlags flags)   { info->flags = flags; }
+void                       audioInfoSetRate        (GstAudioInfo * info, gint rate)             { info->rate = rate; }
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// Additional audio meta tags
+const (
+	TagAudioChannels gst.Tag = C.GST_META_TAG_AUDIO_CHANNELS_STR
+	TagAudioRate     gst.Tag = C.GST_META_TAG_AUDIO_RATE_STR
+	TagAudio         gst.Tag = C.GST_META_TAG_AUDIO_STR
+)
+
+// Layout is the layout of the audio samples for the different channels.
+type Layout int
+
+// Type castings
+const (
+	LayoutInterleaved    Layout = C.GST_AUDIO_LAYOUT_INTERLEAVED     // (0) – interleaved audio
+	LayoutNonInterleaved Layout = C.GST_AUDIO_LAYOUT_NON_INTERLEAVED // (1) – non-interleaved audio
+)
+
+// Flags are extra audio flags.
+type Flags int
+
+// Type castings
+const (
+	FlagNone         Flags = C.GST_AUDIO_FLAG_NONE         // (0) – no valid flag
+	FlagUnpositioned Flags = C.GST_AUDIO_FLAG_UNPOSITIONED // (1) – the position array explicitly contains unpositioned channels.
+)
+
+// Info describes the properties of raw audio. This information can be filled in from GstCaps with
+// FromCaps, or built with WithFormat.
+type Info struct {
+	ptr *C.GstAudioInfo
+}
+
+func wrapInfo(ainfo *C.GstAudioInfo) *Info {
+	info := &Info{ainfo}
+	runtime.SetFinalizer(info, func(i *Info) { C.gst_audio_info_free(ainfo) })
+	return info
+}
+
+// instance returns the underlying GstAudioInfo instance.
+func (i *Info) instance() *C.GstAudioInfo { return i.ptr }
+
+// NewInfo returns a new Info instance. You can populate it by chaining builders
+// to this constructor.
+func NewInfo() *Info {
+	return wrapInfo(C.gst_audio_info_new())
+}
+
+// FromGstAudioInfoUnsafe returns a copy of the given C GstAudioInfo in the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstAudioInfoUnsafe(info unsafe.Pointer) *Info {
+	return wrapInfo(C.gst_audio_info_copy((*C.GstAudioInfo)(info)))
+}
+
+// Instance returns the underlying GstAudioInfo as an unsafe pointer.
+// This is meant for internal usage and is exported for visibility to other packages.
+func (i *Info) Instance() unsafe.Pointer { return unsafe.Pointer(i.ptr) }
+
+// FromCaps parses the caps and updates this info.
+func (i *Info) FromCaps(caps *gst.Caps) *Info {
+	C.gst_audio_info_from_caps(i.instance(), fromCoreCaps(caps))
+	return i
+}
+
+// ToCaps returns the caps representation of this audio info.
+func (i *Info) ToCaps() *gst.Caps {
+	caps := C.gst_audio_info_to_caps(i.instance())
+	return gst.FromGstCapsUnsafeFull(unsafe.Pointer(caps))
+}
+
+// Copy returns a copy of this info.
+func (i *Info) Copy() *Info {
+	return wrapInfo(C.gst_audio_info_copy(i.instance()))
+}
+
+// Convert converts among various gst.Format types. This function handles gst.FormatBytes, gst.FormatTime,
+// and gst.FormatDefault. For raw audio, gst.FormatDefault corresponds to audio frames. This function can
+// be used to handle pad queries of the type gst.QueryTypeConvert.
+func (i *Info) Convert(srcFormat, destFormat gst.Format, srcValue int64) (out int64, ok bool) {
+	var gout C.gint64
+	gok := C.gst_audio_info_convert(i.instance(), C.GstFormat(srcFormat), C.gint64(srcValue), C.GstFormat(destFormat), &gout)
+	return int64(gout), gobool(gok)
+}
+
+// IsEqual compares two GstAudioInfo and returns whether they are equal or not.
+func (i *Info) IsEqual(info *Info) bool {
+	return gobool(C.gst_audio_info_is_equal(i.instance(), info.instance()))
+}
+
+// WithFormat sets the format, rate and channels on this info. positions may be nil, in which case the
+// default positions for the number of channels are used. Otherwise it must hold a position for each
+// channel, or an error is returned.
+//
+// Note: This initializes info first, no values are preserved.
+func (i *Info) WithFormat(format Format, rate, channels int, positions []ChannelPosition) (*Info, error) {
+	var cPositions *C.GstAudioChannelPosition
+	if positions != nil {
+		if len(positions) != channels {
+			return nil, fmt.Errorf("expected %d channel positions, got %d", channels, len(positions))
+		}
+		if channels > 0 {
+			cPos := toCPositions(positions)
+			cPositions = &cPos[0]
+		}
+	}
+	C.gst_audio_info_set_format(i.instance(), C.GstAudioFormat(format), C.gint(rate), C.gint(channels), cPositions)
+	return i, nil
+}
+
+// WithLayout sets the layout on this info.
+func (i *Info) WithLayout(layout Layout) *Info {
+	C.audioInfoSetLayout(i.instance(), C.GstAudioLayout(layout))
+	return i
+}
+
+func (i *Info) setRate(rate int) { C.audioInfoSetRate(i.instance(), C.gint(rate)) }
+
+// WithFlags sets the flags on this info.
+func (i *Info) WithFlags(flags Flags) *Info {
+	C.audioInfoSetFlags(i.instance(), C.GstAudioFlags(flags))
+	return i
+}
+
+// Format returns the format for the info. You can call Info() on the return value
+// to inspect the properties further.
+func (i *Info) Format() Format { return i.FormatInfo().Format() }
+
+// FormatInfo returns the format info for the info.
+func (i *Info) FormatInfo() *FormatInfo {
+	return &FormatInfo{ptr: C.audioInfoFormatInfo(i.instance())}
+}
+
+// Name returns the name of the format of the info.
+func (i *Info) Name() string { return i.FormatInfo().Name() }
+
+// Flags returns the flags on the info.
+func (i *Info) Flags() Flags { return Flags(C.audioInfoFlags(i.instance())) }
+
+// IsUnpositioned returns true if the channels of the info have no positions.
+func (i *Info) IsUnpositioned() bool { return gobool(C.audioInfoIsUnpositioned(i.instance())) }
+
+// Layout returns the layout of the samples of the info.
+func (i *Info) Layout() Layout { return Layout(C.audioInfoLayout(i.instance())) }
+
+// Rate returns the sample rate of the info.
+func (i *Info) Rate() int { return int(C.audioInfoRate(i.instance())) }
+
+// Channels returns the number of channels of the info.
+func (i *Info) Channels() int { return int(C.audioInfoChannels(i.instance())) }
+
+// BPF returns the number of bytes for one frame, which is the size of one sample multiplied by the number
+// of channels.
+func (i *Info) BPF() int { return int(C.audioInfoBPF(i.instance())) }
+
+// BPS returns the number of bytes for one sample of one channel.
+func (i *Info) BPS() int { return i.Width() / 8 }
+
+// Width returns the number of bits used for one sample.
+func (i *Info) Width() int { return i.FormatInfo().Width() }
+
+// Depth returns the number of valid bits in one sample.
+func (i *Info) Depth() int { return i.FormatInfo().Depth() }
+
+// IsFloat returns true if the samples are floating point.
+func (i *Info) IsFloat() bool { return i.FormatInfo().IsFloat() }
+
+// IsInteger returns true if the samples are integers.
+func (i *Info) IsInteger() bool { return i.FormatInfo().IsInteger() }
+
+// IsSigned returns true if the samples are signed.
+func (i *Info) IsSigned() bool { return i.FormatInfo().IsSigned() }
+
+// Endianness returns the byte order of the samples.
+func (i *Info) Endianness() Endianness { return i.FormatInfo().Endianness() }
+
+// Positions returns the positions of the channels of the info.
+func (i *Info) Positions() []ChannelPosition {
+	channels := i.Channels()
+	out := make([]ChannelPosition, channels)
+	for idx := 0; idx < channels; idx++ {
+		out[idx] = ChannelPosition(C.audioInfoPosition(i.instance(), C.gint(idx)))
+	}
+	return out
+}
+
+// ChannelMask returns the channel mask of the positions of the info, or 0 if the channels are unpositioned.
+func (i *Info) ChannelMask() uint64 {
+	if i.IsUnpositioned() {
+		return 0
+	}
+	mask, err := ChannelPositionsToMask(i.Positions(), false)
+	if err != nil {
+		return 0
+	}
+	return mask
+}
+
+// BytesToSamples returns the number of samples per channel, or frames, in the given amount of bytes.
+func (i *Info) BytesToSamples(bytes int64) int64 {
+	if bpf := i.BPF(); bpf > 0 {
+		return bytes / int64(bpf)
+	}
+	return 0
+}
+
+// SamplesToBytes returns the number of bytes used by the given number of samples per channel.
+func (i *Info) SamplesToBytes(samples int64) int64 {
+	return samples * int64(i.BPF())
+}
+
+// SamplesToDuration returns the duration of the given number of samples per channel.
+func (i *Info) SamplesToDuration(samples int64) time.Duration {
+	if samples < 0 || i.Rate() <= 0 {
+		return 0
+	}
+	return time.Duration(C.gst_util_uint64_scale_int(C.guint64(samples), C.gint(time.Second), C.gint(i.Rate())))
+}
+
+// DurationToSamples returns the number of samples per channel in the given duration, rounded down.
+func (i *Info) DurationToSamples(d time.Duration) int64 {
+	if d < 0 || i.Rate() <= 0 {
+		return 0
+	}
+	return int64(C.gst_util_uint64_scale_int(C.guint64(d), C.gint(i.Rate()), C.gint(time.Second)))
+}
+
+// BytesToDuration returns the duration of the given amount of bytes.
+func (i *Info) BytesToDuration(bytes int64) time.Duration {
+	return i.SamplesToDuration(i.BytesToSamples(bytes))
+}
+
+// DurationToBytes returns the amount of bytes of whole frames in the given duration.
+func (i *Info) DurationToBytes(d time.Duration) int64 {
+	return i.SamplesToBytes(i.DurationToSamples(d))
+}
@@ -0,0 +1,29 @@
+package audio
+
+import "testing"
+
+func TestInfoWithFormatPositions(t *testing.T) {
+	info, err := NewInfo().WithFormat(FormatS16LE, 48000, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Channels() != 2 || info.Rate() != 48000 {
+		t.Fatalf("Unexpected info: %d channels at %d Hz", info.Channels(), info.Rate())
+	}
+
+	positions := []ChannelPosition{ChannelPositionFrontRight, ChannelPositionFrontLeft}
+	info, err = NewInfo().WithFormat(FormatS16LE, 48000, 2, positions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Positions(); len(got) != 2 || got[0] != positions[0] || got[1] != positions[1] {
+		t.Fatalf("Expected positions %v, got %v", positions, got)
+	}
+
+	if _, err := NewInfo().WithFormat(FormatS16LE, 48000, 2, positions[:1]); err == nil {
+		t.Fatal("Expected an error for fewer positions than channels")
+	}
+	if _, err := NewInfo().WithFormat(FormatS16LE, 48000, 1, positions); err == nil {
+		t.Fatal("Expected an error for more positions than channels")
+	}
+}
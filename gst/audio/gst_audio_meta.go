@@ -0,0 +1,82 @@
+package audio
+
+/*
+#include <gst/audio/audio.h>
+
+gsize audioMetaOffset (GstAudioMeta * meta, gint i) { return meta->offsets[i]; }
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// Meta is a go representation of a GstAudioMeta. It describes the layout of the samples in a buffer,
+// and is required on buffers of non-interleaved audio, where it holds the offset of each plane.
+type Meta struct {
+	ptr *C.GstAudioMeta
+}
+
+// AddMeta adds a Meta to the buffer describing samples samples per channel of the given info. offsets
+// holds the offset of each plane in bytes, and may be nil for non-interleaved buffers whose planes directly
+// follow each other.
+func AddMeta(buffer *gst.Buffer, info *Info, samples int64, offsets []int64) (*Meta, error) {
+	var cOffsets *C.gsize
+	if len(offsets) > 0 {
+		if len(offsets) != info.Channels() {
+			return nil, errors.New("There must be an offset for every channel")
+		}
+		arr := make([]C.gsize, len(offsets))
+		for i, o := range offsets {
+			arr[i] = C.gsize(o)
+		}
+		cOffsets = &arr[0]
+	}
+	meta := C.gst_buffer_add_audio_meta(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		info.instance(),
+		C.gsize(samples),
+		cOffsets,
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add audio meta")
+	}
+	return &Meta{ptr: meta}, nil
+}
+
+// GetMeta returns the Meta on the buffer, or nil if it has none.
+func GetMeta(buffer *gst.Buffer) *Meta {
+	meta := C.gst_buffer_get_audio_meta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &Meta{ptr: meta}
+}
+
+// Instance returns the underlying GstAudioMeta instance.
+func (m *Meta) Instance() *C.GstAudioMeta { return m.ptr }
+
+// Info returns a copy of the info of the samples in the buffer.
+func (m *Meta) Info() *Info {
+	return wrapInfo(C.gst_audio_info_copy(&m.ptr.info))
+}
+
+// Samples returns the number of samples per channel in the buffer.
+func (m *Meta) Samples() int64 { return int64(m.ptr.samples) }
+
+// Offsets returns the offset of each plane in bytes, for non-interleaved buffers. nil is returned for
+// interleaved buffers.
+func (m *Meta) Offsets() []int64 {
+	if m.ptr.offsets == nil || Layout(m.ptr.info.layout) != LayoutNonInterleaved {
+		return nil
+	}
+	channels := int(m.ptr.info.channels)
+	out := make([]int64, channels)
+	for i := range out {
+		out[i] = int64(C.audioMetaOffset(m.ptr, C.gint(i)))
+	}
+	return out
+}
@@ -0,0 +1,214 @@
+package audio
+
+/*
+#include <stdlib.h>
+#include <gst/audio/audio.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// ResamplerMethod is the method used for resampling.
+type ResamplerMethod int
+
+// Type castings
+const (
+	ResamplerMethodNearest         ResamplerMethod = C.GST_AUDIO_RESAMPLER_METHOD_NEAREST          // (0) – Duplicates the samples when upsampling and drops when downsampling
+	ResamplerMethodLinear          ResamplerMethod = C.GST_AUDIO_RESAMPLER_METHOD_LINEAR           // (1) – Uses linear interpolation to reconstruct missing samples and averaging to downsample
+	ResamplerMethodCubic           ResamplerMethod = C.GST_AUDIO_RESAMPLER_METHOD_CUBIC            // (2) – Uses cubic interpolation
+	ResamplerMethodBlackmanNuttall ResamplerMethod = C.GST_AUDIO_RESAMPLER_METHOD_BLACKMAN_NUTTALL // (3) – Uses Blackman-Nuttall windowed sinc interpolation
+	ResamplerMethodKaiser          ResamplerMethod = C.GST_AUDIO_RESAMPLER_METHOD_KAISER           // (4) – Uses Kaiser windowed sinc interpolation
+)
+
+// ResamplerFlags are extra flags passed to NewResampler.
+type ResamplerFlags int
+
+// Type castings
+const (
+	ResamplerFlagNone              ResamplerFlags = C.GST_AUDIO_RESAMPLER_FLAG_NONE                // (0) – no flags
+	ResamplerFlagNonInterleavedIn  ResamplerFlags = C.GST_AUDIO_RESAMPLER_FLAG_NON_INTERLEAVED_IN  // (1) – input samples are non-interleaved. an array of blocks of samples, one for each channel, should be passed to the resample function.
+	ResamplerFlagNonInterleavedOut ResamplerFlags = C.GST_AUDIO_RESAMPLER_FLAG_NON_INTERLEAVED_OUT // (2) – output samples are non-interleaved. an array of blocks of samples, one for each channel, should be passed to the resample function.
+	ResamplerFlagVariableRate      ResamplerFlags = C.GST_AUDIO_RESAMPLER_FLAG_VARIABLE_RATE       // (4) – optimize for dynamic updates of the sample rates with Update.
+)
+
+// Resampler quality bounds
+const (
+	ResamplerQualityMin     int = C.GST_AUDIO_RESAMPLER_QUALITY_MIN     // (0) – the lowest quality
+	ResamplerQualityDefault int = C.GST_AUDIO_RESAMPLER_QUALITY_DEFAULT // (4) – the default quality
+	ResamplerQualityMax     int = C.GST_AUDIO_RESAMPLER_QUALITY_MAX     // (10) – the highest quality
+)
+
+// Resampler is a go representation of a GstAudioResampler. It resamples audio of FormatS16, FormatS32,
+// FormatF32 or FormatF64 from one rate to another. Unlike a Converter it does not change the format or
+// channels of the samples.
+type Resampler struct {
+	ptr      *C.GstAudioResampler
+	flags    ResamplerFlags
+	format   Format
+	channels int
+	method   ResamplerMethod
+	quality  int
+}
+
+// NewResampler creates a new Resampler for the given number of channels of samples of format, converting
+// from inRate to outRate with the given method and quality.
+func NewResampler(method ResamplerMethod, flags ResamplerFlags, format Format, channels, inRate, outRate, quality int) (*Resampler, error) {
+	options := newResamplerOptions(method, quality, inRate, outRate)
+	defer C.gst_structure_free(options)
+	resampler := C.gst_audio_resampler_new(
+		C.GstAudioResamplerMethod(method),
+		C.GstAudioResamplerFlags(flags),
+		C.GstAudioFormat(format),
+		C.gint(channels),
+		C.gint(inRate),
+		C.gint(outRate),
+		options,
+	)
+	if resampler == nil {
+		return nil, fmt.Errorf("Could not create a resampler for %d channels of %s", channels, format)
+	}
+	r := &Resampler{
+		ptr:      resampler,
+		flags:    flags,
+		format:   format,
+		channels: channels,
+		method:   method,
+		quality:  quality,
+	}
+	runtime.SetFinalizer(r, (*Resampler).Free)
+	return r, nil
+}
+
+func newResamplerOptions(method ResamplerMethod, quality, inRate, outRate int) *C.GstStructure {
+	name := C.CString("GstAudioResampler.options")
+	defer C.free(unsafe.Pointer(name))
+	options := C.gst_structure_new_empty(name)
+	C.gst_audio_resampler_options_set_quality(
+		C.GstAudioResamplerMethod(method),
+		C.guint(quality),
+		C.gint(inRate),
+		C.gint(outRate),
+		options,
+	)
+	return options
+}
+
+// Free frees the resources of the resampler. It cannot be used afterwards.
+func (r *Resampler) Free() {
+	if r.ptr == nil {
+		return
+	}
+	C.gst_audio_resampler_free(r.ptr)
+	r.ptr = nil
+}
+
+// GetOutFrames returns the number of output frames produced when resampling inFrames input frames.
+func (r *Resampler) GetOutFrames(inFrames int64) int64 {
+	return int64(C.gst_audio_resampler_get_out_frames(r.ptr, C.gsize(inFrames)))
+}
+
+// GetInFrames returns the number of input frames needed to produce outFrames output frames.
+func (r *Resampler) GetInFrames(outFrames int64) int64 {
+	return int64(C.gst_audio_resampler_get_in_frames(r.ptr, C.gsize(outFrames)))
+}
+
+// GetMaxLatency returns the maximum number of input frames the resampler will need before producing
+// output frames.
+func (r *Resampler) GetMaxLatency() int64 {
+	return int64(C.gst_audio_resampler_get_max_latency(r.ptr))
+}
+
+// Reset resets the resampler to its initial state, dropping any samples it holds on to.
+func (r *Resampler) Reset() { C.gst_audio_resampler_reset(r.ptr) }
+
+// Update sets new rates on the resampler. This requires ResamplerFlagVariableRate to have been passed to
+// NewResampler.
+func (r *Resampler) Update(inRate, outRate int) error {
+	options := newResamplerOptions(r.method, r.quality, inRate, outRate)
+	defer C.gst_structure_free(options)
+	if !gobool(C.gst_audio_resampler_update(r.ptr, C.gint(inRate), C.gint(outRate), options)) {
+		return errors.New("Failed to update the resampler rates")
+	}
+	return nil
+}
+
+// Resample resamples the planes of input samples and returns the planes of output samples. For
+// interleaved input there must be a single plane, otherwise one plane per channel. The output is
+// interleaved unless ResamplerFlagNonInterleavedOut was passed to NewResampler.
+func (r *Resampler) Resample(planes [][]byte) ([][]byte, error) {
+	inInterleaved := r.flags&ResamplerFlagNonInterleavedIn == 0
+	outInterleaved := r.flags&ResamplerFlagNonInterleavedOut == 0
+	bps := r.format.Info().Width() / 8
+	inStride, outStride, inPlanes, outPlanes := bps, bps, r.channels, r.channels
+	if inInterleaved {
+		inStride, inPlanes = bps*r.channels, 1
+	}
+	if outInterleaved {
+		outStride, outPlanes = bps*r.channels, 1
+	}
+	if len(planes) != inPlanes {
+		return nil, fmt.Errorf("Expected %d input planes, got %d", inPlanes, len(planes))
+	}
+	inFrames := int64(len(planes[0]) / inStride)
+	for _, plane := range planes {
+		if len(plane) != int(inFrames)*inStride {
+			return nil, errors.New("Input planes must hold the same number of whole frames")
+		}
+	}
+	outFrames := r.GetOutFrames(inFrames)
+	in := newCPlanes(inPlanes, len(planes[0]), planes)
+	defer in.free()
+	out := newCPlanes(outPlanes, int(outFrames)*outStride, nil)
+	defer out.free()
+	C.gst_audio_resampler_resample(r.ptr, in.ptr, C.gsize(inFrames), out.ptr, C.gsize(outFrames))
+	return out.bytes(int(outFrames) * outStride), nil
+}
+
+// ResampleSamples resamples the given samples, which must be a slice of the go type matching the format
+// of the resampler for interleaved input, or a slice of such slices with one per channel for non-interleaved
+// input. The output samples are returned the same way.
+func (r *Resampler) ResampleSamples(samples interface{}) (interface{}, error) {
+	planes, err := samplesToPlanes(samples, r.format)
+	if err != nil {
+		return nil, err
+	}
+	out, err := r.Resample(planes)
+	if err != nil {
+		return nil, err
+	}
+	return planesToSamples(out, r.format, r.outLayout())
+}
+
+// Drain returns the planes of output samples still held by the resampler, by feeding it GetMaxLatency
+// frames of silence. It should be called at the end of a stream, after which Reset must be called before
+// resampling a new one. The planes are returned the same way as Resample does.
+func (r *Resampler) Drain() [][]byte {
+	outInterleaved := r.flags&ResamplerFlagNonInterleavedOut == 0
+	outStride, outPlanes := r.format.Info().Width()/8, r.channels
+	if outInterleaved {
+		outStride, outPlanes = outStride*r.channels, 1
+	}
+	inFrames := r.GetMaxLatency()
+	outFrames := r.GetOutFrames(inFrames)
+	out := newCPlanes(outPlanes, int(outFrames)*outStride, nil)
+	defer out.free()
+	C.gst_audio_resampler_resample(r.ptr, nil, C.gsize(inFrames), out.ptr, C.gsize(outFrames))
+	return out.bytes(int(outFrames) * outStride)
+}
+
+// DrainSamples is like Drain, but returns the output samples the same way as ResampleSamples does.
+func (r *Resampler) DrainSamples() (interface{}, error) {
+	return planesToSamples(r.Drain(), r.format, r.outLayout())
+}
+
+func (r *Resampler) outLayout() Layout {
+	if r.flags&ResamplerFlagNonInterleavedOut != 0 {
+		return LayoutNonInterleaved
+	}
+	return LayoutInterleaved
+}
@@ -0,0 +1,218 @@
+package audio
+
+/*
+#include <stdlib.h>
+#include <string.h>
+#include <glib.h>
+
+gpointer * allocPlanes (gint n)                               { return calloc(n, sizeof(gpointer)); }
+gpointer   planeAt     (gpointer * planes, gint i)            { return planes[i]; }
+void       setPlaneAt  (gpointer * planes, gint i, gpointer p) { planes[i] = p; }
+
+void freePlanes (gpointer * planes, gint n)
+{
+	gint i;
+	for (i = 0; i < n; i++)
+		free(planes[i]);
+	free(planes);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// cPlanes holds copies of sample planes in C memory, so they can be passed to the converter and resampler.
+type cPlanes struct {
+	ptr *C.gpointer
+	n   int
+}
+
+// newCPlanes allocates n planes of size bytes each, copying data into them if it is not nil.
+func newCPlanes(n, size int, data [][]byte) *cPlanes {
+	planes := &cPlanes{ptr: C.allocPlanes(C.gint(n)), n: n}
+	for i := 0; i < n; i++ {
+		plane := C.calloc(1, C.size_t(size))
+		if data != nil && len(data[i]) > 0 {
+			C.memcpy(plane, unsafe.Pointer(&data[i][0]), C.size_t(len(data[i])))
+		}
+		C.setPlaneAt(planes.ptr, C.gint(i), C.gpointer(plane))
+	}
+	return planes
+}
+
+// bytes returns a copy of the first size bytes of every plane.
+func (c *cPlanes) bytes(size int) [][]byte {
+	out := make([][]byte, c.n)
+	for i := range out {
+		out[i] = C.GoBytes(unsafe.Pointer(C.planeAt(c.ptr, C.gint(i))), C.int(size))
+	}
+	return out
+}
+
+func (c *cPlanes) free() { C.freePlanes(c.ptr, C.gint(c.n)) }
+
+// sampleFormat returns the format matching the go type of the samples in data, which is either a slice of
+// samples or a slice of slices of samples.
+func sampleFormat(data interface{}) (Format, error) {
+	switch data.(type) {
+	case []uint8:
+		return FormatU8, nil
+	case []int16:
+		return FormatS16, nil
+	case []int32:
+		return FormatS32, nil
+	case []float32:
+		return FormatF32, nil
+	case []float64:
+		return FormatF64, nil
+	case [][]uint8:
+		return FormatU8, nil
+	case [][]int16:
+		return FormatS16, nil
+	case [][]int32:
+		return FormatS32, nil
+	case [][]float32:
+		return FormatF32, nil
+	case [][]float64:
+		return FormatF64, nil
+	}
+	return FormatUnknown, fmt.Errorf("Unsupported sample type %T", data)
+}
+
+// samplesToPlanes returns the bytes of the samples in data, which is either a slice of samples holding a
+// single interleaved plane, or a slice of slices of samples holding one plane per channel. An error is
+// returned if the go type of the samples does not match format.
+func samplesToPlanes(data interface{}, format Format) ([][]byte, error) {
+	dataFormat, err := sampleFormat(data)
+	if err != nil {
+		return nil, err
+	}
+	if dataFormat != format {
+		return nil, fmt.Errorf("Samples of type %T cannot hold samples of format %s", data, format)
+	}
+	switch samples := data.(type) {
+	case []uint8:
+		return [][]byte{samples}, nil
+	case []int16:
+		return [][]byte{int16Bytes(samples)}, nil
+	case []int32:
+		return [][]byte{int32Bytes(samples)}, nil
+	case []float32:
+		return [][]byte{float32Bytes(samples)}, nil
+	case []float64:
+		return [][]byte{float64Bytes(samples)}, nil
+	case [][]uint8:
+		return samples, nil
+	case [][]int16:
+		out := make([][]byte, len(samples))
+		for i := range samples {
+			out[i] = int16Bytes(samples[i])
+		}
+		return out, nil
+	case [][]int32:
+		out := make([][]byte, len(samples))
+		for i := range samples {
+			out[i] = int32Bytes(samples[i])
+		}
+		return out, nil
+	case [][]float32:
+		out := make([][]byte, len(samples))
+		for i := range samples {
+			out[i] = float32Bytes(samples[i])
+		}
+		return out, nil
+	default:
+		samples := data.([][]float64)
+		out := make([][]byte, len(samples))
+		for i := range samples {
+			out[i] = float64Bytes(samples[i])
+		}
+		return out, nil
+	}
+}
+
+// The following return byte slices sharing the memory of the given samples.
+
+func int16Bytes(s []int16) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafeBytes(unsafe.Pointer(&s[0]), len(s)*2)
+}
+
+func int32Bytes(s []int32) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafeBytes(unsafe.Pointer(&s[0]), len(s)*4)
+}
+
+func float32Bytes(s []float32) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafeBytes(unsafe.Pointer(&s[0]), len(s)*4)
+}
+
+func float64Bytes(s []float64) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafeBytes(unsafe.Pointer(&s[0]), len(s)*8)
+}
+
+// planesToSamples converts the given planes to a slice of samples of the go type matching format if there
+// is a single interleaved plane, or to a slice of slices of samples otherwise.
+func planesToSamples(planes [][]byte, format Format, layout Layout) (interface{}, error) {
+	switch format {
+	case FormatU8:
+		if layout == LayoutInterleaved {
+			return planes[0], nil
+		}
+		return planes, nil
+	case FormatS16:
+		out := make([][]int16, len(planes))
+		for i, p := range planes {
+			out[i] = make([]int16, len(p)/2)
+			copy(int16Bytes(out[i]), p)
+		}
+		if layout == LayoutInterleaved {
+			return out[0], nil
+		}
+		return out, nil
+	case FormatS32:
+		out := make([][]int32, len(planes))
+		for i, p := range planes {
+			out[i] = make([]int32, len(p)/4)
+			copy(int32Bytes(out[i]), p)
+		}
+		if layout == LayoutInterleaved {
+			return out[0], nil
+		}
+		return out, nil
+	case FormatF32:
+		out := make([][]float32, len(planes))
+		for i, p := range planes {
+			out[i] = make([]float32, len(p)/4)
+			copy(float32Bytes(out[i]), p)
+		}
+		if layout == LayoutInterleaved {
+			return out[0], nil
+		}
+		return out, nil
+	case FormatF64:
+		out := make([][]float64, len(planes))
+		for i, p := range planes {
+			out[i] = make([]float64, len(p)/8)
+			copy(float64Bytes(out[i]), p)
+		}
+		if layout == LayoutInterleaved {
+			return out[0], nil
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("No go sample type for format %s", format)
+}
@@ -1,6 +1,11 @@
 package gst
 
-// #include "gst.go.h"
+/*
+#include "gst.go.h"
+
+gchar ** newStrv (guint n) { return g_new0(gchar *, n + 1); }
+void     setStrv (gchar ** strv, guint idx, const gchar * str) { strv[idx] = g_strdup(str); }
+*/
 import "C"
 
 import (
@@ -70,6 +75,17 @@ func goStrings(argc C.int, argv **C.gchar) []string {
 	return gostrings
 }
 
+// newStrv returns a newly allocated NULL terminated array of the given strings, to be freed with g_strfreev.
+func newStrv(strs []string) **C.gchar {
+	strv := C.newStrv(C.guint(len(strs)))
+	for i, str := range strs {
+		cStr := C.CString(str)
+		C.setStrv(strv, C.guint(i), (*C.gchar)(unsafe.Pointer(cStr)))
+		C.free(unsafe.Pointer(cStr))
+	}
+	return strv
+}
+
 func gcharStrings(strs []string) **C.gchar {
 	gcharSlc := make([]*C.gchar, len(strs))
 	for _, s := range strs {
@@ -7,6 +7,7 @@ package gst
 import "C"
 
 import (
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
@@ -15,13 +16,15 @@ import (
 
 //export goElementCallAsync
 func goElementCallAsync(element *C.GstElement, userData C.gpointer) {
+	defer RecoverCallbackUnsafe(unsafe.Pointer(element), nil)
 	iface := gopointer.Restore(unsafe.Pointer(userData))
 	f := iface.(func())
 	f()
 }
 
 //export goPadStickyEventForEachFunc
-func goPadStickyEventForEachFunc(gpad *C.GstPad, event **C.GstEvent, userData C.gpointer) C.gboolean {
+func goPadStickyEventForEachFunc(gpad *C.GstPad, event **C.GstEvent, userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(unsafe.Pointer(gpad), func() { ret = gboolean(false) })
 	cbIface := gopointer.Restore(unsafe.Pointer(userData))
 	cbFunc := cbIface.(StickyEventsForEachFunc)
 	pad := wrapPad(toGObject(unsafe.Pointer(gpad)))
@@ -30,15 +33,23 @@ func goPadStickyEventForEachFunc(gpad *C.GstPad, event **C.GstEvent, userData C.
 }
 
 //export goPadProbeFunc
-func goPadProbeFunc(gstPad *C.GstPad, info *C.GstPadProbeInfo, userData C.gpointer) C.GstPadProbeReturn {
-	cbIface := gopointer.Restore(unsafe.Pointer(userData))
-	cbFunc := cbIface.(PadProbeCallback)
+func goPadProbeFunc(gstPad *C.GstPad, info *C.GstPadProbeInfo, userData C.gpointer) (ret C.GstPadProbeReturn) {
+	defer RecoverCallbackUnsafe(unsafe.Pointer(gstPad), func() { ret = C.GstPadProbeReturn(PadProbeDrop) })
+	probe := gopointer.Restore(unsafe.Pointer(userData)).(*padProbe)
 	pad := wrapPad(toGObject(unsafe.Pointer(gstPad)))
-	return C.GstPadProbeReturn(cbFunc(pad, &PadProbeInfo{info}))
+	return C.GstPadProbeReturn(probe.f(pad, &PadProbeInfo{info}))
+}
+
+//export goPadProbeDestroyNotify
+func goPadProbeDestroyNotify(userData C.gpointer) {
+	probe := gopointer.Restore(unsafe.Pointer(userData)).(*padProbe)
+	atomic.StoreInt32(&probe.removed, 1)
+	unrefPointer(unsafe.Pointer(userData))
 }
 
 //export goPadForwardFunc
-func goPadForwardFunc(gstPad *C.GstPad, userData C.gpointer) C.gboolean {
+func goPadForwardFunc(gstPad *C.GstPad, userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(unsafe.Pointer(gstPad), func() { ret = gboolean(false) })
 	cbIface := gopointer.Restore(unsafe.Pointer(userData))
 	cbFunc := cbIface.(PadForwardFunc)
 	pad := wrapPad(toGObject(unsafe.Pointer(gstPad)))
@@ -47,20 +58,23 @@ func goPadForwardFunc(gstPad *C.GstPad, userData C.gpointer) C.gboolean {
 
 //export goTagForEachFunc
 func goTagForEachFunc(tagList *C.GstTagList, tag *C.gchar, userData C.gpointer) {
+	defer RecoverCallbackUnsafe(nil, nil)
 	cbIface := gopointer.Restore(unsafe.Pointer(userData))
 	cbFunc := cbIface.(TagListForEachFunc)
 	cbFunc(wrapTagList(tagList), Tag(C.GoString(tag)))
 }
 
 //export goBufferListForEachCb
-func goBufferListForEachCb(buf **C.GstBuffer, idx C.guint, userData C.gpointer) C.gboolean {
+func goBufferListForEachCb(buf **C.GstBuffer, idx C.guint, userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
 	cbIface := gopointer.Restore(unsafe.Pointer(userData))
 	cbFunc := cbIface.(func(*Buffer, uint) bool)
 	return gboolean(cbFunc(wrapBuffer(*buf), uint(idx)))
 }
 
 //export goBufferMetaForEachCb
-func goBufferMetaForEachCb(buf *C.GstBuffer, meta **C.GstMeta, userData C.gpointer) C.gboolean {
+func goBufferMetaForEachCb(buf *C.GstBuffer, meta **C.GstMeta, userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
 	cbIface := gopointer.Restore(unsafe.Pointer(userData))
 	cbFunc := cbIface.(func(*Meta) bool)
 	return gboolean(cbFunc(wrapMeta(*meta)))
@@ -88,7 +102,10 @@ func structForEachCb(fieldID C.GQuark, val *C.GValue, chPtr C.gpointer) C.gboole
 }
 
 //export goBusSyncHandler
-func goBusSyncHandler(bus *C.GstBus, cMsg *C.GstMessage, userData C.gpointer) C.GstBusSyncReply {
+func goBusSyncHandler(bus *C.GstBus, cMsg *C.GstMessage, userData C.gpointer) (ret C.GstBusSyncReply) {
+	// posting to the bus would call the handler again synchronously, so the panic is only printed
+	defer RecoverCallbackUnsafe(nil, func() { ret = C.GstBusSyncReply(BusPass) })
+
 	// wrap the message
 	msg := wrapMessage(cMsg)
 
@@ -98,7 +115,6 @@ func goBusSyncHandler(bus *C.GstBus, cMsg *C.GstMessage, userData C.gpointer) C.
 	busFunc, ok := funcIface.(BusSyncHandler)
 
 	if !ok {
-		gopointer.Unref(ptr)
 		return C.GstBusSyncReply(BusPass)
 	}
 
@@ -106,26 +122,22 @@ func goBusSyncHandler(bus *C.GstBus, cMsg *C.GstMessage, userData C.gpointer) C.
 }
 
 //export goBusFunc
-func goBusFunc(bus *C.GstBus, cMsg *C.GstMessage, userData C.gpointer) C.gboolean {
+func goBusFunc(bus *C.GstBus, cMsg *C.GstMessage, userData C.gpointer) (ret C.gboolean) {
+	// the watch is removed on panic, since the error posted to the bus would be dispatched to it again
+	defer RecoverCallbackUnsafe(unsafe.Pointer(bus), func() { ret = gboolean(false) })
+
 	// wrap the message
 	msg := wrapMessage(cMsg)
 
-	// retrieve the ptr to the function
-	ptr := unsafe.Pointer(userData)
-	funcIface := gopointer.Restore(ptr)
+	// retrieve the ptr to the function, it is released by the destroy notify of the watch
+	funcIface := gopointer.Restore(unsafe.Pointer(userData))
 	busFunc, ok := funcIface.(BusWatchFunc)
 	if !ok {
-		gopointer.Unref(ptr)
 		return gboolean(false)
 	}
 
 	// run the call back
-	if cont := busFunc(msg); !cont {
-		gopointer.Unref(ptr)
-		return gboolean(false)
-	}
-
-	return gboolean(true)
+	return gboolean(busFunc(msg))
 }
 
 func getMetaInfoCbFuncs(meta *C.GstMeta) *MetaInfoCallbackFuncs {
@@ -140,46 +152,229 @@ func getMetaInfoCbFuncs(meta *C.GstMeta) *MetaInfoCallbackFuncs {
 
 //export goMetaFreeFunc
 func goMetaFreeFunc(meta *C.GstMeta, buf *C.GstBuffer) {
+	defer RecoverCallbackUnsafe(nil, nil)
 	cbFuncs := getMetaInfoCbFuncs(meta)
 	if cbFuncs != nil && cbFuncs.FreeFunc != nil {
-		cbFuncs.FreeFunc(wrapBuffer(buf))
+		// the buffer is being freed, so it must not be wrapped with a reference
+		cbFuncs.FreeFunc(&Buffer{ptr: buf})
 	}
 }
 
 //export goMetaInitFunc
-func goMetaInitFunc(meta *C.GstMeta, params C.gpointer, buf *C.GstBuffer) C.gboolean {
+func goMetaInitFunc(meta *C.GstMeta, params C.gpointer, buf *C.GstBuffer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
 	cbFuncs := getMetaInfoCbFuncs(meta)
 	if cbFuncs != nil && cbFuncs.InitFunc != nil {
 		paramsIface := gopointer.Restore(unsafe.Pointer(params))
-		defer gopointer.Unref(unsafe.Pointer(params))
-		return gboolean(cbFuncs.InitFunc(paramsIface, wrapBuffer(buf)))
+		defer unrefPointer(unsafe.Pointer(params))
+		// the buffer needs to stay writable while metas are added to it
+		return gboolean(cbFuncs.InitFunc(paramsIface, &Buffer{ptr: buf}))
 	}
 	return gboolean(true)
 }
 
 //export goMetaTransformFunc
-func goMetaTransformFunc(transBuf *C.GstBuffer, meta *C.GstMeta, buffer *C.GstBuffer, mType C.GQuark, data C.gpointer) C.gboolean {
+func goMetaTransformFunc(transBuf *C.GstBuffer, meta *C.GstMeta, buffer *C.GstBuffer, mType C.GQuark, data C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
 	cbFuncs := getMetaInfoCbFuncs(meta)
 	if cbFuncs != nil && cbFuncs.TransformFunc != nil {
-		transformData := (*C.GstMetaTransformCopy)(unsafe.Pointer(data))
+		transformType := quarkToString(mType)
 		return gboolean(cbFuncs.TransformFunc(
-			wrapBuffer(transBuf),
-			wrapBuffer(buffer),
-			quarkToString(mType),
-			&MetaTransformCopy{
-				Region: gobool(transformData.region),
-				Offset: int64(transformData.offset),
-				Size:   int64(transformData.size),
-			},
+			&Buffer{ptr: transBuf},
+			&Buffer{ptr: buffer},
+			transformType,
+			wrapMetaTransformData(transformType, data),
 		))
 	}
 	return gboolean(true)
 }
 
+//export goCustomMetaTransform
+func goCustomMetaTransform(transBuf *C.GstBuffer, meta *C.GstMeta, buffer *C.GstBuffer, mType C.GQuark, data C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	info := getCustomMetaInfoFor(meta)
+	if info == nil {
+		return gboolean(false)
+	}
+	transformType := quarkToString(mType)
+	customMeta := &CustomMeta{Meta: wrapMeta(meta), info: info}
+	return gboolean(customMeta.transform(
+		&Buffer{ptr: transBuf},
+		&Buffer{ptr: buffer},
+		transformType,
+		wrapMetaTransformData(transformType, data),
+	))
+}
+
+//export goPromiseChangeFunc
+func goPromiseChangeFunc(promise *C.GstPromise, userData C.gpointer) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	f := gopointer.Restore(unsafe.Pointer(userData)).(PromiseChangeFunc)
+	f(wrapPromise(promise))
+}
+
+//export goTypeFindFunc
+func goTypeFindFunc(find *C.GstTypeFind, userData C.gpointer) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	f := gopointer.Restore(unsafe.Pointer(userData)).(TypeFindFunc)
+	f(&TypeFind{ptr: find})
+}
+
+//export goAllocatorAlloc
+func goAllocatorAlloc(allocator *C.GstAllocator, maxSize, align C.gsize, handle *C.gpointer) (ret C.gpointer) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = nil })
+	impl := getGoAllocatorImpl(allocator)
+	data, goHandle := impl.Alloc(int64(maxSize), int64(align))
+	if len(data) == 0 {
+		return nil
+	}
+	// GstMemory expects the data to honor the requested alignment, so misaligned blocks are rejected
+	// the same way as blocks that are too short.
+	if len(data) < int(maxSize) || uintptr(unsafe.Pointer(&data[0]))&uintptr(align) != 0 {
+		impl.Free(data, goHandle)
+		return nil
+	}
+	if goHandle != nil {
+		*handle = (C.gpointer)(savePointer(goHandle))
+	}
+	return (C.gpointer)(unsafe.Pointer(&data[0]))
+}
+
+//export goAllocatorFree
+func goAllocatorFree(allocator *C.GstAllocator, data C.gpointer, maxSize C.gsize, handle C.gpointer) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	var goHandle interface{}
+	if handle != nil {
+		goHandle = gopointer.Restore(unsafe.Pointer(handle))
+		defer unrefPointer(unsafe.Pointer(handle))
+	}
+	getGoAllocatorImpl(allocator).Free(cBytes(unsafe.Pointer(data), int(maxSize)), goHandle)
+}
+
+//export goAllocatorMap
+func goAllocatorMap(allocator *C.GstAllocator, mem *C.GstMemory, flags C.GstMapFlags) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	if mapper, ok := getGoAllocatorImpl(allocator).(AllocatorMapper); ok {
+		return gboolean(mapper.Map(wrapMemory(mem), MapFlags(flags)))
+	}
+	return gboolean(true)
+}
+
+//export goAllocatorUnmap
+func goAllocatorUnmap(allocator *C.GstAllocator, mem *C.GstMemory) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	if mapper, ok := getGoAllocatorImpl(allocator).(AllocatorMapper); ok {
+		mapper.Unmap(wrapMemory(mem))
+	}
+}
+
+//export goAllocatorShare
+func goAllocatorShare(allocator *C.GstAllocator, mem *C.GstMemory, offset, size C.gssize) (ret *C.GstMemory) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = nil })
+	shared := getGoAllocatorImpl(allocator).(AllocatorSharer).Share(wrapMemory(mem), int64(offset), int64(size))
+	if shared == nil {
+		return nil
+	}
+	return shared.give()
+}
+
+//export goAllocatorCopy
+func goAllocatorCopy(allocator *C.GstAllocator, mem *C.GstMemory, offset, size C.gssize) (ret *C.GstMemory) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = nil })
+	copied := getGoAllocatorImpl(allocator).(AllocatorCopier).Copy(wrapMemory(mem), int64(offset), int64(size))
+	if copied == nil {
+		return nil
+	}
+	return copied.give()
+}
+
+//export goAllocatorIsSpan
+func goAllocatorIsSpan(allocator *C.GstAllocator, mem1, mem2 *C.GstMemory, offset *C.gsize) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	off, ok := getGoAllocatorImpl(allocator).(AllocatorSpanChecker).IsSpan(wrapMemory(mem1), wrapMemory(mem2))
+	if ok && offset != nil {
+		*offset = C.gsize(off)
+	}
+	return gboolean(ok)
+}
+
+//export goBufferPoolGetOptions
+func goBufferPoolGetOptions(pool *C.GstBufferPool) (ret **C.gchar) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = newStrv(nil) })
+	opts := getBufferPoolCallbacks(pool).GetOptionsFunc(wrapBufferPool(toGObject(unsafe.Pointer(pool))))
+	return newStrv(opts)
+}
+
+//export goBufferPoolSetConfig
+func goBufferPoolSetConfig(pool *C.GstBufferPool, config *C.GstStructure) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	cfg := BufferPoolConfig(*wrapStructure(config))
+	return gboolean(getBufferPoolCallbacks(pool).SetConfigFunc(wrapBufferPool(toGObject(unsafe.Pointer(pool))), &cfg))
+}
+
+//export goBufferPoolStart
+func goBufferPoolStart(pool *C.GstBufferPool) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	return gboolean(getBufferPoolCallbacks(pool).StartFunc(wrapBufferPool(toGObject(unsafe.Pointer(pool)))))
+}
+
+//export goBufferPoolStop
+func goBufferPoolStop(pool *C.GstBufferPool) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	return gboolean(getBufferPoolCallbacks(pool).StopFunc(wrapBufferPool(toGObject(unsafe.Pointer(pool)))))
+}
+
+//export goBufferPoolAcquireBuffer
+func goBufferPoolAcquireBuffer(pool *C.GstBufferPool, buffer **C.GstBuffer, params *C.GstBufferPoolAcquireParams) (ret C.GstFlowReturn) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = C.GstFlowReturn(FlowError) })
+	buf, flow := getBufferPoolCallbacks(pool).AcquireBufferFunc(
+		wrapBufferPool(toGObject(unsafe.Pointer(pool))),
+		wrapBufferPoolAcquireParams(params),
+	)
+	if buf != nil {
+		*buffer = buf.give()
+	}
+	return C.GstFlowReturn(flow)
+}
+
+//export goBufferPoolAllocBuffer
+func goBufferPoolAllocBuffer(pool *C.GstBufferPool, buffer **C.GstBuffer, params *C.GstBufferPoolAcquireParams) (ret C.GstFlowReturn) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = C.GstFlowReturn(FlowError) })
+	buf, flow := getBufferPoolCallbacks(pool).AllocBufferFunc(
+		wrapBufferPool(toGObject(unsafe.Pointer(pool))),
+		wrapBufferPoolAcquireParams(params),
+	)
+	if buf != nil {
+		*buffer = buf.give()
+	}
+	return C.GstFlowReturn(flow)
+}
+
+//export goBufferPoolResetBuffer
+func goBufferPoolResetBuffer(pool *C.GstBufferPool, buffer *C.GstBuffer) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	// The buffer is borrowed without taking a reference, which would make it read-only for the
+	// function and for the pool until the wrapper is finalized.
+	getBufferPoolCallbacks(pool).ResetBufferFunc(wrapBufferPool(toGObject(unsafe.Pointer(pool))), &Buffer{ptr: buffer})
+}
+
+//export goBufferPoolReleaseBuffer
+func goBufferPoolReleaseBuffer(pool *C.GstBufferPool, buffer *C.GstBuffer) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	getBufferPoolCallbacks(pool).ReleaseBufferFunc(wrapBufferPool(toGObject(unsafe.Pointer(pool))), takeBuffer(buffer))
+}
+
+//export goBufferPoolFreeBuffer
+func goBufferPoolFreeBuffer(pool *C.GstBufferPool, buffer *C.GstBuffer) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	getBufferPoolCallbacks(pool).FreeBufferFunc(wrapBufferPool(toGObject(unsafe.Pointer(pool))), takeBuffer(buffer))
+}
+
 //export goGDestroyNotifyFunc
 func goGDestroyNotifyFunc(ptr C.gpointer) {
+	defer RecoverCallbackUnsafe(nil, nil)
 	funcIface := gopointer.Restore(unsafe.Pointer(ptr))
-	defer gopointer.Unref(unsafe.Pointer(ptr))
+	defer unrefPointer(unsafe.Pointer(ptr))
 	f := funcIface.(func())
 	if f != nil {
 		f()
@@ -188,18 +383,20 @@ func goGDestroyNotifyFunc(ptr C.gpointer) {
 
 //export goGDestroyNotifyFuncNoRun
 func goGDestroyNotifyFuncNoRun(ptr C.gpointer) {
-	gopointer.Unref(unsafe.Pointer(ptr))
+	unrefPointer(unsafe.Pointer(ptr))
 }
 
 //export goCapsMapFunc
-func goCapsMapFunc(features *C.GstCapsFeatures, structure *C.GstStructure, userData C.gpointer) C.gboolean {
+func goCapsMapFunc(features *C.GstCapsFeatures, structure *C.GstStructure, userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+
 	// retrieve the ptr to the function
 	ptr := unsafe.Pointer(userData)
 	funcIface := gopointer.Restore(ptr)
 	mapFunc, ok := funcIface.(CapsMapFunc)
 
 	if !ok {
-		gopointer.Unref(ptr)
+		unrefPointer(ptr)
 		return gboolean(false)
 	}
 
@@ -207,17 +404,44 @@ func goCapsMapFunc(features *C.GstCapsFeatures, structure *C.GstStructure, userD
 }
 
 //export goClockCb
-func goClockCb(gclock *C.GstClock, clockTime C.GstClockTime, clockID C.GstClockID, userData C.gpointer) C.gboolean {
+func goClockCb(gclock *C.GstClock, clockTime C.GstClockTime, clockID C.GstClockID, userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+
 	// retrieve the ptr to the function
 	ptr := unsafe.Pointer(userData)
 	funcIface := gopointer.Restore(ptr)
 	cb, ok := funcIface.(ClockCallback)
 
 	if !ok {
-		gopointer.Unref(ptr)
+		unrefPointer(ptr)
 		return gboolean(false)
 	}
 
 	clock := wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(gclock))})
 	return gboolean(cb(clock, ClockTime(clockTime)))
 }
+
+//export goBusSyncMessage
+func goBusSyncMessage(bus *C.GstBus, msg *C.GstMessage, userData C.gpointer) {
+	dispatchBusMessage(bus, msg)
+}
+
+//export goBusWeakNotify
+func goBusWeakNotify(userData C.gpointer, bus *C.GObject) {
+	busFinalized((*C.GstBus)(unsafe.Pointer(bus)))
+}
+
+//export goSourceFunc
+func goSourceFunc(userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	f := gopointer.Restore(unsafe.Pointer(userData)).(SourceFunc)
+	return gboolean(f())
+}
+
+//export goInvokeFunc
+func goInvokeFunc(userData C.gpointer) C.gboolean {
+	defer RecoverCallbackUnsafe(nil, nil)
+	f := gopointer.Restore(unsafe.Pointer(userData)).(func())
+	f()
+	return gboolean(false)
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+// +build !windows
+
+package gst
+
+// #include <gst/gst.h>
+import "C"
+
+import (
+	"unsafe"
+
+	gopointer "github.com/mattn/go-pointer"
+)
+
+//export goChildWatchFunc
+func goChildWatchFunc(pid C.GPid, status C.gint, userData C.gpointer) {
+	defer RecoverCallbackUnsafe(nil, nil)
+	f := gopointer.Restore(unsafe.Pointer(userData)).(ChildWatchFunc)
+	f(int(pid), int(status))
+}
+
+//export goUnixFDSourceFunc
+func goUnixFDSourceFunc(fd C.gint, condition C.GIOCondition, userData C.gpointer) (ret C.gboolean) {
+	defer RecoverCallbackUnsafe(nil, func() { ret = gboolean(false) })
+	f := gopointer.Restore(unsafe.Pointer(userData)).(UnixFDSourceFunc)
+	return gboolean(f(int(fd), IOCondition(condition)))
+}
@@ -442,6 +442,32 @@ func (p PadPresence) String() string {
 	return ""
 }
 
+// PromiseResult casts GstPromiseResult to a go type.
+type PromiseResult int
+
+// Type castings of PromiseResults
+const (
+	PromiseResultPending     PromiseResult = C.GST_PROMISE_RESULT_PENDING     // (0) – Initial state. Waiting for transition to any other state.
+	PromiseResultInterrupted PromiseResult = C.GST_PROMISE_RESULT_INTERRUPTED // (1) – Interrupted by the consumer as it doesn't want the value anymore.
+	PromiseResultReplied     PromiseResult = C.GST_PROMISE_RESULT_REPLIED     // (2) – A producer marked a reply
+	PromiseResultExpired     PromiseResult = C.GST_PROMISE_RESULT_EXPIRED     // (3) – The promise expired (the carrying object lost all refs) and the promise will never be fulfilled.
+)
+
+// String implements a stringer on PromiseResult.
+func (p PromiseResult) String() string {
+	switch p {
+	case PromiseResultPending:
+		return "Pending"
+	case PromiseResultInterrupted:
+		return "Interrupted"
+	case PromiseResultReplied:
+		return "Replied"
+	case PromiseResultExpired:
+		return "Expired"
+	}
+	return ""
+}
+
 // PadProbeReturn casts GstPadProbeReturn
 type PadProbeReturn int
 
@@ -809,6 +835,64 @@ const (
 	TaskPaused  TaskState = C.GST_TASK_PAUSED  // (2) – the task is paused
 )
 
+// Rank casts GstRank. Ranks are used by autoplugging elements such as decodebin to choose between
+// features that can handle the same media.
+type Rank uint
+
+// Type castings of Ranks
+const (
+	RankNone      Rank = C.GST_RANK_NONE      // (0) – will be chosen last or not at all
+	RankMarginal  Rank = C.GST_RANK_MARGINAL  // (64) – unlikely to be chosen
+	RankSecondary Rank = C.GST_RANK_SECONDARY // (128) – likely to be chosen
+	RankPrimary   Rank = C.GST_RANK_PRIMARY   // (256) – will be chosen first
+)
+
+// ElementFactoryListType casts GstElementFactoryListType. It is used with ListElementFactories to select
+// factories by their klass, and can be combined with the bitwise or operator.
+type ElementFactoryListType uint64
+
+// Type castings of ElementFactoryListTypes
+const (
+	ElementFactoryTypeDecoder         ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_DECODER          // (1) – decoder elements
+	ElementFactoryTypeEncoder         ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_ENCODER          // (2) – encoder elements
+	ElementFactoryTypeSink            ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_SINK             // (4) – sink elements
+	ElementFactoryTypeSrc             ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_SRC              // (8) – source elements
+	ElementFactoryTypeMuxer           ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MUXER            // (16) – muxer elements
+	ElementFactoryTypeDemuxer         ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_DEMUXER          // (32) – demuxer elements
+	ElementFactoryTypeParser          ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_PARSER           // (64) – parser elements
+	ElementFactoryTypePayloader       ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_PAYLOADER        // (128) – payloader elements
+	ElementFactoryTypeDepayloader     ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_DEPAYLOADER      // (256) – depayloader elements
+	ElementFactoryTypeFormatter       ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_FORMATTER        // (512) – formatter elements
+	ElementFactoryTypeDecryptor       ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_DECRYPTOR        // (1024) – decryptor elements
+	ElementFactoryTypeEncryptor       ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_ENCRYPTOR        // (2048) – encryptor elements
+	ElementFactoryTypeMaxElements     ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MAX_ELEMENTS     // (281474976710656) – elements of any of the above types
+	ElementFactoryTypeAny             ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_ANY              // (562949953421311) – any element
+	ElementFactoryTypeMediaAny        ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MEDIA_ANY        // (18446462598732840960) – elements handling any media type
+	ElementFactoryTypeMediaVideo      ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MEDIA_VIDEO      // (562949953421312) – elements handling video
+	ElementFactoryTypeMediaAudio      ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MEDIA_AUDIO      // (1125899906842624) – elements handling audio
+	ElementFactoryTypeMediaImage      ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MEDIA_IMAGE      // (2251799813685248) – elements handling images
+	ElementFactoryTypeMediaSubtitle   ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MEDIA_SUBTITLE   // (4503599627370496) – elements handling subtitles
+	ElementFactoryTypeMediaMetadata   ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_MEDIA_METADATA   // (9007199254740992) – elements handling metadata
+	ElementFactoryTypeAudioEncoder    ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_AUDIO_ENCODER    // audio encoders
+	ElementFactoryTypeVideoEncoder    ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_VIDEO_ENCODER    // video and image encoders
+	ElementFactoryTypeAudioVideoSinks ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_AUDIOVIDEO_SINKS // audio, video and image sinks
+	ElementFactoryTypeDecodable       ElementFactoryListType = C.GST_ELEMENT_FACTORY_TYPE_DECODABLE        // elements that decodebin can autoplug: decoders, demuxers, depayloaders, parsers and decryptors
+)
+
+// TypeFindProbability casts GstTypeFindProbability. It is the probability of a suggested type being
+// correct.
+type TypeFindProbability int
+
+// Type castings of TypeFindProbabilities
+const (
+	TypeFindNone          TypeFindProbability = C.GST_TYPE_FIND_NONE           // (0) – type undetected.
+	TypeFindMinimum       TypeFindProbability = C.GST_TYPE_FIND_MINIMUM        // (1) – unlikely typefind.
+	TypeFindPossible      TypeFindProbability = C.GST_TYPE_FIND_POSSIBLE       // (50) – possible type detected.
+	TypeFindLikely        TypeFindProbability = C.GST_TYPE_FIND_LIKELY         // (80) – likely a type was detected.
+	TypeFindNearlyCertain TypeFindProbability = C.GST_TYPE_FIND_NEARLY_CERTAIN // (99) – nearly certain that a type was detected.
+	TypeFindMaximum       TypeFindProbability = C.GST_TYPE_FIND_MAXIMUM        // (100) – very certain a type was detected.
+)
+
 // TOCScope represents the scope of a TOC.
 type TOCScope int
 
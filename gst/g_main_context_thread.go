@@ -0,0 +1,103 @@
+package gst
+
+/*
+#include <glib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ErrMainContextThreadStopped is returned from MainContextThread.Do when the thread has been stopped.
+var ErrMainContextThreadStopped = errors.New("Main context thread is stopped")
+
+// MainContextThread runs a MainContext on a dedicated, locked OS thread. The context is the
+// thread-default context of that thread, so any sources and signal watches created while running
+// on the thread are dispatched on it as well.
+//
+// This is useful for libraries and toolkits that require all calls to happen from the same thread,
+// and for serializing control operations on a pipeline without running a MainLoop in the main
+// goroutine.
+//
+//   thread := gst.NewMainContextThread()
+//   defer thread.Stop()
+//
+//   thread.Do(func() {
+//       // runs on the dedicated thread
+//   })
+type MainContextThread struct {
+	ctx      *MainContext
+	loop     *MainLoop
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMainContextThread creates a new MainContext and starts running it on a new locked OS thread.
+// The thread runs until Stop is called.
+func NewMainContextThread() *MainContextThread {
+	ctx := NewMainContext()
+	t := &MainContextThread{
+		ctx:     ctx,
+		loop:    NewMainLoop(ctx, false),
+		stopped: make(chan struct{}),
+	}
+	running := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		C.g_main_context_push_thread_default(t.ctx.Instance())
+		defer C.g_main_context_pop_thread_default(t.ctx.Instance())
+		close(running)
+		t.loop.Run()
+	}()
+	<-running
+	runtime.SetFinalizer(t, func(t *MainContextThread) {
+		t.loop.Unref()
+		t.ctx.Unref()
+	})
+	return t
+}
+
+// Context returns the MainContext being run by this thread.
+func (t *MainContextThread) Context() *MainContext { return t.ctx }
+
+// Do runs f on the thread and blocks until it has returned. If Do is called from the thread itself,
+// f is called directly. If f panics, the panic is propagated to the caller of Do. An error is returned
+// if the thread was stopped before f could be run.
+func (t *MainContextThread) Do(f func()) error {
+	if t.ctx.IsOwner() {
+		f()
+		return nil
+	}
+	select {
+	case <-t.stopped:
+		return ErrMainContextThreadStopped
+	default:
+	}
+	done := make(chan interface{}, 1)
+	t.ctx.InvokeFull(PriorityDefault, func() {
+		defer func() { done <- recover() }()
+		f()
+	})
+	select {
+	case r := <-done:
+		if r != nil {
+			panic(r)
+		}
+		return nil
+	case <-t.stopped:
+		return ErrMainContextThreadStopped
+	}
+}
+
+// Stop quits the main loop on the thread. Functions scheduled with Do that have not yet run may not
+// be called, in which case Do returns ErrMainContextThreadStopped. It is safe to call Stop multiple times.
+func (t *MainContextThread) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopped)
+		t.loop.Quit()
+	})
+}
@@ -0,0 +1,181 @@
+package gst
+
+/*
+#include <stdlib.h>
+#include <glib.h>
+
+extern gboolean goSourceFunc              (gpointer user_data);
+extern gboolean goInvokeFunc              (gpointer user_data);
+extern void     goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+gboolean cgoSourceFunc (gpointer user_data)
+{
+	return goSourceFunc(user_data);
+}
+
+gboolean cgoInvokeFunc (gpointer user_data)
+{
+	return goInvokeFunc(user_data);
+}
+
+void sourceDestroyNotify (gpointer user_data)
+{
+	goGDestroyNotifyFuncNoRun(user_data);
+}
+
+void setGoSourceFunc (GSource * source, gpointer user_data)
+{
+	g_source_set_callback(source, cgoSourceFunc, user_data, sourceDestroyNotify);
+}
+
+void invokeGoFunc (GMainContext * ctx, gint priority, gpointer user_data)
+{
+	g_main_context_invoke_full(ctx, priority, cgoInvokeFunc, user_data, sourceDestroyNotify);
+}
+*/
+import "C"
+
+import (
+	"math"
+	"runtime"
+	"time"
+	"unsafe"
+)
+
+// Priority represents the priority of a source on a MainContext. Sources with a lower
+// value are dispatched before those with a higher value.
+type Priority int
+
+// Type castings of common Priorities
+const (
+	PriorityHigh        Priority = C.G_PRIORITY_HIGH         // (-100) – Use this for high priority event sources.
+	PriorityDefault     Priority = C.G_PRIORITY_DEFAULT      // (0) – Use this for default priority event sources. This is the priority used by timeouts.
+	PriorityHighIdle    Priority = C.G_PRIORITY_HIGH_IDLE    // (100) – Use this for high priority idle functions.
+	PriorityDefaultIdle Priority = C.G_PRIORITY_DEFAULT_IDLE // (200) – Use this for default priority idle functions. This is the priority used by IdleAdd.
+	PriorityLow         Priority = C.G_PRIORITY_LOW          // (300) – Use this for very low priority background tasks.
+)
+
+// SourceFunc is the prototype of a function called from a Source. It should return true to be
+// called again, or false to remove the source.
+type SourceFunc func() bool
+
+// Source is a go representation of a GSource that has been attached to a MainContext. It can be
+// used to remove the source before it would otherwise be removed.
+type Source struct {
+	ptr *C.GSource
+}
+
+// wrapSource attaches the given floating source to the context and takes ownership of the
+// reference held on it.
+func wrapSource(ctx *MainContext, src *C.GSource) *Source {
+	var gCtx *C.GMainContext
+	if ctx != nil {
+		gCtx = ctx.Instance()
+	}
+	C.g_source_attach(src, gCtx)
+	source := &Source{ptr: src}
+	runtime.SetFinalizer(source, func(s *Source) { C.g_source_unref(s.Instance()) })
+	return source
+}
+
+// Instance returns the underlying GSource instance.
+func (s *Source) Instance() *C.GSource { return s.ptr }
+
+// ID returns the numeric ID of the source within its MainContext.
+func (s *Source) ID() uint { return uint(C.g_source_get_id(s.Instance())) }
+
+// SetPriority sets the priority of the source. The source will be dispatched before any sources
+// with a lower priority (a higher numeric value).
+func (s *Source) SetPriority(priority Priority) {
+	C.g_source_set_priority(s.Instance(), C.gint(priority))
+}
+
+// GetPriority returns the priority of the source.
+func (s *Source) GetPriority() Priority { return Priority(C.g_source_get_priority(s.Instance())) }
+
+// SetName sets a name for the source, used in debugging and profiling.
+func (s *Source) SetName(name string) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.g_source_set_name(s.Instance(), (*C.gchar)(unsafe.Pointer(cName)))
+}
+
+// Remove removes the source from its MainContext. The callback will not be called again. It is
+// safe to call this function from any thread, and to call it on a source that was already removed.
+func (s *Source) Remove() { C.g_source_destroy(s.Instance()) }
+
+// IsRemoved returns true if the source has been removed from its MainContext, either by a call to
+// Remove or by its callback returning false.
+func (s *Source) IsRemoved() bool { return gobool(C.g_source_is_destroyed(s.Instance())) }
+
+// attachFunc sets f as the callback of the given source and attaches it to the context.
+func (m *MainContext) attachFunc(src *C.GSource, f SourceFunc) *Source {
+	C.setGoSourceFunc(src, (C.gpointer)(savePointer(f)))
+	return wrapSource(m, src)
+}
+
+// InvokeFull invokes a function in such a way that this context is owned during the invocation
+// of f. If the context is owned by the current thread, f is called directly. Otherwise, if the
+// context is the thread-default main context of the current thread and it is not being iterated,
+// f is also called directly. In all other cases f is scheduled as an idle source with the given
+// priority and will be called the next time the context is iterated.
+//
+// Since f may be called before this function returns, it must not block on anything that the
+// caller is holding.
+func (m *MainContext) InvokeFull(priority Priority, f func()) {
+	C.invokeGoFunc(m.Instance(), C.gint(priority), (C.gpointer)(savePointer(f)))
+}
+
+// Invoke is like InvokeFull with PriorityDefault.
+func (m *MainContext) Invoke(f func()) { m.InvokeFull(PriorityDefault, f) }
+
+// IdleAdd adds a function to be called whenever there are no higher priority events pending
+// on the context. The function is called repeatedly until it returns false, at which point the
+// source is removed. The source has a priority of PriorityDefaultIdle, which can be changed with
+// Source.SetPriority.
+func (m *MainContext) IdleAdd(f SourceFunc) *Source {
+	return m.attachFunc(C.g_idle_source_new(), f)
+}
+
+// TimeoutAdd adds a function to be called at regular intervals on the context. The function is
+// called repeatedly until it returns false, at which point the source is removed. The first call
+// happens at the end of the first interval.
+//
+// Note that timeout functions may be delayed, due to the processing of other event sources. Thus
+// they should not be relied on for precise timing. After each call to the timeout function, the
+// time of the next timeout is recalculated based on the current time and the given interval (it
+// does not try to 'catch up' time lost in delays).
+//
+// The interval has a granularity of milliseconds. A negative interval is treated as 0, and one longer
+// than the range of a guint of milliseconds is capped to it. For intervals of a second or more, consider
+// using TimeoutAddSeconds instead.
+func (m *MainContext) TimeoutAdd(interval time.Duration, f SourceFunc) *Source {
+	ms := interval.Milliseconds()
+	if ms < 0 {
+		ms = 0
+	} else if ms > math.MaxUint32 {
+		ms = math.MaxUint32
+	}
+	return m.attachFunc(C.g_timeout_source_new(C.guint(ms)), f)
+}
+
+// TimeoutAddSeconds is like TimeoutAdd except the interval is given in seconds. This allows
+// GLib to group the wakeups of timeouts that fire in the same second, which is more efficient
+// for the system power usage.
+func (m *MainContext) TimeoutAddSeconds(interval uint, f SourceFunc) *Source {
+	return m.attachFunc(C.g_timeout_source_new_seconds(C.guint(interval)), f)
+}
+
+// RemoveSource removes the source with the given ID from the context. False is returned if the
+// source could not be found.
+func (m *MainContext) RemoveSource(id uint) bool {
+	src := C.g_main_context_find_source_by_id(m.Instance(), C.guint(id))
+	if src == nil {
+		return false
+	}
+	C.g_source_destroy(src)
+	return true
+}
+
+// IsOwner returns true if the context is currently owned by the calling thread.
+func (m *MainContext) IsOwner() bool { return gobool(C.g_main_context_is_owner(m.Instance())) }
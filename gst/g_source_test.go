@@ -0,0 +1,28 @@
+package gst
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeoutAddClampsNegativeIntervals(t *testing.T) {
+	ctx := NewMainContext()
+	defer ctx.Unref()
+
+	calls := 0
+	src := ctx.TimeoutAdd(-time.Hour, func() bool {
+		calls++
+		return false
+	})
+	deadline := time.Now().Add(testTimeout)
+	for calls == 0 && time.Now().Before(deadline) {
+		ctx.Iteration(false)
+		time.Sleep(time.Millisecond)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected a negative interval to fire right away, got %d calls", calls)
+	}
+	if !src.IsRemoved() {
+		t.Error("Expected the source to be removed once the function returned false")
+	}
+}
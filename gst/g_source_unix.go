@@ -0,0 +1,84 @@
+//go:build !windows
+// +build !windows
+
+package gst
+
+/*
+#include <glib.h>
+#include <glib-unix.h>
+
+extern void     goChildWatchFunc          (GPid pid, gint status, gpointer user_data);
+extern gboolean goUnixFDSourceFunc        (gint fd, GIOCondition condition, gpointer user_data);
+extern void     goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+void cgoChildWatchFunc (GPid pid, gint status, gpointer user_data)
+{
+	goChildWatchFunc(pid, status, user_data);
+}
+
+gboolean cgoUnixFDSourceFunc (gint fd, GIOCondition condition, gpointer user_data)
+{
+	return goUnixFDSourceFunc(fd, condition, user_data);
+}
+
+void unixSourceDestroyNotify (gpointer user_data)
+{
+	goGDestroyNotifyFuncNoRun(user_data);
+}
+
+GSource * newGoChildWatchSource (GPid pid, gpointer user_data)
+{
+	GSource * source = g_child_watch_source_new(pid);
+	g_source_set_callback(source, (GSourceFunc) cgoChildWatchFunc, user_data, unixSourceDestroyNotify);
+	return source;
+}
+
+GSource * newGoUnixFDSource (gint fd, GIOCondition condition, gpointer user_data)
+{
+	GSource * source = g_unix_fd_source_new(fd, condition);
+	g_source_set_callback(source, (GSourceFunc) cgoUnixFDSourceFunc, user_data, unixSourceDestroyNotify);
+	return source;
+}
+*/
+import "C"
+
+// IOCondition is a go cast of a GIOCondition. It is a bitwise combination of conditions to
+// watch for on a file descriptor.
+type IOCondition int
+
+// Type castings of IOConditions
+const (
+	IOIn   IOCondition = C.G_IO_IN   // (1) – There is data to read.
+	IOOut  IOCondition = C.G_IO_OUT  // (4) – Data can be written (without blocking).
+	IOPri  IOCondition = C.G_IO_PRI  // (2) – There is urgent data to read.
+	IOErr  IOCondition = C.G_IO_ERR  // (8) – Error condition.
+	IOHup  IOCondition = C.G_IO_HUP  // (16) – Hung up (the connection has been broken, usually for pipes and sockets).
+	IONval IOCondition = C.G_IO_NVAL // (32) – Invalid request. The file descriptor is not open.
+)
+
+// ChildWatchFunc is the prototype of a function called when a child process watched with
+// ChildWatchAdd exits. The status is the raw wait status of the process.
+type ChildWatchFunc func(pid int, status int)
+
+// UnixFDSourceFunc is the prototype of a function called when a condition watched with
+// UnixFDAdd is met. It should return true to keep watching the file descriptor, or false
+// to remove the source.
+type UnixFDSourceFunc func(fd int, condition IOCondition) bool
+
+// ChildWatchAdd adds a function to be called on the context when the child process with the
+// given pid exits. The source is removed automatically after the function is called.
+//
+// The process must be a child of the current process, and must not have been reaped by
+// another means (e.g. os/exec's Wait). GLib reaps the child itself before calling f.
+func (m *MainContext) ChildWatchAdd(pid int, f ChildWatchFunc) *Source {
+	src := C.newGoChildWatchSource(C.GPid(pid), (C.gpointer)(savePointer(f)))
+	return wrapSource(m, src)
+}
+
+// UnixFDAdd adds a function to be called on the context whenever any of the given conditions
+// are met on the file descriptor. The function is called repeatedly until it returns false, at
+// which point the source is removed. The file descriptor is not closed when the source is removed.
+func (m *MainContext) UnixFDAdd(fd int, condition IOCondition, f UnixFDSourceFunc) *Source {
+	src := C.newGoUnixFDSource(C.gint(fd), C.GIOCondition(condition), (C.gpointer)(savePointer(f)))
+	return wrapSource(m, src)
+}
@@ -31,7 +31,9 @@ inline GstPad *               toGstPad               (void *p) { return (GST_PAD
 inline GstPadTemplate *       toGstPadTemplate       (void *p) { return (GST_PAD_TEMPLATE(p)); }
 inline GstPipeline *          toGstPipeline          (void *p) { return (GST_PIPELINE(p)); }
 inline GstPluginFeature *     toGstPluginFeature     (void *p) { return (GST_PLUGIN_FEATURE(p)); }
+inline GstPreset *            toGstPreset            (void *p) { return (GST_PRESET(p)); }
 inline GstPlugin *            toGstPlugin            (void *p) { return (GST_PLUGIN(p)); }
+inline GstPromise *           toGstPromise           (void *p) { return (GST_PROMISE(p)); }
 inline GstProxyPad *          toGstProxyPad          (void *p) { return (GST_PROXY_PAD(p)); }
 inline GstQuery *             toGstQuery             (void *p) { return (GST_QUERY(p)); }
 inline GstRegistry *          toGstRegistry          (void *p) { return (GST_REGISTRY(p)); }
@@ -154,6 +156,7 @@ inline GstTagList *     makeTagListWritable   (GstTagList * tagList)   { return
 
 inline GObjectClass *  getGObjectClass         (void * p)                               { return (G_OBJECT_GET_CLASS(p)); }
 inline gboolean        gstElementIsURIHandler  (GstElement * elem)                      { return (GST_IS_URI_HANDLER(elem)); }
+inline gboolean        gstElementIsPreset      (GstElement * elem)                      { return (GST_IS_PRESET(elem)); }
 inline gboolean        gstObjectFlagIsSet      (GstObject * obj, GstElementFlags flags) { return (GST_OBJECT_FLAG_IS_SET(obj, flags)); }
 
 /* Element utilities */
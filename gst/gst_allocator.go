@@ -24,8 +24,13 @@ func NewAllocationParams() *AllocationParams {
 	return params
 }
 
-// Instance returns the underlying GstAllocationParams.
-func (a *AllocationParams) Instance() *C.GstAllocationParams { return a.ptr }
+// Instance returns the underlying GstAllocationParams, or nil if a is nil.
+func (a *AllocationParams) Instance() *C.GstAllocationParams {
+	if a == nil {
+		return nil
+	}
+	return a.ptr
+}
 
 // Init initializes these AllocationParams to their original values.
 func (a *AllocationParams) Init() { C.gst_allocation_params_init(a.ptr) }
@@ -67,11 +72,18 @@ type Allocator struct{ *Object }
 
 // DefaultAllocator returns the default GstAllocator.
 func DefaultAllocator() *Allocator {
-	return wrapAllocator(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(C.gst_allocator_find(nil)))})
+	obj := wrapAllocator(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(C.gst_allocator_find(nil)))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
-// Instance returns the underlying GstAllocator instance.
-func (a *Allocator) Instance() *C.GstAllocator { return C.toGstAllocator(a.Unsafe()) }
+// Instance returns the underlying GstAllocator instance, or nil if a is nil.
+func (a *Allocator) Instance() *C.GstAllocator {
+	if a == nil {
+		return nil
+	}
+	return C.toGstAllocator(a.Unsafe())
+}
 
 // MemType returns the memory type for this allocator.
 func (a *Allocator) MemType() string { return C.GoString(a.Instance().mem_type) }
@@ -84,12 +96,17 @@ func (a *Allocator) MemType() string { return C.GoString(a.Instance().mem_type)
 // MemoryFlagZeroPadded respectively.
 //
 // The alignment in params is given as a bitmask so that align + 1 equals the amount of bytes to
-// align to. For example, to align to 8 bytes, use an alignment of 7.
+// align to. For example, to align to 8 bytes, use an alignment of 7. nil is returned if the memory
+// could not be allocated.
 func (a *Allocator) Alloc(size int64, params *AllocationParams) *Memory {
-	return wrapMemory(C.gst_allocator_alloc(a.Instance(), C.gsize(size), params.ptr))
+	mem := C.gst_allocator_alloc(a.Instance(), C.gsize(size), params.Instance())
+	if mem == nil {
+		return nil
+	}
+	return takeMemory(mem)
 }
 
 // Free memory that was originally allocated with this allocator.
 func (a *Allocator) Free(mem *Memory) {
-	C.gst_allocator_free(a.Instance(), mem.Instance())
+	C.gst_allocator_free(a.Instance(), mem.give())
 }
@@ -19,7 +19,9 @@ func NewBin(name string) *Bin {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	bin := C.gst_bin_new((*C.gchar)(unsafe.Pointer(cName)))
-	return wrapBin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(bin))})
+	obj := wrapBin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(bin))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // BinFromElement wraps the given Element in a Bin reference. This only works for elements
@@ -43,7 +45,9 @@ func (b *Bin) GetElementByName(name string) (*Element, error) {
 	if elem == nil {
 		return nil, fmt.Errorf("Could not find element with name %s", name)
 	}
-	return wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))}), nil
+	obj := wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
 }
 
 // GetElementByNameRecursive returns the element with the given name. If it is not
@@ -55,7 +59,9 @@ func (b *Bin) GetElementByNameRecursive(name string) (*Element, error) {
 	if elem == nil {
 		return nil, fmt.Errorf("Could not find element with name %s", name)
 	}
-	return wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))}), nil
+	obj := wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
 }
 
 // GetElements returns a list of the elements added to this pipeline.
@@ -100,7 +106,9 @@ func (b *Bin) GetByInterface(iface glib.Type) (*Element, error) {
 	if elem == nil {
 		return nil, fmt.Errorf("Could not find any elements implementing %s", iface.Name())
 	}
-	return wrapElement(toGObject(unsafe.Pointer(elem))), nil
+	obj := wrapElement(toGObject(unsafe.Pointer(elem)))
+	adoptObject(obj.BaseObject())
+	return obj, nil
 }
 
 // GetAllByInterface looks for all elements inside the bin that implements the given interface. You can
@@ -164,7 +172,9 @@ func (b *Bin) FindUnlinkedPad(direction PadDirection) *Pad {
 	if pad == nil {
 		return nil
 	}
-	return wrapPad(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pad))})
+	obj := wrapPad(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pad))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetSuppressedFlags returns the suppressed flags of the bin.
@@ -3,13 +3,8 @@ package gst
 /*
 #include "gst.go.h"
 
-extern void      goGDestroyNotifyFunc  (gpointer data);
 extern gboolean  goBufferMetaForEachCb (GstBuffer * buffer, GstMeta ** meta, gpointer user_data);
 
-void cgoDestroyNotifyFunc (gpointer data) {
-	goGDestroyNotifyFunc(data);
-}
-
 gboolean cgoBufferMetaForEachCb (GstBuffer * buffer, GstMeta ** meta, gpointer user_data)
 {
 	return goBufferMetaForEachCb(buffer, meta, user_data);
@@ -27,7 +22,6 @@ import (
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // GetMaxBufferMemory returns the maximum amount of memory a buffer can hold.
@@ -35,12 +29,13 @@ func GetMaxBufferMemory() uint64 { return uint64(C.gst_buffer_get_max_memory())
 
 // Buffer is a go representation of a GstBuffer.
 type Buffer struct {
-	ptr *C.GstBuffer
+	ptr  *C.GstBuffer
+	refs refTracker
 }
 
 // NewEmptyBuffer returns a new empty buffer.
 func NewEmptyBuffer() *Buffer {
-	return wrapBuffer(C.gst_buffer_new())
+	return takeBuffer(C.gst_buffer_new())
 }
 
 // NewBufferWithSize is a convenience wrapped for NewBufferrAllocate with the default allocator
@@ -69,7 +64,7 @@ func NewBufferAllocate(alloc *Allocator, params *AllocationParams, size int64) *
 	if buf == nil {
 		return nil
 	}
-	return wrapBuffer(buf)
+	return takeBuffer(buf)
 }
 
 // NewBufferFromBytes returns a new buffer from the given byte slice.
@@ -78,7 +73,7 @@ func NewBufferFromBytes(b []byte) *Buffer {
 	p := unsafe.Pointer(C.CString(str))
 	// memory is freed by gstreamer after building the new buffer
 	buf := C.gst_buffer_new_wrapped((C.gpointer)(p), C.gulong(len(str)))
-	return wrapBuffer(buf)
+	return takeBuffer(buf)
 }
 
 // NewBufferFromReader returns a new buffer from the given io.Reader.
@@ -94,7 +89,9 @@ func NewBufferFromReader(rdr io.Reader) (*Buffer, error) {
 // have the region from offset and size visible. The maxsize must be at least the size of the
 // data provided.
 //
-// When the buffer is destroyed, notifyFunc will be called if it is not nil.
+// When the buffer is destroyed, notifyFunc will be called if it is not nil. The data is only shared
+// without copying if it was allocated with AllocCBytes, see NewBufferWrapped. nil is returned, without
+// calling notifyFunc, if the sizes are invalid.
 //
 // The prefix/padding must be filled with 0 if flags contains MemoryFlagZeroPrefixed and MemoryFlagZeroPadded respectively.
 //
@@ -109,34 +106,44 @@ func NewBufferFromReader(rdr io.Reader) (*Buffer, error) {
 //
 //  // > buffer was destroyed
 func NewBufferFull(flags MemoryFlags, data []byte, maxSize, offset, size int64, notifyFunc func()) *Buffer {
-	var notifyData unsafe.Pointer
-	var gnotifyFunc C.GDestroyNotify
-	if notifyFunc != nil {
-		notifyData = gopointer.Save(notifyFunc)
-		gnotifyFunc = C.GDestroyNotify(C.cgoDestroyNotifyFunc)
+	dataPtr, notifyData, gnotifyFunc, ok := wrapBytes(data, maxSize, offset, size, notifyFunc)
+	if !ok {
+		return nil
 	}
-	dataStr := string(data)
-	dataPtr := unsafe.Pointer(C.CString(dataStr))
 	buf := C.gst_buffer_new_wrapped_full(
 		C.GstMemoryFlags(flags),
-		(C.gpointer)(dataPtr),
+		dataPtr,
 		C.gsize(maxSize), C.gsize(offset), C.gsize(size),
-		(C.gpointer)(notifyData), gnotifyFunc,
+		notifyData, gnotifyFunc,
 	)
 	if buf == nil {
 		return nil
 	}
-	return wrapBuffer(buf)
+	return takeBuffer(buf)
 }
 
+// FromGstBufferUnsafe wraps the pointer to the given C GstBuffer with the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstBufferUnsafe(buf unsafe.Pointer) *Buffer { return wrapBuffer(C.toGstBuffer(buf)) }
+
+// FromGstBufferUnsafeFull wraps the pointer to the given C GstBuffer with the go type, taking ownership
+// of the reference the caller holds on it (transfer full).
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstBufferUnsafeFull(buf unsafe.Pointer) *Buffer { return takeBuffer(C.toGstBuffer(buf)) }
+
 // Instance returns the underlying GstBuffer instance.
 func (b *Buffer) Instance() *C.GstBuffer { return C.toGstBuffer(unsafe.Pointer(b.ptr)) }
 
+// TransferUnsafe returns the underlying GstBuffer for passing to a C function that takes ownership of it
+// (transfer full). The buffer must not be used afterwards. This is meant for internal usage and is exported
+// for visibility to other packages.
+func (b *Buffer) TransferUnsafe() unsafe.Pointer { return unsafe.Pointer(b.give()) }
+
 // Ref increases the ref count on the buffer by one.
-func (b *Buffer) Ref() *Buffer { return wrapBuffer(C.gst_buffer_ref(b.Instance())) }
+func (b *Buffer) Ref() *Buffer { return takeBuffer(C.gst_buffer_ref(b.Instance())) }
 
 // Unref decreaes the ref count on the buffer by one. When the refcount reaches zero, the memory is freed.
-func (b *Buffer) Unref() { C.gst_buffer_unref(b.Instance()) }
+func (b *Buffer) Unref() { unrefMiniObject(b) }
 
 // Reader returns an io.Reader for this buffer.
 func (b *Buffer) Reader() io.Reader { return bytes.NewBuffer(b.Bytes()) }
@@ -224,7 +231,7 @@ func (b *Buffer) OffsetEnd() int64 { return int64(b.Instance().offset_end) }
 //   // > Buffer was destroyed
 //
 func (b *Buffer) AddMeta(info *MetaInfo, params interface{}) *Meta {
-	meta := C.gst_buffer_add_meta(b.Instance(), info.Instance(), (C.gpointer)(gopointer.Save(params)))
+	meta := C.gst_buffer_add_meta(b.Instance(), info.Instance(), (C.gpointer)(savePointer(params)))
 	if meta == nil {
 		return nil
 	}
@@ -291,7 +298,7 @@ func (b *Buffer) AddReferenceTimestampMeta(ref *Caps, timestamp, duration time.D
 // Append will append all the memory from the given buffer to this one. The result buffer will
 // contain a concatenation of the memory of the two buffers.
 func (b *Buffer) Append(buf *Buffer) *Buffer {
-	return wrapBuffer(C.gst_buffer_append(b.Instance(), buf.Instance()))
+	return takeBuffer(C.gst_buffer_append(b.give(), buf.give()))
 }
 
 // AppendMemory append the memory block to this buffer. This function takes ownership of
@@ -299,25 +306,25 @@ func (b *Buffer) Append(buf *Buffer) *Buffer {
 //
 // This function is identical to InsertMemory with an index of -1.
 func (b *Buffer) AppendMemory(mem *Memory) {
-	C.gst_buffer_append_memory(b.Instance(), mem.Instance())
+	C.gst_buffer_append_memory(b.Instance(), mem.give())
 }
 
 // AppendRegion will append size bytes at offset from the given buffer to this one. The result
 // buffer will contain a concatenation of the memory of this buffer and the requested region of
 // the one provided.
 func (b *Buffer) AppendRegion(buf *Buffer, offset, size int64) *Buffer {
-	newbuf := C.gst_buffer_append_region(b.Instance(), buf.Instance(), C.gssize(offset), C.gssize(size))
-	return wrapBuffer(newbuf)
+	newbuf := C.gst_buffer_append_region(b.give(), buf.give(), C.gssize(offset), C.gssize(size))
+	return takeBuffer(newbuf)
 }
 
 // Copy creates a copy of this buffer. This will only copy the buffer's data to a newly allocated
 // Memory if needed (if the type of memory requires it), otherwise the underlying data is just referenced.
 // Check DeepCopy if you want to force the data to be copied to newly allocated Memory.
-func (b *Buffer) Copy() *Buffer { return wrapBuffer(C.gst_buffer_copy(b.Instance())) }
+func (b *Buffer) Copy() *Buffer { return takeBuffer(C.gst_buffer_copy(b.Instance())) }
 
 // DeepCopy creates a copy of the given buffer. This will make a newly allocated copy of the data
 // the source buffer contains.
-func (b *Buffer) DeepCopy() *Buffer { return wrapBuffer(C.gst_buffer_copy_deep(b.Instance())) }
+func (b *Buffer) DeepCopy() *Buffer { return takeBuffer(C.gst_buffer_copy_deep(b.Instance())) }
 
 // CopyInto copies the information from this buffer into the given one. If the given buffer already
 // contains memory and flags contains BufferCopyMemory, the memory from this one will be appended to
@@ -349,7 +356,7 @@ func (b *Buffer) CopyRegion(flags BufferCopyFlags, offset, size int64) *Buffer {
 		C.gsize(offset),
 		C.gsize(size),
 	)
-	return wrapBuffer(newbuf)
+	return takeBuffer(newbuf)
 }
 
 // Extract extracts size bytes starting from offset in this buffer. The data extracted may be lower
@@ -448,8 +455,8 @@ func (b *Buffer) FindMemory(offset, size int64) (index, length uint, skip int64)
 // The function can modify the passed meta pointer or its contents. The return value defines if this function continues
 // or if the remaining metadata items in the buffer should be skipped.
 func (b *Buffer) ForEachMeta(f func(meta *Meta) bool) bool {
-	fPtr := gopointer.Save(f)
-	defer gopointer.Unref(fPtr)
+	fPtr := savePointer(f)
+	defer unrefPointer(fPtr)
 	return gobool(C.gst_buffer_foreach_meta(
 		b.Instance(),
 		C.GstBufferForeachMetaFunc(C.cgoBufferMetaForEachCb),
@@ -459,7 +466,7 @@ func (b *Buffer) ForEachMeta(f func(meta *Meta) bool) bool {
 
 // GetAllMemory retrieves all the memory inside this buffer.
 func (b *Buffer) GetAllMemory() *Memory {
-	return wrapMemory(C.gst_buffer_get_all_memory(b.Instance()))
+	return takeMemory(C.gst_buffer_get_all_memory(b.Instance()))
 }
 
 // GetFlags returns the flags on this buffer.
@@ -473,7 +480,7 @@ func (b *Buffer) GetMemory(idx uint) *Memory {
 	if mem == nil {
 		return nil
 	}
-	return wrapMemory(mem)
+	return takeMemory(mem)
 }
 
 // GetMemoryRange retrieves length memory blocks in buffer starting at idx. The memory blocks
@@ -483,7 +490,7 @@ func (b *Buffer) GetMemoryRange(idx uint, length int) *Memory {
 	if mem == nil {
 		return nil
 	}
-	return wrapMemory(mem)
+	return takeMemory(mem)
 }
 
 // GetMeta retrieves the metadata for the given api on buffer. When there is no such metadata,
@@ -556,7 +563,7 @@ func (b *Buffer) HasFlags(flags BufferFlags) bool {
 // Only the value from GetMaxBufferMemory can be added to a buffer. If more memory is added, existing memory
 // blocks will automatically be merged to make room for the new memory.
 func (b *Buffer) InsertMemory(mem *Memory, idx int) {
-	C.gst_buffer_insert_memory(b.Instance(), C.gint(idx), mem.Instance())
+	C.gst_buffer_insert_memory(b.Instance(), C.gint(idx), mem.give())
 }
 
 // IsAllMemoryWritable checks if all memory blocks in buffer are writable.
@@ -597,7 +604,14 @@ func (b *Buffer) IsWritable() bool {
 // In short, this function unrefs the buf in the argument and refs the buffer that it returns. Don't access the argument
 // after calling this function unless you have an additional reference to it.
 func (b *Buffer) MakeWritable() *Buffer {
-	return wrapBuffer(C.makeBufferWritable(b.Instance()))
+	return takeBuffer(C.makeBufferWritable(b.give()))
+}
+
+// MakeMut makes this buffer writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and b refers to the copy from then on.
+// Unlike MakeWritable, b remains valid after the call and must be released as usual.
+func (b *Buffer) MakeMut() {
+	b.ptr = C.toGstBuffer(unsafe.Pointer(makeMutMiniObject(b)))
 }
 
 // IterateMeta retrieves the next Meta after the given one. If state points to nil, the first Meta is returned.
@@ -674,7 +688,7 @@ func (b *Buffer) PeekMemory(idx uint) *Memory {
 //
 // This function is identical to InsertMemory with an index of 0.
 func (b *Buffer) PrependMemory(mem *Memory) {
-	C.gst_buffer_prepend_memory(b.Instance(), mem.Instance())
+	C.gst_buffer_prepend_memory(b.Instance(), mem.give())
 }
 
 // RemoveAllMemory removes all memory blocks in the buffer.
@@ -697,12 +711,12 @@ func (b *Buffer) RemoveMeta(meta *Meta) bool {
 
 // ReplaceAllMemory replaces all the memory in this buffer with that provided.
 func (b *Buffer) ReplaceAllMemory(mem *Memory) {
-	C.gst_buffer_replace_all_memory(b.Instance(), mem.Instance())
+	C.gst_buffer_replace_all_memory(b.Instance(), mem.give())
 }
 
 // ReplaceMemory replaces the memory at the given index with the given memory.
 func (b *Buffer) ReplaceMemory(mem *Memory, idx uint) {
-	C.gst_buffer_replace_memory(b.Instance(), C.guint(idx), mem.Instance())
+	C.gst_buffer_replace_memory(b.Instance(), C.guint(idx), mem.give())
 }
 
 // ReplaceMemoryRange replaces length memory blocks in the buffer starting at idx with
@@ -712,7 +726,7 @@ func (b *Buffer) ReplaceMemory(mem *Memory, idx uint) {
 //
 // The buffer should be writable.
 func (b *Buffer) ReplaceMemoryRange(idx uint, length int, mem *Memory) {
-	C.gst_buffer_replace_memory_range(b.Instance(), C.guint(idx), C.gint(length), mem.Instance())
+	C.gst_buffer_replace_memory_range(b.Instance(), C.guint(idx), C.gint(length), mem.give())
 }
 
 // Resize sets the offset and total size of the memory blocks in this buffer.
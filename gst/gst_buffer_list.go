@@ -13,28 +13,32 @@ gboolean cgoBufferListForEachCb (GstBuffer ** buffer, guint idx, gpointer user_d
 import "C"
 import (
 	"unsafe"
-
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // BufferList is a go wrapper around a GstBufferList for grouping Buffers
 type BufferList struct {
-	ptr *C.GstBufferList
+	ptr  *C.GstBufferList
+	refs refTracker
 }
 
 // NewBufferList returns a new empty BufferList.
 func NewBufferList() *BufferList {
-	return wrapBufferList(C.gst_buffer_list_new())
+	return takeBufferList(C.gst_buffer_list_new())
 }
 
 // NewBufferListSized creates a new BufferList with the given size.
 func NewBufferListSized(size uint) *BufferList {
-	return wrapBufferList(C.gst_buffer_list_new_sized(C.guint(size)))
+	return takeBufferList(C.gst_buffer_list_new_sized(C.guint(size)))
 }
 
 // Instance returns the underlying GstBufferList.
 func (b *BufferList) Instance() *C.GstBufferList { return C.toGstBufferList(unsafe.Pointer(b.ptr)) }
 
+// TransferUnsafe returns the underlying GstBufferList for passing to a C function that takes ownership of it
+// (transfer full). The buffer list must not be used afterwards. This is meant for internal usage and is exported
+// for visibility to other packages.
+func (b *BufferList) TransferUnsafe() unsafe.Pointer { return unsafe.Pointer(b.give()) }
+
 // CalculateSize calculates the size of the data contained in this buffer list by adding the size of all buffers.
 func (b *BufferList) CalculateSize() int64 {
 	return int64(C.gst_buffer_list_calculate_size(b.Instance()))
@@ -43,13 +47,13 @@ func (b *BufferList) CalculateSize() int64 {
 // Copy creates a shallow copy of the given buffer list. This will make a newly allocated copy of the
 // source list with copies of buffer pointers. The refcount of buffers pointed to will be increased by one.
 func (b *BufferList) Copy() *BufferList {
-	return wrapBufferList(C.gst_buffer_list_copy(b.Instance()))
+	return takeBufferList(C.gst_buffer_list_copy(b.Instance()))
 }
 
 // DeepCopy creates a copy of the given buffer list. This will make a newly allocated copy of each buffer
 // that the source buffer list contains.
 func (b *BufferList) DeepCopy() *BufferList {
-	return wrapBufferList(C.gst_buffer_list_copy_deep(b.Instance()))
+	return takeBufferList(C.gst_buffer_list_copy_deep(b.Instance()))
 }
 
 // IsWritable returns true if this BufferList is writable.
@@ -60,7 +64,14 @@ func (b *BufferList) IsWritable() bool {
 // MakeWritable makes a writable buffer list from this one. If the source buffer list is already writable,
 // this will simply return the same buffer list. A copy will otherwise be made using Copy.
 func (b *BufferList) MakeWritable() *BufferList {
-	return wrapBufferList(C.makeBufferListWritable(b.Instance()))
+	return takeBufferList(C.makeBufferListWritable(b.give()))
+}
+
+// MakeMut makes this buffer list writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and b refers to the copy from then on.
+// Unlike MakeWritable, b remains valid after the call and must be released as usual.
+func (b *BufferList) MakeMut() {
+	b.ptr = C.toGstBufferList(unsafe.Pointer(makeMutMiniObject(b)))
 }
 
 // ForEach calls the given function for each buffer in list.
@@ -68,8 +79,8 @@ func (b *BufferList) MakeWritable() *BufferList {
 // The function can modify the passed buffer pointer or its contents. The return value defines if this
 // function returns or if the remaining buffers in the list should be skipped.
 func (b *BufferList) ForEach(f func(buf *Buffer, idx uint) bool) {
-	fPtr := gopointer.Save(f)
-	defer gopointer.Unref(fPtr)
+	fPtr := savePointer(f)
+	defer unrefPointer(fPtr)
 	C.gst_buffer_list_foreach(
 		b.Instance(),
 		C.GstBufferListFunc(C.cgoBufferListForEachCb),
@@ -95,7 +106,7 @@ func (b *BufferList) GetWritableBufferAt(idx uint) *Buffer {
 //
 // A -1 value for idx will append the buffer at the end.
 func (b *BufferList) Insert(idx int, buf *Buffer) {
-	C.gst_buffer_list_insert(b.Instance(), C.gint(idx), buf.Instance())
+	C.gst_buffer_list_insert(b.Instance(), C.gint(idx), buf.give())
 }
 
 // Length returns the number of buffers in the list.
@@ -109,7 +120,7 @@ func (b *BufferList) Length() uint {
 // It is important to note that keeping additional references to GstBufferList instances
 // can potentially increase the number of memcpy operations in a pipeline.
 func (b *BufferList) Ref() *BufferList {
-	C.gst_buffer_list_ref(b.Instance())
+	refMiniObject(b)
 	return b
 }
 
@@ -122,5 +133,5 @@ func (b *BufferList) Remove(idx, length uint) {
 // Unref decreases the refcount of the buffer list. If the refcount reaches 0, the buffer
 // list will be freed.
 func (b *BufferList) Unref() {
-	C.gst_buffer_list_unref(b.Instance())
+	unrefMiniObject(b)
 }
@@ -19,7 +19,9 @@ type BufferPool struct{ *Object }
 // NewBufferPool returns a new BufferPool instance.
 func NewBufferPool() *BufferPool {
 	pool := C.gst_buffer_pool_new()
-	return wrapBufferPool(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pool))})
+	obj := wrapBufferPool(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pool))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the underlying GstBufferPool instance.
@@ -36,17 +38,15 @@ type BufferPoolAcquireParams struct {
 	Flags  BufferPoolAcquireFlags // flags (GstBufferPoolAcquireFlags) – additional flags
 }
 
-// AcquireBuffer acquires a buffer from this pool.
+// AcquireBuffer acquires a buffer from this pool. params can be nil. The buffer is nil if the
+// returned FlowReturn is not FlowOK.
 func (b *BufferPool) AcquireBuffer(params *BufferPoolAcquireParams) (*Buffer, FlowReturn) {
 	var buf *C.GstBuffer
-	gparams := &C.GstBufferPoolAcquireParams{
-		format: C.GstFormat(params.Format),
-		start:  C.gint64(params.Start),
-		stop:   C.gint64(params.Stop),
-		flags:  C.GstBufferPoolAcquireFlags(params.Flags),
+	ret := C.gst_buffer_pool_acquire_buffer(b.Instance(), &buf, params.toC())
+	if buf == nil {
+		return nil, FlowReturn(ret)
 	}
-	ret := C.gst_buffer_pool_acquire_buffer(b.Instance(), &buf, gparams)
-	return wrapBuffer(buf), FlowReturn(ret)
+	return takeBuffer(buf), FlowReturn(ret)
 }
 
 // GetConfig retrieves a copy of the current configuration of the pool. This configuration can either
@@ -60,8 +60,11 @@ func (b *BufferPool) GetConfig() *BufferPoolConfig {
 // GetOptions retrieves a list of supported bufferpool options for the pool. An option would typically
 // be enabled with AddOption.
 func (b *BufferPool) GetOptions() []string {
-	opts := C.gst_buffer_pool_get_options(b.Instance())
-	defer C.g_free((C.gpointer)(unsafe.Pointer(opts)))
+	// the options are owned by the pool
+	opts := (**C.gchar)(unsafe.Pointer(C.gst_buffer_pool_get_options(b.Instance())))
+	if opts == nil {
+		return nil
+	}
 	return goStrings(C.sizeOfGCharArray(opts), opts)
 }
 
@@ -80,7 +83,7 @@ func (b *BufferPool) IsActive() bool { return gobool(C.gst_buffer_pool_is_active
 //
 // This function is usually called automatically when the last ref on buffer disappears.
 func (b *BufferPool) ReleaseBuffer(buf *Buffer) {
-	C.gst_buffer_pool_release_buffer(b.Instance(), buf.Instance())
+	C.gst_buffer_pool_release_buffer(b.Instance(), buf.give())
 }
 
 // SetActive can be used to control the active state of pool. When the pool is inactive, new calls to
@@ -137,6 +140,9 @@ func (b *BufferPoolConfig) GetAllocator() (*Allocator, *AllocationParams) {
 	var allocator *C.GstAllocator
 	var params C.GstAllocationParams
 	C.gst_buffer_pool_config_get_allocator(b.Instance(), &allocator, &params)
+	if allocator == nil {
+		return nil, &AllocationParams{ptr: &params}
+	}
 	return wrapAllocator(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(allocator))}), &AllocationParams{ptr: &params}
 }
 
@@ -5,6 +5,7 @@ package gst
 
 extern GstBusSyncReply   goBusSyncHandler (GstBus * bus, GstMessage * message, gpointer user_data);
 extern gboolean          goBusFunc        (GstBus * bus, GstMessage * msg, gpointer user_data);
+extern void              goGDestroyNotifyFuncNoRun (gpointer user_data);
 
 gboolean cgoBusFunc (GstBus * bus, GstMessage * msg, gpointer user_data)
 {
@@ -16,30 +17,36 @@ GstBusSyncReply cgoBusSyncHandler (GstBus * bus, GstMessage * message, gpointer
 	return goBusSyncHandler(bus, message, user_data);
 }
 
+void cgoBusDestroyNotify (gpointer user_data)
+{
+	goGDestroyNotifyFuncNoRun(user_data);
+}
+
+GSource * newGoBusWatchSource (GstBus * bus, gpointer user_data)
+{
+	GSource * source = gst_bus_create_watch(bus);
+	g_source_set_callback(source, (GSourceFunc) cgoBusFunc, user_data, cgoBusDestroyNotify);
+	return source;
+}
+
 */
 import "C"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"runtime/debug"
-	"sync"
 	"time"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // Bus is a Go wrapper around a GstBus. It provides convenience methods for
 // popping messages from the queue.
-type Bus struct {
-	*Object
-
-	msgChannels []chan *Message
-	mux         sync.Mutex
-}
+type Bus struct{ *Object }
 
 // NewBus returns a new Bus instance.
 //
@@ -77,7 +84,9 @@ type Bus struct {
 //
 func NewBus() *Bus {
 	bus := C.gst_bus_new()
-	return wrapBus(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(bus))})
+	obj := wrapBus(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(bus))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the underlying GstBus instance.
@@ -93,39 +102,17 @@ func (b *Bus) Instance() *C.GstBus { return C.toGstBus(b.Unsafe()) }
 // as many times as this function is called.
 func (b *Bus) AddSignalWatch() { C.gst_bus_add_signal_watch(b.Instance()) }
 
-func (b *Bus) deliverMessages() {
-	for {
-		msg := b.BlockPopMessage()
-		if msg == nil {
-			return
-		}
-		b.mux.Lock()
-		for _, ch := range b.msgChannels {
-			ch <- msg.Ref()
-		}
-		b.mux.Unlock()
-		msg.Unref()
-	}
-}
-
 // MessageChan returns a new channel to listen for messages asynchronously. Messages
-// should be unreffed after each usage. Messages are delivered to channels in the
-// order in which this function was called.
+// should be unreffed after each usage. No message is ever dropped, and the channel stays
+// open when the bus is flushed. The channel is closed once the bus is finalized, and the
+// messages that were not received by then are dropped. Like Subscribe, messages are not
+// removed from the bus.
 //
-// While a message is being delivered to created channels, there is a lock on creating
-// new ones.
-//
-// It is much safer and easier to use the AddWatch method or other polling functions. Only use this method if you
-// are unable to also run a MainLoop, or for convenience sake.
+// Deprecated: MessageChan is a thin wrapper around Subscribe with no way to stop listening.
+// Use Subscribe instead.
 func (b *Bus) MessageChan() chan *Message {
-	b.mux.Lock()
-	defer b.mux.Unlock()
-	ch := make(chan *Message)
-	b.msgChannels = append(b.msgChannels, ch)
-	if len(b.msgChannels) == 1 {
-		go b.deliverMessages()
-	}
-	return ch
+	sub := subscribeBus(b.Instance(), context.Background(), MessageAny, &SubscribeOptions{DropPolicy: DropNone}, true)
+	return sub.ch
 }
 
 // PopMessage attempts to pop a message from the bus. It returns nil if none are available.
@@ -168,19 +155,48 @@ type BusWatchFunc func(msg *Message) bool
 // it's own reference to the Bus.
 //
 // The watch can be removed either by returning false from the function or by using RemoveWatch().
-// A MainLoop must be running for bus watches to work.
+// The function is released when the watch is removed. A MainLoop must be running for bus watches
+// to work. To add more than one watch to a bus, or to watch it from another context, see AttachWatch.
 //
 // The return value reflects whether the watch was successfully added. False is returned if there
 // is already a function registered.
 func (b *Bus) AddWatch(busFunc BusWatchFunc) bool {
-	fPtr := gopointer.Save(busFunc)
-	return gobool(
-		C.int(C.gst_bus_add_watch(
-			b.Instance(),
-			C.GstBusFunc(C.cgoBusFunc),
-			(C.gpointer)(unsafe.Pointer(fPtr)),
-		)),
+	fPtr := savePointer(busFunc)
+	id := C.gst_bus_add_watch_full(
+		b.Instance(),
+		C.G_PRIORITY_DEFAULT,
+		C.GstBusFunc(C.cgoBusFunc),
+		(C.gpointer)(unsafe.Pointer(fPtr)),
+		C.GDestroyNotify(C.cgoBusDestroyNotify),
 	)
+	// the destroy notify is not called if the watch could not be added
+	if id == 0 {
+		unrefPointer(fPtr)
+		return false
+	}
+	return true
+}
+
+// BusWatch is a watch created with AttachWatch. It is the Source dispatching the messages of the
+// bus, so removing it with Remove releases the watch function.
+type BusWatch struct{ *Source }
+
+// AttachWatch creates a watch for messages emitted on this bus and attaches it to the given MainContext,
+// or the default context if it is nil. Unlike AddWatch, any number of watches created this way can exist
+// on a bus at the same time. The watch holds a reference to the Bus while it is attached.
+//
+// The watch is removed when the function returns false or when Remove is called on the returned BusWatch,
+// at which point the function is released. A MainLoop must be running on the context for the watch to be
+// dispatched.
+//
+//   watch := bus.AttachWatch(nil, func(msg *gst.Message) bool {
+//       fmt.Println(msg)
+//       return true
+//   })
+//   defer watch.Remove()
+func (b *Bus) AttachWatch(ctx *MainContext, busFunc BusWatchFunc) *BusWatch {
+	src := C.newGoBusWatchSource(b.Instance(), (C.gpointer)(savePointer(busFunc)))
+	return &BusWatch{wrapSource(ctx, src)}
 }
 
 // CreateWatch creates a watch and returns the GSource to be added to a main loop.
@@ -190,8 +206,9 @@ func (b *Bus) CreateWatch() *C.GSource {
 	return C.gst_bus_create_watch(b.Instance())
 }
 
-// RemoveWatch will remove any watches installed on the bus. This can also be accomplished
-// by returning false from a previously installed function.
+// RemoveWatch will remove the watch installed on the bus with AddWatch. This can also be accomplished
+// by returning false from a previously installed function. Watches created with AttachWatch are removed
+// with the Remove method of the returned Source.
 //
 // The function returns false if there was no watch on the bus.
 func (b *Bus) RemoveWatch() bool {
@@ -256,7 +273,7 @@ func (b *Bus) HavePending() bool {
 // Peek peeks the message on the top of the bus' queue. The message will remain on the bus'
 // message queue. A reference is returned, and needs to be unreffed by the caller.
 func (b *Bus) Peek() *Message {
-	return wrapMessage(C.gst_bus_peek(b.Instance()))
+	return takeMessage(C.gst_bus_peek(b.Instance()))
 }
 
 // Poll the bus for messages. Will block while waiting for messages to come. You can specify a maximum
@@ -289,7 +306,7 @@ func (b *Bus) Poll(msgTypes MessageType, timeout time.Duration) *Message {
 	if msg == nil {
 		return nil
 	}
-	return wrapMessage(msg)
+	return takeMessage(msg)
 }
 
 // Pop pops a message from the bus, or returns nil if none are available.
@@ -298,7 +315,7 @@ func (b *Bus) Pop() *Message {
 	if msg == nil {
 		return nil
 	}
-	return wrapMessage(msg)
+	return takeMessage(msg)
 }
 
 // PopFiltered gets a message matching type from the bus. Will discard all messages on the bus that do not match type
@@ -310,12 +327,12 @@ func (b *Bus) PopFiltered(msgTypes MessageType) *Message {
 	if msg == nil {
 		return nil
 	}
-	return wrapMessage(msg)
+	return takeMessage(msg)
 }
 
 // Post a new message on the bus. The bus takes ownership of the message.
 func (b *Bus) Post(msg *Message) bool {
-	return gobool(C.gst_bus_post(b.Instance(), msg.Instance()))
+	return gobool(C.gst_bus_post(b.Instance(), msg.give()))
 }
 
 // PostError is a wrapper for creating a new error mesesage and then posting it to the bus.
@@ -336,7 +353,14 @@ func (b *Bus) PostError(src interface{}, msg string, err error) bool {
 
 // SetFlushing sets whether to flush out and unref any messages queued in the bus. Releases references to the message origin
 // objects. Will flush future messages until SetFlushing sets flushing to FALSE.
-func (b *Bus) SetFlushing(flushing bool) { C.gst_bus_set_flushing(b.Instance(), gboolean(flushing)) }
+//
+// Setting flushing to true also ends any Subscriptions on the bus.
+func (b *Bus) SetFlushing(flushing bool) {
+	C.gst_bus_set_flushing(b.Instance(), gboolean(flushing))
+	if flushing {
+		closeBusSubscriptions(b.Instance())
+	}
+}
 
 // BusSyncHandler will be invoked synchronously, when a new message has been injected into the bus. This function is mostly
 // used internally. Only one sync handler can be attached to a given bus.
@@ -348,14 +372,18 @@ type BusSyncHandler func(msg *Message) BusSyncReply
 // Note that the function will be called in the same thread context as the posting object. This function is usually only called by the
 // creator of the bus. Applications should handle messages asynchronously using the watch and poll functions.
 //
-// Currently, destroyNotify funcs are not supported.
+// Any previously set handler is released when it is replaced. Passing nil unsets the current handler.
 func (b *Bus) SetSyncHandler(f BusSyncHandler) {
-	ptr := gopointer.Save(f)
+	if f == nil {
+		C.gst_bus_set_sync_handler(b.Instance(), nil, nil, nil)
+		return
+	}
+	ptr := savePointer(f)
 	C.gst_bus_set_sync_handler(
 		b.Instance(),
 		C.GstBusSyncHandler(C.cgoBusSyncHandler),
 		(C.gpointer)(unsafe.Pointer(ptr)),
-		nil,
+		C.GDestroyNotify(C.cgoBusDestroyNotify),
 	)
 }
 
@@ -373,7 +401,7 @@ func (b *Bus) TimedPop(dur time.Duration) *Message {
 	if msg == nil {
 		return nil
 	}
-	return wrapMessage(msg)
+	return takeMessage(msg)
 }
 
 // TimedPopFiltered gets a message from the bus whose type matches the message type mask types, waiting up to the specified timeout
@@ -392,5 +420,5 @@ func (b *Bus) TimedPopFiltered(dur time.Duration, msgTypes MessageType) *Message
 	if msg == nil {
 		return nil
 	}
-	return wrapMessage(msg)
+	return takeMessage(msg)
 }
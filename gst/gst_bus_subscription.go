@@ -0,0 +1,451 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+extern void goBusSyncMessage (GstBus * bus, GstMessage * msg, gpointer user_data);
+extern void goBusWeakNotify  (gpointer user_data, GObject * bus);
+
+void cgoBusSyncMessage (GstBus * bus, GstMessage * msg, gpointer user_data)
+{
+	goBusSyncMessage(bus, msg, user_data);
+}
+
+void cgoBusWeakNotify (gpointer user_data, GObject * bus)
+{
+	goBusWeakNotify(user_data, bus);
+}
+
+gulong connectBusSyncMessage (GstBus * bus)
+{
+	return g_signal_connect(bus, "sync-message", G_CALLBACK(cgoBusSyncMessage), NULL);
+}
+
+void addBusWeakNotify    (GstBus * bus) { g_object_weak_ref(G_OBJECT(bus), cgoBusWeakNotify, NULL); }
+void removeBusWeakNotify (GstBus * bus) { g_object_weak_unref(G_OBJECT(bus), cgoBusWeakNotify, NULL); }
+
+// The termination of the stream on a bus is tracked in its qdata, 1 while the stream runs and 2 once
+// it ended. It is tracked from C so it can be done for the lifetime of the bus at no cost to go.
+#define GO_BUS_TERMINATED_QUARK (g_quark_from_static_string("go-gst-bus-terminated"))
+
+static void busTrackTermination (GstBus * bus, GstMessage * msg, gpointer user_data)
+{
+	switch (GST_MESSAGE_TYPE(msg)) {
+	case GST_MESSAGE_EOS:
+	case GST_MESSAGE_ERROR:
+		g_object_set_qdata(G_OBJECT(bus), GO_BUS_TERMINATED_QUARK, GINT_TO_POINTER(2));
+		break;
+	case GST_MESSAGE_ASYNC_DONE:
+	case GST_MESSAGE_STREAM_START:
+		g_object_set_qdata(G_OBJECT(bus), GO_BUS_TERMINATED_QUARK, GINT_TO_POINTER(1));
+		break;
+	default:
+		break;
+	}
+}
+
+void trackBusTermination (GstBus * bus)
+{
+	gboolean tracked;
+	GST_OBJECT_LOCK(bus);
+	tracked = g_object_get_qdata(G_OBJECT(bus), GO_BUS_TERMINATED_QUARK) != NULL;
+	if (!tracked)
+		g_object_set_qdata(G_OBJECT(bus), GO_BUS_TERMINATED_QUARK, GINT_TO_POINTER(1));
+	GST_OBJECT_UNLOCK(bus);
+	if (tracked)
+		return;
+	// The emission is never disabled, since the handler lives as long as the bus.
+	gst_bus_enable_sync_message_emission(bus);
+	g_signal_connect(bus, "sync-message", G_CALLBACK(busTrackTermination), NULL);
+}
+
+gboolean busIsTerminated (GstBus * bus)
+{
+	return GPOINTER_TO_INT(g_object_get_qdata(G_OBJECT(bus), GO_BUS_TERMINATED_QUARK)) == 2;
+}
+
+void busResetTermination (GstBus * bus)
+{
+	if (busIsTerminated(bus))
+		g_object_set_qdata(G_OBJECT(bus), GO_BUS_TERMINATED_QUARK, GINT_TO_POINTER(1));
+}
+
+gboolean busIsFlushing (GstBus * bus)
+{
+	gboolean flushing;
+	GST_OBJECT_LOCK(bus);
+	flushing = GST_OBJECT_FLAG_IS_SET(bus, GST_BUS_FLUSHING);
+	GST_OBJECT_UNLOCK(bus);
+	return flushing;
+}
+*/
+import "C"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// DefaultSubscriptionBufferSize is the number of messages buffered by a Subscription when none is
+// provided in the SubscribeOptions.
+const DefaultSubscriptionBufferSize = 32
+
+// DropPolicy determines what happens to a message delivered to a Subscription whose buffer is full.
+// Every subscription has its own buffer, so a subscriber that does not keep up never holds back the
+// others or the thread that posted the message.
+type DropPolicy int
+
+// Type castings of DropPolicies
+const (
+	DropNewest DropPolicy = iota // (0) – The incoming message is discarded, keeping the messages already buffered.
+	DropOldest                   // (1) – The oldest buffered message is discarded to make room for the incoming one.
+	DropNone                     // (2) – No message is ever discarded, the buffer grows for as long as the subscriber lags behind.
+)
+
+// SubscribeOptions are options that can be passed to Subscribe. A nil value uses the defaults.
+type SubscribeOptions struct {
+	// BufferSize is the number of messages that can be queued on the subscription before the
+	// DropPolicy takes effect. Defaults to DefaultSubscriptionBufferSize. It is ignored for DropNone.
+	BufferSize int
+	// DropPolicy is the policy to apply when the buffer is full. Defaults to DropNewest.
+	DropPolicy DropPolicy
+}
+
+// Subscription is a channel based listener for messages posted on a Bus. Subscriptions are created
+// with Bus.Subscribe.
+type Subscription struct {
+	dispatcher *busDispatcher
+	mask       MessageType
+	policy     DropPolicy
+	size       int
+	// persistent subscriptions are not ended when the bus is flushed, only when it is finalized.
+	persistent bool
+
+	ch      chan *Message
+	done    chan struct{}
+	cancel  chan struct{}
+	wake    chan struct{}
+	dropped uint64
+
+	mux       sync.Mutex
+	queue     []*Message
+	ended     bool
+	discarded bool
+}
+
+// Messages returns the channel on which messages are delivered. Each message received holds its own
+// reference and should be unreffed after usage. The channel is closed when the subscription ends and
+// the messages buffered before that have been received.
+func (s *Subscription) Messages() <-chan *Message { return s.ch }
+
+// Done returns a channel that is closed when the subscription ends, either through Unsubscribe, the
+// cancellation of its context, the bus being flushed, the pipeline being destroyed, or the bus being
+// finalized.
+func (s *Subscription) Done() <-chan struct{} { return s.done }
+
+// Dropped returns the number of messages that have been discarded by the DropPolicy of this subscription.
+func (s *Subscription) Dropped() uint64 { return atomic.LoadUint64(&s.dropped) }
+
+// Unsubscribe ends the subscription. Any messages still buffered on the channel are unreffed and
+// discarded. It is safe to call this function multiple times.
+func (s *Subscription) Unsubscribe() {
+	busDispatchersMux.Lock()
+	s.dispatcher.remove(s)
+	busDispatchersMux.Unlock()
+	s.end(true)
+	for msg := range s.ch {
+		msg.Unref()
+	}
+}
+
+// push queues the message on the subscription according to its DropPolicy. It never blocks.
+func (s *Subscription) push(msg *C.GstMessage) {
+	if MessageType(msg._type)&s.mask == 0 {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.ended {
+		return
+	}
+	if s.policy != DropNone && len(s.queue) >= s.size {
+		atomic.AddUint64(&s.dropped, 1)
+		if s.policy == DropNewest {
+			return
+		}
+		s.queue[0].Unref()
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+	}
+	s.queue = append(s.queue, takeMessage(C.gst_message_ref(msg)))
+	s.notify()
+}
+
+// end ends the subscription. When discard is true the messages that were not received yet are
+// dropped, otherwise they remain readable from the channel.
+func (s *Subscription) end(discard bool) {
+	s.mux.Lock()
+	first := !s.ended
+	s.ended = true
+	cancel := discard && !s.discarded
+	if discard {
+		s.discarded = true
+	}
+	s.mux.Unlock()
+	if first {
+		close(s.done)
+	}
+	if cancel {
+		close(s.cancel)
+	}
+	s.notify()
+}
+
+func (s *Subscription) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next blocks until a message is queued, and returns nil once the subscription has ended and there
+// are no more messages to deliver.
+func (s *Subscription) next() *Message {
+	for {
+		s.mux.Lock()
+		if s.discarded {
+			for _, msg := range s.queue {
+				msg.Unref()
+			}
+			s.queue = nil
+			s.mux.Unlock()
+			return nil
+		}
+		if len(s.queue) > 0 {
+			msg := s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+			s.mux.Unlock()
+			return msg
+		}
+		ended := s.ended
+		s.mux.Unlock()
+		if ended {
+			return nil
+		}
+		<-s.wake
+	}
+}
+
+// run moves queued messages to the channel of the subscription until it ends.
+func (s *Subscription) run() {
+	defer close(s.ch)
+	for {
+		msg := s.next()
+		if msg == nil {
+			return
+		}
+		select {
+		case s.ch <- msg:
+		case <-s.cancel:
+			msg.Unref()
+		}
+	}
+}
+
+// busDispatcher hands the messages posted on a single bus to each of its subscriptions. It is
+// connected to the "sync-message" signal of the bus for as long as it has subscriptions, and does
+// not hold a reference to the bus.
+type busDispatcher struct {
+	bus       *C.GstBus
+	handlerID C.gulong
+	subs      map[*Subscription]struct{}
+}
+
+var (
+	busDispatchers    = make(map[*C.GstBus]*busDispatcher)
+	busDispatchersMux sync.Mutex
+)
+
+// dispatchBusMessage delivers a message posted on the given bus to its subscriptions. It is called
+// from the thread that posted the message, and only holds the lock of each subscription while
+// queuing the message on it.
+func dispatchBusMessage(bus *C.GstBus, msg *C.GstMessage) {
+	busDispatchersMux.Lock()
+	d, ok := busDispatchers[bus]
+	if !ok {
+		busDispatchersMux.Unlock()
+		return
+	}
+	subs := make([]*Subscription, 0, len(d.subs))
+	for sub := range d.subs {
+		subs = append(subs, sub)
+	}
+	busDispatchersMux.Unlock()
+	for _, sub := range subs {
+		sub.push(msg)
+	}
+}
+
+// busFinalized ends all the subscriptions of a bus that is being finalized. Persistent subscriptions
+// cannot be unsubscribed from, so the messages they did not deliver yet are dropped.
+func busFinalized(bus *C.GstBus) {
+	busDispatchersMux.Lock()
+	d, ok := busDispatchers[bus]
+	if !ok {
+		busDispatchersMux.Unlock()
+		return
+	}
+	// The signal handler and the weak reference are released along with the bus.
+	delete(busDispatchers, bus)
+	ending := make([]*Subscription, 0, len(d.subs))
+	for sub := range d.subs {
+		ending = append(ending, sub)
+	}
+	d.subs = make(map[*Subscription]struct{})
+	busDispatchersMux.Unlock()
+	for _, sub := range ending {
+		sub.end(sub.persistent)
+	}
+}
+
+// trackBusTermination starts tracking whether the stream on the given bus ended, for as long as
+// the bus lives. It is a no-op for buses that are already tracked.
+func trackBusTermination(bus *C.GstBus) { C.trackBusTermination(bus) }
+
+// busTerminated returns true if an EOS or ERROR message was posted on the bus since the stream
+// started, prerolled or the bus was flushed, and since its termination is tracked.
+func busTerminated(bus *C.GstBus) bool { return gobool(C.busIsTerminated(bus)) }
+
+// flushed ends the subscriptions that do not survive the bus being flushed. Their buffered messages
+// remain readable.
+func (d *busDispatcher) flushed() {
+	busDispatchersMux.Lock()
+	ending := make([]*Subscription, 0, len(d.subs))
+	for sub := range d.subs {
+		if !sub.persistent {
+			d.remove(sub)
+			ending = append(ending, sub)
+		}
+	}
+	busDispatchersMux.Unlock()
+	for _, sub := range ending {
+		sub.end(false)
+	}
+}
+
+// remove removes a subscription from the dispatcher, disconnecting it from the bus when it was the
+// last one. It must be called with busDispatchersMux held.
+func (d *busDispatcher) remove(s *Subscription) {
+	if _, ok := d.subs[s]; !ok {
+		return
+	}
+	delete(d.subs, s)
+	if len(d.subs) > 0 || busDispatchers[d.bus] != d {
+		return
+	}
+	C.g_signal_handler_disconnect((C.gpointer)(unsafe.Pointer(d.bus)), d.handlerID)
+	C.gst_bus_disable_sync_message_emission(d.bus)
+	C.removeBusWeakNotify(d.bus)
+	delete(busDispatchers, d.bus)
+}
+
+// Subscribe creates a new Subscription for messages on the bus matching the given mask. Use MessageAny
+// to receive all messages. The subscription ends when ctx is cancelled, when Unsubscribe is called, when
+// the bus is flushed through SetFlushing or by setting its pipeline to the NULL state from go, when the
+// pipeline is destroyed, or when the bus is finalized. Options may be nil to use the defaults.
+//
+// Messages are observed through the "sync-message" signal of the bus, like SetStateContext does, so they
+// are not removed from it. Any number of subscriptions can coexist on a bus, along with bus watches, signal
+// watches and popping messages directly, which all still receive every message. Since nothing is popped on
+// behalf of the subscriptions, a bus that is only observed through subscriptions keeps its messages queued
+// until it is flushed. Every subscription buffers messages on its own, and a subscriber that does not keep
+// up loses messages according to its DropPolicy without holding back the other subscribers or the pipeline.
+//
+//   sub := pipeline.GetPipelineBus().Subscribe(ctx, gst.MessageEOS|gst.MessageError, nil)
+//   defer sub.Unsubscribe()
+//
+//   for msg := range sub.Messages() {
+//       fmt.Println(msg)
+//       msg.Unref()
+//   }
+//
+// Messages posted while the bus is flushing are discarded by the bus and are never seen by a subscription.
+// A bus flushed from C, e.g. by a pipeline stopped outside of go, does not end the subscriptions.
+func (b *Bus) Subscribe(ctx context.Context, mask MessageType, opts *SubscribeOptions) *Subscription {
+	return subscribeBus(b.Instance(), ctx, mask, opts, false)
+}
+
+func subscribeBus(bus *C.GstBus, ctx context.Context, mask MessageType, opts *SubscribeOptions, persistent bool) *Subscription {
+	if opts == nil {
+		opts = &SubscribeOptions{}
+	}
+	size := opts.BufferSize
+	if size <= 0 {
+		size = DefaultSubscriptionBufferSize
+	}
+	sub := &Subscription{
+		mask:       mask,
+		policy:     opts.DropPolicy,
+		size:       size,
+		persistent: persistent,
+		ch:         make(chan *Message),
+		done:       make(chan struct{}),
+		cancel:     make(chan struct{}),
+		wake:       make(chan struct{}, 1),
+	}
+
+	busDispatchersMux.Lock()
+	d, ok := busDispatchers[bus]
+	if !ok {
+		d = &busDispatcher{bus: bus, subs: make(map[*Subscription]struct{})}
+		trackBusTermination(bus)
+		C.gst_bus_enable_sync_message_emission(bus)
+		d.handlerID = C.connectBusSyncMessage(bus)
+		C.addBusWeakNotify(bus)
+		busDispatchers[bus] = d
+	}
+	d.subs[sub] = struct{}{}
+	sub.dispatcher = d
+	busDispatchersMux.Unlock()
+
+	go sub.run()
+	if ctx != nil && ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				sub.Unsubscribe()
+			case <-sub.done:
+			}
+		}()
+	}
+	return sub
+}
+
+// closeBusSubscriptions ends the subscriptions on the given bus that do not survive a flush, and
+// forgets about the end of its stream. Messages already buffered remain readable from the
+// subscription channels.
+func closeBusSubscriptions(bus *C.GstBus) {
+	C.busResetTermination(bus)
+	busDispatchersMux.Lock()
+	d, ok := busDispatchers[bus]
+	busDispatchersMux.Unlock()
+	if ok {
+		d.flushed()
+	}
+}
+
+// closeFlushedBusSubscriptions ends the subscriptions on the bus of the given element if the bus is
+// flushing, which pipelines do when they are set to the NULL state.
+func closeFlushedBusSubscriptions(elem *C.GstElement) {
+	bus := C.gst_element_get_bus(elem)
+	if bus == nil {
+		return
+	}
+	defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(bus)))
+	if gobool(C.busIsFlushing(bus)) {
+		closeBusSubscriptions(bus)
+	}
+}
@@ -0,0 +1,284 @@
+package gst
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+const testTimeout = 5 * time.Second
+
+func postApplicationMessages(t *testing.T, bus *Bus, n int) {
+	t.Helper()
+	src, err := NewElement("fakesrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Unref()
+	for i := 0; i < n; i++ {
+		if !bus.Post(NewApplicationMessage(src, NewStructure(fmt.Sprintf("message-%d", i)))) {
+			t.Fatalf("Could not post message %d", i)
+		}
+	}
+}
+
+func receiveMessage(t *testing.T, ch <-chan *Message) *Message {
+	t.Helper()
+	select {
+	case msg, ok := <-ch:
+		if !ok {
+			t.Fatal("Subscription channel closed early")
+		}
+		return msg
+	case <-time.After(testTimeout):
+		t.Fatal("Timed out waiting for a message")
+	}
+	return nil
+}
+
+func waitClosed(t *testing.T, ch <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(testTimeout):
+		t.Fatal("Timed out waiting for the subscription to end")
+	}
+}
+
+func TestSubscribeDeliversWithoutPopping(t *testing.T) {
+	bus := NewBus()
+	defer bus.Unref()
+
+	sub := bus.Subscribe(context.Background(), MessageApplication, &SubscribeOptions{DropPolicy: DropNone})
+	defer sub.Unsubscribe()
+
+	postApplicationMessages(t, bus, 10)
+	for i := 0; i < 10; i++ {
+		msg := receiveMessage(t, sub.Messages())
+		if name := msg.GetStructure().Name(); name != fmt.Sprintf("message-%d", i) {
+			t.Errorf("Expected message-%d, got %s", i, name)
+		}
+		msg.Unref()
+	}
+	// The messages are left on the bus for any other consumer.
+	for i := 0; i < 10; i++ {
+		msg := bus.Pop()
+		if msg == nil {
+			t.Fatalf("Expected message-%d to still be queued on the bus", i)
+		}
+		if name := msg.GetStructure().Name(); name != fmt.Sprintf("message-%d", i) {
+			t.Errorf("Expected message-%d on the bus, got %s", i, name)
+		}
+		msg.Unref()
+	}
+}
+
+func TestWaitLeavesMessagesOnTheBus(t *testing.T) {
+	pipeline, err := NewPipelineFromString("fakesrc num-buffers=1 ! fakesink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeline.Destroy()
+	bus := pipeline.GetPipelineBus()
+
+	if err := pipeline.Start(); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		Wait(pipeline)
+		close(done)
+	}()
+	waitClosed(t, done)
+
+	// The EOS Wait returned for can still be popped from the bus.
+	msg := bus.TimedPopFiltered(testTimeout, MessageEOS)
+	if msg == nil {
+		t.Fatal("Expected the EOS message to be left on the bus")
+	}
+	msg.Unref()
+}
+
+func TestMessageChanClosesWhenBusIsFinalized(t *testing.T) {
+	bus := NewBus()
+	ch := bus.MessageChan()
+	postApplicationMessages(t, bus, 3)
+	bus.Unref()
+
+	deadline := time.After(testTimeout)
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			msg.Unref()
+		case <-deadline:
+			t.Fatal("Timed out waiting for the channel to be closed")
+		}
+	}
+}
+
+func TestSubscribeMask(t *testing.T) {
+	bus := NewBus()
+	defer bus.Unref()
+
+	sub := bus.Subscribe(context.Background(), MessageEOS, nil)
+	defer sub.Unsubscribe()
+
+	src, err := NewElement("fakesrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Unref()
+	bus.Post(NewApplicationMessage(src, NewStructure("ignored")))
+	bus.Post(NewEOSMessage(src))
+
+	msg := receiveMessage(t, sub.Messages())
+	defer msg.Unref()
+	if msg.Type() != MessageEOS {
+		t.Errorf("Expected an EOS message, got %s", msg.TypeName())
+	}
+}
+
+func TestSlowSubscriberDoesNotStallOthers(t *testing.T) {
+	bus := NewBus()
+	defer bus.Unref()
+
+	slow := bus.Subscribe(context.Background(), MessageApplication, &SubscribeOptions{BufferSize: 1, DropPolicy: DropNewest})
+	defer slow.Unsubscribe()
+	fast := bus.Subscribe(context.Background(), MessageApplication, &SubscribeOptions{DropPolicy: DropNone})
+	defer fast.Unsubscribe()
+
+	postApplicationMessages(t, bus, 20)
+	for i := 0; i < 20; i++ {
+		receiveMessage(t, fast.Messages()).Unref()
+	}
+	// The slow subscriber holds one message in hand and one buffered, the rest are dropped.
+	if dropped := slow.Dropped(); dropped < 18 {
+		t.Errorf("Expected at least 18 dropped messages, got %d", dropped)
+	}
+}
+
+func TestDropOldestKeepsNewest(t *testing.T) {
+	bus := NewBus()
+	defer bus.Unref()
+
+	sub := bus.Subscribe(context.Background(), MessageApplication, &SubscribeOptions{BufferSize: 1, DropPolicy: DropOldest})
+	defer sub.Unsubscribe()
+
+	postApplicationMessages(t, bus, 10)
+	deadline := time.Now().Add(testTimeout)
+	for sub.Dropped() < 8 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The subscription may hold the oldest message in hand, followed by the newest one buffered.
+	var last string
+	for i := 0; i < 2 && last != "message-9"; i++ {
+		msg := receiveMessage(t, sub.Messages())
+		last = msg.GetStructure().Name()
+		msg.Unref()
+	}
+	if last != "message-9" {
+		t.Errorf("Expected the newest message to be kept, got %s", last)
+	}
+}
+
+func TestSubscriptionEndsOnFlush(t *testing.T) {
+	bus := NewBus()
+	defer bus.Unref()
+
+	sub := bus.Subscribe(context.Background(), MessageAny, nil)
+	defer sub.Unsubscribe()
+	ch := bus.MessageChan()
+
+	bus.SetFlushing(true)
+	waitClosed(t, sub.Done())
+	for range sub.Messages() {
+	}
+
+	// MessageChan survives flushes and never drops messages.
+	bus.SetFlushing(false)
+	postApplicationMessages(t, bus, 100)
+	for i := 0; i < 100; i++ {
+		receiveMessage(t, ch).Unref()
+	}
+}
+
+func TestSubscriptionEndsOnContextCancel(t *testing.T) {
+	bus := NewBus()
+	defer bus.Unref()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := bus.Subscribe(ctx, MessageAny, nil)
+	postApplicationMessages(t, bus, 5)
+	cancel()
+
+	waitClosed(t, sub.Done())
+	for msg := range sub.Messages() {
+		msg.Unref()
+	}
+}
+
+func TestWaitReturnsForStoppedPipeline(t *testing.T) {
+	pipeline, err := NewPipeline("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeline.Unref()
+
+	done := make(chan struct{})
+	go func() {
+		Wait(pipeline)
+		close(done)
+	}()
+	waitClosed(t, done)
+}
+
+func TestWaitReturnsAfterEOS(t *testing.T) {
+	pipeline, err := NewPipelineFromString("fakesrc num-buffers=1 ! fakesink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeline.Destroy()
+
+	if err := pipeline.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// Let the pipeline reach EOS before waiting on it.
+	time.Sleep(500 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		Wait(pipeline)
+		close(done)
+	}()
+	waitClosed(t, done)
+}
+
+func TestWaitReturnsWhenStoppedFromC(t *testing.T) {
+	pipeline, err := NewPipelineFromString("fakesrc is-live=true ! fakesink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeline.Unref()
+
+	if err := pipeline.Start(); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		Wait(pipeline)
+		close(done)
+	}()
+	time.Sleep(200 * time.Millisecond)
+	// Stopping in two steps means the READY to NULL transition is never seen on the bus.
+	if err := pipeline.BlockSetState(StateReady); err != nil {
+		t.Fatal(err)
+	}
+	if err := pipeline.BlockSetState(StateNull); err != nil {
+		t.Fatal(err)
+	}
+	waitClosed(t, done)
+}
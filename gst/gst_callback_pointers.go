@@ -0,0 +1,103 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+GWeakRef * newGoWeakRef (gpointer object)
+{
+	GWeakRef * ref = g_new0(GWeakRef, 1);
+	g_weak_ref_init(ref, object);
+	return ref;
+}
+
+void freeGoWeakRef (GWeakRef * ref)
+{
+	g_weak_ref_clear(ref);
+	g_free(ref);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	gopointer "github.com/mattn/go-pointer"
+)
+
+// livePointers is the number of go values currently saved for use as user data in C callbacks.
+var livePointers int64
+
+// savePointer saves v for passing as user data to C and counts it as live until it is released
+// with unrefPointer, usually from a GDestroyNotify.
+func savePointer(v interface{}) unsafe.Pointer {
+	atomic.AddInt64(&livePointers, 1)
+	return gopointer.Save(v)
+}
+
+// unrefPointer releases a pointer previously returned from savePointer.
+func unrefPointer(ptr unsafe.Pointer) {
+	gopointer.Unref(ptr)
+	atomic.AddInt64(&livePointers, -1)
+}
+
+// SavePointerUnsafe saves v for passing as user data to a C callback. The returned pointer must be
+// released with UnrefPointerUnsafe once the callback can no longer be called, usually from a GDestroyNotify.
+//
+// This is meant for internal usage and is exported for visibility to other packages.
+func SavePointerUnsafe(v interface{}) unsafe.Pointer { return savePointer(v) }
+
+// UnrefPointerUnsafe releases a pointer returned from SavePointerUnsafe.
+//
+// This is meant for internal usage and is exported for visibility to other packages.
+func UnrefPointerUnsafe(ptr unsafe.Pointer) { unrefPointer(ptr) }
+
+// LiveCallbacks returns the number of go callbacks and values that are currently registered with C,
+// e.g. by signal handlers, pad probes, bus watches, sources or CallAsync. Each of these is released when
+// the C side no longer needs it, such as when a probe or watch is removed, or when the object it was
+// registered on is finalized.
+//
+// It is meant for detecting leaks in tests, by comparing the value before and after the code under test.
+// See also CheckCallbackLeaks.
+func LiveCallbacks() int { return int(atomic.LoadInt64(&livePointers)) }
+
+// CheckCallbackLeaks waits up to timeout for the number of LiveCallbacks to drop to expected, and
+// returns an error if it does not. Callbacks are often released from other threads, for example when
+// a pipeline shuts down or a main loop dispatches the removal of a source, hence the timeout.
+//
+//   func TestPipeline(t *testing.T) {
+//       before := gst.LiveCallbacks()
+//       runPipeline()
+//       if err := gst.CheckCallbackLeaks(before, time.Second); err != nil {
+//           t.Error(err)
+//       }
+//   }
+func CheckCallbackLeaks(expected int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		live := LiveCallbacks()
+		if live <= expected {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d go callbacks were not released, expected %d live but found %d", live-expected, expected, live)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// weakRef is a weak reference to a GObject. It lets the handles of callbacks registered on an object
+// outlive the object itself.
+type weakRef struct{ ptr *C.GWeakRef }
+
+func newWeakRef(object unsafe.Pointer) *weakRef {
+	ref := &weakRef{ptr: C.newGoWeakRef(C.gpointer(object))}
+	runtime.SetFinalizer(ref, func(ref *weakRef) { C.freeGoWeakRef(ref.ptr) })
+	return ref
+}
+
+// get returns a new reference to the object, or nil if it has been finalized.
+func (w *weakRef) get() C.gpointer { return C.g_weak_ref_get(w.ptr) }
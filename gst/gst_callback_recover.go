@@ -0,0 +1,90 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+gboolean postCallbackPanic (GstObject * owner, const gchar * text, const gchar * debug)
+{
+	GstElement * element = NULL;
+	GstMessage * msg;
+	GError     * err;
+
+	if (owner == NULL)
+		return FALSE;
+
+	err = g_error_new_literal(GST_CORE_ERROR, GST_CORE_ERROR_FAILED, text);
+
+	if (GST_IS_BUS(owner)) {
+		msg = gst_message_new_error(NULL, err, debug);
+		g_error_free(err);
+		return gst_bus_post(GST_BUS(owner), msg);
+	}
+
+	if (GST_IS_ELEMENT(owner)) {
+		element = GST_ELEMENT(gst_object_ref(owner));
+	} else if (GST_IS_PAD(owner)) {
+		element = gst_pad_get_parent_element(GST_PAD(owner));
+	}
+
+	if (element == NULL) {
+		g_error_free(err);
+		return FALSE;
+	}
+
+	msg = gst_message_new_error(GST_OBJECT(element), err, debug);
+	g_error_free(err);
+
+	gboolean ret = gst_element_post_message(element, msg);
+	gst_object_unref(element);
+	return ret;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"unsafe"
+)
+
+// RecoverCallbackUnsafe recovers from a panic in a go callback invoked from C, usually on one of
+// the streaming threads of a pipeline, where a panic would otherwise crash the whole process. The
+// panic is posted as an error message on the bus of the owning element, with the stack trace in the
+// debug string. The owner can be a GstElement, a GstPad (in which case its parent element is used),
+// or a GstBus. If it is nil, or the message could not be posted, the panic is printed to stderr.
+//
+// onPanic is called after the panic is reported, and should be used to set a safe return value for the
+// callback, e.g. FlowError. RecoverCallbackUnsafe must be deferred directly by the callback:
+//
+//   //export goMyCallback
+//   func goMyCallback(elem *C.GstElement) (ret C.GstFlowReturn) {
+//       defer gst.RecoverCallbackUnsafe(unsafe.Pointer(elem), func() { ret = C.GstFlowReturn(gst.FlowError) })
+//       ...
+//   }
+//
+// This is meant for internal usage and is exported for visibility to other packages.
+func RecoverCallbackUnsafe(owner unsafe.Pointer, onPanic func()) {
+	if r := recover(); r != nil {
+		reportCallbackPanic(owner, r, debug.Stack())
+		if onPanic != nil {
+			onPanic()
+		}
+	}
+}
+
+func reportCallbackPanic(owner unsafe.Pointer, r interface{}, stack []byte) {
+	text := fmt.Sprintf("Panic in go callback: %v", r)
+	cText := C.CString(text)
+	defer C.free(unsafe.Pointer(cText))
+	cStack := C.CString(string(stack))
+	defer C.free(unsafe.Pointer(cStack))
+	posted := C.postCallbackPanic(
+		(*C.GstObject)(owner),
+		(*C.gchar)(unsafe.Pointer(cText)),
+		(*C.gchar)(unsafe.Pointer(cStack)),
+	)
+	if !gobool(posted) {
+		fmt.Fprintf(os.Stderr, "go-gst-error: %s\n%s", text, stack)
+	}
+}
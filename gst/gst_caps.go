@@ -18,18 +18,23 @@ import (
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // Caps is a go wrapper around GstCaps.
 type Caps struct {
 	native *C.GstCaps
+	refs   refTracker
 }
 
 // FromGstCapsUnsafe wraps the pointer to the given C GstCaps with the go type.
 // This is meant for internal usage and is exported for visibility to other packages.
 func FromGstCapsUnsafe(caps unsafe.Pointer) *Caps { return wrapCaps(C.toGstCaps(caps)) }
 
+// FromGstCapsUnsafeFull wraps the pointer to the given C GstCaps with the go type, taking ownership
+// of the reference the caller holds on it (transfer full).
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstCapsUnsafeFull(caps unsafe.Pointer) *Caps { return takeCaps(C.toGstCaps(caps)) }
+
 // CapsMapFunc represents a function passed to the Caps MapInPlace, ForEach, and FilterAndMapInPlace methods.
 type CapsMapFunc func(features *CapsFeatures, structure *Structure) bool
 
@@ -39,7 +44,7 @@ type CapsMapFunc func(features *CapsFeatures, structure *Structure) bool
 //   fmt.Println(caps.IsAny())
 //   // true
 //
-func NewAnyCaps() *Caps { return wrapCaps(C.gst_caps_new_any()) }
+func NewAnyCaps() *Caps { return takeCaps(C.gst_caps_new_any()) }
 
 // NewEmptyCaps creates a new empty caps object. This is essentially the opposite of
 // NewAnyCamps.
@@ -48,7 +53,7 @@ func NewAnyCaps() *Caps { return wrapCaps(C.gst_caps_new_any()) }
 //   fmt.Println(caps.IsEmpty())
 //   // true
 //
-func NewEmptyCaps() *Caps { return wrapCaps(C.gst_caps_new_empty()) }
+func NewEmptyCaps() *Caps { return takeCaps(C.gst_caps_new_empty()) }
 
 // NewEmptySimpleCaps returns a new empty caps object with the given media format.
 //
@@ -60,7 +65,7 @@ func NewEmptySimpleCaps(mediaFormat string) *Caps {
 	cFormat := C.CString(mediaFormat)
 	defer C.free(unsafe.Pointer(cFormat))
 	caps := C.gst_caps_new_empty_simple(cFormat)
-	return wrapCaps(caps)
+	return takeCaps(caps)
 }
 
 // NewFullCaps creates a new caps from the given structures.
@@ -109,7 +114,7 @@ func NewCapsFromString(capsStr string) *Caps {
 	if caps == nil {
 		return nil
 	}
-	return wrapCaps(caps)
+	return takeCaps(caps)
 }
 
 // NewRawCaps returns new GstCaps with the given format, sample-rate, and channels.
@@ -126,17 +131,24 @@ func (c *Caps) unsafe() unsafe.Pointer { return unsafe.Pointer(c.native) }
 // will not change. This means its structures won't change, etc. To use a Caps object, you must always have a
 // refcount on it -- either the one made implicitly by e.g. NewSimpleCaps, or via taking one explicitly with
 // this function.
-func (c *Caps) Ref() { C.gst_caps_ref(c.Instance()) }
+func (c *Caps) Ref() { refMiniObject(c) }
 
 // Unref decreases the ref count on these caps by one.
-func (c *Caps) Unref() { C.gst_caps_unref(c.Instance()) }
+func (c *Caps) Unref() { unrefMiniObject(c) }
 
 // Instance returns the native GstCaps instance
 func (c *Caps) Instance() *C.GstCaps { return C.toGstCaps(c.unsafe()) }
 
 // MakeWritable returns a writable copy of caps.
 func (c *Caps) MakeWritable() *Caps {
-	return wrapCaps(C.makeCapsWritable(c.Instance()))
+	return takeCaps(C.makeCapsWritable(c.give()))
+}
+
+// MakeMut makes this caps writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and c refers to the copy from then on.
+// Unlike MakeWritable, c remains valid after the call and must be released as usual.
+func (c *Caps) MakeMut() {
+	c.native = C.toGstCaps(unsafe.Pointer(makeMutMiniObject(c)))
 }
 
 // String implements a stringer on a caps instance. This same string can be used for NewCapsFromString.
@@ -160,7 +172,7 @@ func (c *Caps) AppendStructureFull(st *Structure, features *CapsFeatures) {
 // Append appends the given caps element to these caps. These caps take ownership
 // over the given object. If either caps are ANY, the resulting caps will be ANY.
 func (c *Caps) Append(caps *Caps) {
-	C.gst_caps_append(c.Instance(), caps.Instance())
+	C.gst_caps_append(c.Instance(), caps.give())
 }
 
 // CanIntersect tries intersecting these caps with those given and reports whether the result would not be empty.
@@ -175,7 +187,7 @@ func (c *Caps) CanIntersect(caps *Caps) bool {
 // on to a reference to the data, you should use Ref.
 //
 // When you are finished with the caps, call Unref on it.
-func (c *Caps) Copy() *Caps { return wrapCaps(C.gst_caps_copy(c.Instance())) }
+func (c *Caps) Copy() *Caps { return takeCaps(C.gst_caps_copy(c.Instance())) }
 
 // CopyNth creates a new GstCaps and appends a copy of the nth structure contained in caps.
 // func (c *Caps) CopyNth(n uint) *Caps { return wrapCaps(C.gst_caps_copy_nth(c.Instance(), C.guint(n))) }
@@ -200,8 +212,8 @@ func (c *Caps) Copy() *Caps { return wrapCaps(C.gst_caps_copy(c.Instance())) }
 //   // true
 //
 func (c *Caps) FilterAndMapInPlace(f CapsMapFunc) {
-	ptr := gopointer.Save(f)
-	defer gopointer.Unref(ptr)
+	ptr := savePointer(f)
+	defer unrefPointer(ptr)
 	C.gst_caps_filter_and_map_in_place(
 		c.Instance(),
 		C.GstCapsFilterMapFunc(C.cgoCapsMapFunc),
@@ -219,7 +231,7 @@ func (c *Caps) FilterAndMapInPlace(f CapsMapFunc) {
 // returned caps will be the empty too and contain no structure at all.
 //
 // Calling this function with any caps is not allowed.
-func (c *Caps) Fixate() *Caps { return wrapCaps(C.gst_caps_fixate(c.Instance())) }
+func (c *Caps) Fixate() *Caps { return takeCaps(C.gst_caps_fixate(c.give())) }
 
 // ForEach calls the provided function once for each structure and caps feature in the GstCaps. The function must not
 // modify the fields. There is an unresolved bug in this function currently and it is better to use MapInPlace instead.
@@ -234,8 +246,8 @@ func (c *Caps) Fixate() *Caps { return wrapCaps(C.gst_caps_fixate(c.Instance()))
 //   // audio/x-raw;
 //
 func (c *Caps) ForEach(f CapsMapFunc) bool {
-	ptr := gopointer.Save(f)
-	defer gopointer.Unref(ptr)
+	ptr := savePointer(f)
+	defer unrefPointer(ptr)
 	return gobool(C.gst_caps_foreach(
 		c.Instance(),
 		C.GstCapsForeachFunc(C.cgoCapsMapFunc),
@@ -278,13 +290,13 @@ const (
 // Intersect creates a new Caps that contains all the formats that are common to both these caps and those given.
 // Defaults to CapsIntersectZigZag mode.
 func (c *Caps) Intersect(caps *Caps) *Caps {
-	return wrapCaps(C.gst_caps_intersect(c.Instance(), caps.Instance()))
+	return takeCaps(C.gst_caps_intersect(c.Instance(), caps.Instance()))
 }
 
 // IntersectFull creates a new Caps that contains all the formats that are common to both these caps those given.
 // The order is defined by the CapsIntersectMode used.
 func (c *Caps) IntersectFull(caps *Caps, mode CapsIntersectMode) *Caps {
-	return wrapCaps(C.gst_caps_intersect_full(c.Instance(), caps.Instance(), C.GstCapsIntersectMode(mode)))
+	return takeCaps(C.gst_caps_intersect_full(c.Instance(), caps.Instance(), C.GstCapsIntersectMode(mode)))
 }
 
 // IsAlwaysCompatible returns if this structure is always compatible with another if every media format that is in
@@ -343,8 +355,8 @@ func (c *Caps) IsWritable() bool {
 // In contrast to ForEach, the function may modify, but not delete, the structures and features.
 // The caps must be mutable.
 func (c *Caps) MapInPlace(f CapsMapFunc) bool {
-	ptr := gopointer.Save(f)
-	defer gopointer.Unref(ptr)
+	ptr := savePointer(f)
+	defer unrefPointer(ptr)
 	return gobool(C.gst_caps_map_in_place(
 		c.Instance(),
 		C.GstCapsMapFunc(C.cgoCapsMapFunc),
@@ -356,18 +368,18 @@ func (c *Caps) MapInPlace(f CapsMapFunc) bool {
 // The structures in the given caps are not copied -- they are transferred to a writable copy of these ones,
 // and then those given are freed. If either caps are ANY, the resulting caps will be ANY.
 func (c *Caps) Merge(caps *Caps) *Caps {
-	return wrapCaps(C.gst_caps_merge(c.Instance(), caps.Instance()))
+	return takeCaps(C.gst_caps_merge(c.give(), caps.give()))
 }
 
 // MergeStructure appends structure to caps if its not already expressed by caps.
 func (c *Caps) MergeStructure(structure *Structure) *Caps {
-	return wrapCaps(C.gst_caps_merge_structure(c.Instance(), structure.Instance()))
+	return takeCaps(C.gst_caps_merge_structure(c.give(), structure.Instance()))
 }
 
 // MergeStructureFull appends structure with features to the caps if its not already expressed.
 func (c *Caps) MergeStructureFull(structure *Structure, features *CapsFeatures) *Caps {
-	return wrapCaps(C.gst_caps_merge_structure_full(
-		c.Instance(), structure.Instance(), features.Instance(),
+	return takeCaps(C.gst_caps_merge_structure_full(
+		c.give(), structure.Instance(), features.Instance(),
 	))
 }
 
@@ -377,7 +389,7 @@ func (c *Caps) MergeStructureFull(structure *Structure, features *CapsFeatures)
 // This function takes ownership of caps and will call MakeWritable on it so you must not
 // use caps afterwards unless you keep an additional reference to it with Ref.
 func (c *Caps) Normalize() *Caps {
-	return wrapCaps(C.gst_caps_normalize(c.Instance()))
+	return takeCaps(C.gst_caps_normalize(c.give()))
 }
 
 // RemoveStructureAt removes the structure with the given index from the list of structures.
@@ -419,7 +431,7 @@ func (c *Caps) SetValue(field string, val interface{}) {
 //
 // This method does not preserve the original order of caps.
 func (c *Caps) Simplify() *Caps {
-	return wrapCaps(C.gst_caps_simplify(c.Instance()))
+	return takeCaps(C.gst_caps_simplify(c.give()))
 }
 
 // StealStructureAt retrieves the structure with the given index from the list of structures contained in caps.
@@ -430,7 +442,7 @@ func (c *Caps) StealStructureAt(idx uint) *Structure {
 
 // Subtract subtracts the given caps from these.
 func (c *Caps) Subtract(caps *Caps) *Caps {
-	return wrapCaps(C.gst_caps_subtract(c.Instance(), caps.Instance()))
+	return takeCaps(C.gst_caps_subtract(c.Instance(), caps.Instance()))
 }
 
 // Truncate discards all but the first structure from caps. Useful when fixating.
@@ -440,4 +452,4 @@ func (c *Caps) Subtract(caps *Caps) *Caps {
 //
 // Note that it is not guaranteed that the returned caps have exactly one structure. If caps is any or empty caps
 // then then returned caps will be the same and contain no structure at all.
-func (c *Caps) Truncate() *Caps { return wrapCaps(C.gst_caps_truncate(c.Instance())) }
+func (c *Caps) Truncate() *Caps { return takeCaps(C.gst_caps_truncate(c.give())) }
@@ -24,7 +24,6 @@ import (
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // ClockCallback is the prototype of a clock callback function.
@@ -99,7 +98,7 @@ func (c *ClockID) Wait() (ret ClockReturn, jitter ClockTimeDiff) {
 //
 //   // Single shot triggered at 1000000000
 func (c *ClockID) WaitAsync(f ClockCallback) ClockReturn {
-	ptr := gopointer.Save(f)
+	ptr := savePointer(f)
 	return ClockReturn(C.gst_clock_id_wait_async(
 		c.Instance(),
 		C.GstClockCallback(C.cgoClockCb),
@@ -234,7 +233,9 @@ func (c *Clock) GetMaster() *Clock {
 	if clock == nil {
 		return nil
 	}
-	return wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(clock))})
+	obj := wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(clock))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetResolution gets the accuracy of the clock. The accuracy of the clock is the granularity
@@ -6,7 +6,8 @@ import "unsafe"
 
 // Context wraps a GstContext object.
 type Context struct {
-	ptr *C.GstContext
+	ptr  *C.GstContext
+	refs refTracker
 }
 
 // NewContext creates a new context.
@@ -29,7 +30,7 @@ func NewContext(ctxType string, persistent bool) *Context {
 	if ctx == nil {
 		return nil
 	}
-	return wrapContext(ctx)
+	return takeContext(ctx)
 }
 
 // Instance returns the underlying GstContext instance.
@@ -89,7 +90,14 @@ func (c *Context) IsWritable() bool {
 
 // MakeWritable returns a writable version of the context.
 func (c *Context) MakeWritable() *Context {
-	return wrapContext(C.makeContextWritable(c.Instance()))
+	return takeContext(C.makeContextWritable(c.give()))
+}
+
+// MakeMut makes this context writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and c refers to the copy from then on.
+// Unlike MakeWritable, c remains valid after the call and must be released as usual.
+func (c *Context) MakeMut() {
+	c.ptr = C.toGstContext(unsafe.Pointer(makeMutMiniObject(c)))
 }
 
 // WritableStructure returns a writable version of the structure. You should still not unref it.
@@ -0,0 +1,319 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+extern gboolean goCustomMetaTransform     (GstBuffer * transBuf, GstMeta * meta, GstBuffer * buffer, GQuark type, gpointer data);
+extern void     goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+typedef struct _GstGoMeta {
+	GstMeta  meta;
+	gpointer value;
+} GstGoMeta;
+
+gboolean goMetaValueInit (GstMeta * meta, gpointer params, GstBuffer * buffer)
+{
+	((GstGoMeta *) meta)->value = NULL;
+	return TRUE;
+}
+
+void goMetaValueFree (GstMeta * meta, GstBuffer * buffer)
+{
+	GstGoMeta * goMeta = (GstGoMeta *) meta;
+	if (goMeta->value != NULL) {
+		goGDestroyNotifyFuncNoRun(goMeta->value);
+		goMeta->value = NULL;
+	}
+}
+
+gboolean cgoGoMetaTransform (GstBuffer * transBuf, GstMeta * meta, GstBuffer * buffer, GQuark type, gpointer data)
+{
+	return goCustomMetaTransform(transBuf, meta, buffer, type, data);
+}
+
+const GstMetaInfo * registerGoMeta (GType api, const gchar * name)
+{
+	return gst_meta_register(api, name, sizeof(GstGoMeta), goMetaValueInit, goMetaValueFree, cgoGoMetaTransform);
+}
+
+gpointer goMetaGetValue (GstMeta * meta)                 { return ((GstGoMeta *) meta)->value; }
+void     goMetaSetValue (GstMeta * meta, gpointer value) { ((GstGoMeta *) meta)->value = value; }
+
+#if GST_CHECK_VERSION(1, 20, 0)
+
+gboolean cgoCustomMetaTransform (GstBuffer * transBuf, GstCustomMeta * meta, GstBuffer * buffer, GQuark type, gpointer data, gpointer user_data)
+{
+	return goCustomMetaTransform(transBuf, (GstMeta *) meta, buffer, type, data);
+}
+
+const GstMetaInfo * registerCustomMeta (const gchar * name, const gchar ** tags)
+{
+	return gst_meta_register_custom(name, tags, cgoCustomMetaTransform, NULL, NULL);
+}
+
+GstMeta * addCustomMeta (GstBuffer * buffer, const gchar * name)
+{
+	return (GstMeta *) gst_buffer_add_custom_meta(buffer, name);
+}
+
+GstStructure * customMetaGetStructure (GstMeta * meta)
+{
+	return gst_custom_meta_get_structure((GstCustomMeta *) meta);
+}
+
+#else
+
+const GstMetaInfo * registerCustomMeta (const gchar * name, const gchar ** tags) { return NULL; }
+GstMeta *           addCustomMeta      (GstBuffer * buffer, const gchar * name) { return NULL; }
+GstStructure *      customMetaGetStructure (GstMeta * meta)                     { return NULL; }
+
+#endif
+
+gboolean copyStructureField (GQuark field, const GValue * value, gpointer dest)
+{
+	gst_structure_id_set_value((GstStructure *) dest, field, value);
+	return TRUE;
+}
+
+void copyStructureFields (GstStructure * src, GstStructure * dest)
+{
+	gst_structure_foreach(src, copyStructureField, dest);
+}
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+
+	gopointer "github.com/mattn/go-pointer"
+)
+
+// MetaTransformRules describe which transformations of a buffer a custom meta is carried through. When a
+// buffer is transformed in a way that is allowed by the rules, the meta is added to the new buffer with a
+// copy of its Structure, or the same go value. Otherwise it is dropped.
+type MetaTransformRules struct {
+	// Copy carries the meta through full copies of the buffer, such as when a queue or tee makes a buffer
+	// writable.
+	Copy bool
+	// Region carries the meta through copies of only a region of the buffer.
+	Region bool
+	// Scale carries the meta through video scaling transforms (MetaTransformScaleType), such as those done
+	// by videoscale and videoconvertscale.
+	Scale bool
+	// TransformFunc, if set, is called for every transform instead of applying the rules above.
+	TransformFunc CustomMetaTransformFunc
+}
+
+// CustomMetaTransformFunc is called for a custom meta on buf when buf is transformed into transBuf. data
+// is only set when mType is MetaTransformCopyType. Implementations wanting to carry the meta over add a
+// new one to transBuf with AddCustomMeta.
+type CustomMetaTransformFunc func(transBuf *Buffer, meta *CustomMeta, buf *Buffer, mType string, data *MetaTransformCopy) bool
+
+// CustomMeta is a meta registered by name with RegisterCustomMeta or RegisterGoMeta. Its payload is either
+// a Structure or a go value, depending on how it was registered.
+type CustomMeta struct {
+	*Meta
+	info *customMetaInfo
+}
+
+type customMetaInfo struct {
+	name    string
+	info    *C.GstMetaInfo
+	goValue bool
+	rules   MetaTransformRules
+}
+
+// customMetas holds the custom metas registered from go, by name and by their C info.
+var (
+	customMetas       = make(map[string]*customMetaInfo)
+	customMetasByInfo = make(map[*C.GstMetaInfo]*customMetaInfo)
+	customMetasMu     sync.RWMutex
+)
+
+// RegisterCustomMeta registers a meta with the given name and tags whose payload is a Structure, using
+// GstCustomMeta. The structure is created along with each meta and can be accessed with
+// CustomMeta.Structure. If rules is nil, the meta is dropped by all transformations.
+//
+// GstCustomMeta is only available since GStreamer 1.20. When built against older versions, nil is returned.
+//
+//   gst.RegisterCustomMeta("my-detections", nil, &gst.MetaTransformRules{Copy: true, Region: true})
+//
+//   meta := buf.AddCustomMeta("my-detections")
+//   meta.Structure().SetValue("count", 3)
+func RegisterCustomMeta(name string, tags []string, rules *MetaTransformRules) *MetaInfo {
+	return registerCustomMeta(name, tags, rules, false)
+}
+
+// RegisterGoMeta registers a meta with the given name and tags whose payload is a go value, which can be
+// set and retrieved with CustomMeta.SetValue and CustomMeta.Value. The value is released when the meta is
+// freed. Transformed buffers share the value with the original one, so it should not be modified once set.
+// If rules is nil, the meta is dropped by all transformations.
+//
+//   gst.RegisterGoMeta("my-frame-info", nil, &gst.MetaTransformRules{Copy: true, Scale: true})
+//
+//   buf.AddCustomMeta("my-frame-info").SetValue(&FrameInfo{Source: "camera-1"})
+//
+//   // further downstream
+//   if meta := buf.GetCustomMeta("my-frame-info"); meta != nil {
+//       info := meta.Value().(*FrameInfo)
+//   }
+func RegisterGoMeta(name string, tags []string, rules *MetaTransformRules) *MetaInfo {
+	return registerCustomMeta(name, tags, rules, true)
+}
+
+func registerCustomMeta(name string, tags []string, rules *MetaTransformRules, goValue bool) *MetaInfo {
+	customMetasMu.Lock()
+	defer customMetasMu.Unlock()
+	if existing, ok := customMetas[name]; ok {
+		if existing.goValue != goValue {
+			return nil
+		}
+		return wrapMetaInfo(existing.info)
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var info *C.GstMetaInfo
+	if goValue {
+		api := RegisterAPIType(name+"API", tags)
+		info = C.registerGoMeta(C.GType(api), (*C.gchar)(unsafe.Pointer(cName)))
+	} else {
+		cTags := newStrv(tags)
+		defer C.g_strfreev(cTags)
+		info = C.registerCustomMeta((*C.gchar)(unsafe.Pointer(cName)), (**C.gchar)(unsafe.Pointer(cTags)))
+	}
+	if info == nil {
+		return nil
+	}
+	meta := &customMetaInfo{name: name, info: info, goValue: goValue}
+	if rules != nil {
+		meta.rules = *rules
+	}
+	customMetas[name] = meta
+	customMetasByInfo[info] = meta
+	return wrapMetaInfo(info)
+}
+
+func getCustomMetaInfo(name string) *customMetaInfo {
+	customMetasMu.RLock()
+	defer customMetasMu.RUnlock()
+	return customMetas[name]
+}
+
+func getCustomMetaInfoFor(meta *C.GstMeta) *customMetaInfo {
+	customMetasMu.RLock()
+	defer customMetasMu.RUnlock()
+	return customMetasByInfo[meta.info]
+}
+
+// AddCustomMeta adds a new custom meta with the given name to the buffer. The meta must have been
+// registered with RegisterCustomMeta or RegisterGoMeta. nil is returned if it was not.
+func (b *Buffer) AddCustomMeta(name string) *CustomMeta {
+	info := getCustomMetaInfo(name)
+	if info == nil {
+		return nil
+	}
+	var meta *C.GstMeta
+	if info.goValue {
+		meta = C.gst_buffer_add_meta(b.Instance(), info.info, nil)
+	} else {
+		cName := C.CString(name)
+		defer C.free(unsafe.Pointer(cName))
+		meta = C.addCustomMeta(b.Instance(), (*C.gchar)(unsafe.Pointer(cName)))
+	}
+	if meta == nil {
+		return nil
+	}
+	return &CustomMeta{Meta: wrapMeta(meta), info: info}
+}
+
+// GetCustomMeta retrieves the custom meta with the given name from the buffer, or nil if there is none.
+func (b *Buffer) GetCustomMeta(name string) *CustomMeta {
+	info := getCustomMetaInfo(name)
+	if info == nil {
+		return nil
+	}
+	meta := C.gst_buffer_get_meta(b.Instance(), info.info.api)
+	if meta == nil {
+		return nil
+	}
+	return &CustomMeta{Meta: wrapMeta(meta), info: info}
+}
+
+// Name returns the name the meta was registered with.
+func (m *CustomMeta) Name() string { return m.info.name }
+
+// Structure returns the structure holding the payload of a meta registered with RegisterCustomMeta. The
+// structure is owned by the meta and can be modified as long as the buffer is writable. nil is returned
+// for metas registered with RegisterGoMeta.
+func (m *CustomMeta) Structure() *Structure {
+	if m.info.goValue {
+		return nil
+	}
+	st := C.customMetaGetStructure(m.Instance())
+	if st == nil {
+		return nil
+	}
+	return wrapStructure(st)
+}
+
+// Value returns the go value held by a meta registered with RegisterGoMeta, or nil if none was set.
+func (m *CustomMeta) Value() interface{} {
+	if !m.info.goValue {
+		return nil
+	}
+	ptr := C.goMetaGetValue(m.Instance())
+	if ptr == nil {
+		return nil
+	}
+	return gopointer.Restore(unsafe.Pointer(ptr))
+}
+
+// SetValue sets the go value held by a meta registered with RegisterGoMeta, releasing the previous one.
+// It does nothing for metas registered with RegisterCustomMeta.
+func (m *CustomMeta) SetValue(value interface{}) {
+	if !m.info.goValue {
+		return
+	}
+	if old := C.goMetaGetValue(m.Instance()); old != nil {
+		unrefPointer(unsafe.Pointer(old))
+	}
+	var ptr C.gpointer
+	if value != nil {
+		ptr = (C.gpointer)(savePointer(value))
+	}
+	C.goMetaSetValue(m.Instance(), ptr)
+}
+
+// transform applies the transform rules of the meta, or its TransformFunc, to carry it over to transBuf.
+func (m *CustomMeta) transform(transBuf, buf *Buffer, mType string, data *MetaTransformCopy) bool {
+	rules := m.info.rules
+	if rules.TransformFunc != nil {
+		return rules.TransformFunc(transBuf, m, buf, mType, data)
+	}
+	var keep bool
+	switch mType {
+	case MetaTransformCopyType:
+		keep = rules.Copy
+		if data != nil && data.Region {
+			keep = rules.Region
+		}
+	case MetaTransformScaleType:
+		keep = rules.Scale
+	}
+	if !keep {
+		return true
+	}
+	newMeta := transBuf.AddCustomMeta(m.info.name)
+	if newMeta == nil {
+		return false
+	}
+	if m.info.goValue {
+		newMeta.SetValue(m.Value())
+		return true
+	}
+	if src, dest := m.Structure(), newMeta.Structure(); src != nil && dest != nil {
+		C.copyStructureFields(src.Instance(), dest.Instance())
+	}
+	return true
+}
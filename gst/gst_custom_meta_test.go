@@ -0,0 +1,55 @@
+package gst
+
+import "testing"
+
+type testMetaValue struct{ source string }
+
+func TestCustomMetaTransformRules(t *testing.T) {
+	RegisterGoMeta("go-test-copy-meta", nil, &MetaTransformRules{Copy: true})
+	RegisterGoMeta("go-test-region-meta", nil, &MetaTransformRules{Region: true})
+	if RegisterCustomMeta("go-test-copy-structure-meta", nil, &MetaTransformRules{Copy: true}) == nil {
+		t.Skip("GstCustomMeta is not available before GStreamer 1.20")
+	}
+	RegisterCustomMeta("go-test-scale-structure-meta", nil, &MetaTransformRules{Scale: true})
+
+	before := LiveCallbacks()
+	value := &testMetaValue{source: "camera-1"}
+	buf := NewBufferFromBytes([]byte("go-gst-metas"))
+	buf.AddCustomMeta("go-test-copy-meta").SetValue(value)
+	buf.AddCustomMeta("go-test-region-meta").SetValue(value)
+	buf.AddCustomMeta("go-test-copy-structure-meta").Structure().SetValue("count", "three")
+	buf.AddCustomMeta("go-test-scale-structure-meta").Structure().SetValue("count", "four")
+
+	hasMeta := func(buf *Buffer, name string) bool { return buf.GetCustomMeta(name) != nil }
+
+	// A full copy carries the metas with the Copy rule.
+	copied := buf.Copy()
+	if meta := copied.GetCustomMeta("go-test-copy-meta"); meta == nil || meta.Value() != value {
+		t.Errorf("Expected the go value meta to be copied with its value, got %v", meta)
+	}
+	if meta := copied.GetCustomMeta("go-test-copy-structure-meta"); meta == nil {
+		t.Error("Expected the structure meta to be copied")
+	} else if count, err := meta.Structure().GetValue("count"); err != nil || count != "three" {
+		t.Errorf("Expected the structure to be copied, got %v (%v)", count, err)
+	}
+	if hasMeta(copied, "go-test-region-meta") || hasMeta(copied, "go-test-scale-structure-meta") {
+		t.Error("Expected the metas without the Copy rule to be dropped by a copy")
+	}
+
+	// A copy of a region only carries the metas with the Region rule.
+	region := buf.CopyRegion(BufferCopyBufferFlags|BufferCopyTimestamps|BufferCopyMeta|BufferCopyMemory, 3, 3)
+	if meta := region.GetCustomMeta("go-test-region-meta"); meta == nil || meta.Value() != value {
+		t.Errorf("Expected the go value meta to be carried to the region with its value, got %v", meta)
+	}
+	if hasMeta(region, "go-test-copy-meta") || hasMeta(region, "go-test-copy-structure-meta") || hasMeta(region, "go-test-scale-structure-meta") {
+		t.Error("Expected the metas without the Region rule to be dropped by a region copy")
+	}
+
+	// The go values are released with the last meta holding them.
+	buf.Unref()
+	copied.Unref()
+	region.Unref()
+	if err := CheckCallbackLeaks(before, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
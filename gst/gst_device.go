@@ -26,12 +26,14 @@ func (d *Device) CreateElement(name string) *Element {
 		defer C.free(unsafe.Pointer(cName))
 	}
 	elem := C.gst_device_create_element(d.Instance(), cName)
-	return wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))})
+	obj := wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Caps returns the caps that this device supports. Unref after usage.
 func (d *Device) Caps() *Caps {
-	return wrapCaps(C.gst_device_get_caps(d.Instance()))
+	return takeCaps(C.gst_device_get_caps(d.Instance()))
 }
 
 // DeviceClass gets the "class" of a device. This is a "/" separated list of classes that
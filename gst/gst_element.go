@@ -16,6 +16,37 @@ void cgoElementCallAsync (GstElement * element, gpointer user_data)
 	goElementCallAsync(element, user_data);
 }
 
+GValue * allocSignalValues (guint n) { return g_new0(GValue, n); }
+GValue * signalValueAt     (GValue * values, guint idx) { return &values[idx]; }
+
+void freeSignalValues (GValue * values, guint n)
+{
+	for (guint i = 0; i < n; i++) {
+		if (G_IS_VALUE(&values[i]))
+			g_value_unset(&values[i]);
+	}
+	g_free(values);
+}
+
+void setSignalInstance (GValue * value, gpointer instance)
+{
+	g_value_init(value, G_TYPE_FROM_INSTANCE(instance));
+	g_value_set_object(value, instance);
+}
+
+void copySignalValue (GValue * dest, const GValue * src)
+{
+	g_value_init(dest, G_VALUE_TYPE(src));
+	g_value_copy(src, dest);
+}
+
+GType signalReturnType (guint id)
+{
+	GSignalQuery query;
+	g_signal_query(id, &query);
+	return query.return_type & ~G_SIGNAL_TYPE_STATIC_SCOPE;
+}
+
 */
 import "C"
 
@@ -24,7 +55,6 @@ import (
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // Element is a Go wrapper around a GstElement.
@@ -72,7 +102,9 @@ func (e *Element) GetBus() *Bus {
 	if bus == nil {
 		return nil
 	}
-	return wrapBus(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(bus))})
+	obj := wrapBus(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(bus))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetClock returns the Clock for this element. This is the clock as was last set with gst_element_set_clock.
@@ -82,7 +114,9 @@ func (e *Element) GetClock() *Clock {
 	if cClock == nil {
 		return nil
 	}
-	return wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(cClock))})
+	obj := wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(cClock))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetState returns the current state of this element.
@@ -93,6 +127,9 @@ func (e *Element) GetState() State {
 // SetState sets the target state for this element.
 func (e *Element) SetState(state State) error {
 	stateRet := C.gst_element_set_state((*C.GstElement)(e.Instance()), C.GstState(state))
+	if state == StateNull {
+		closeFlushedBusSubscriptions(e.Instance())
+	}
 	if stateRet == C.GST_STATE_CHANGE_FAILURE {
 		return fmt.Errorf("Failed to change state to %s", state.String())
 	}
@@ -100,7 +137,8 @@ func (e *Element) SetState(state State) error {
 }
 
 // BlockSetState is like SetState except it will block until the transition
-// is complete.
+// is complete. If the transition never completes (e.g. a sink that never prerolls),
+// this function will block forever. Use SetStateContext to bound the wait.
 func (e *Element) BlockSetState(state State) error {
 	if err := e.SetState(state); err != nil {
 		return err
@@ -145,7 +183,9 @@ func (e *Element) GetStaticPad(name string) *Pad {
 	if pad == nil {
 		return nil
 	}
-	return wrapPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetPadTemplates retrieves a list of the pad templates associated with this element.
@@ -204,6 +244,15 @@ func (e *Element) TagSetter() TagSetter {
 	return &gstTagSetter{ptr: e.Instance()}
 }
 
+// Preset returns a Preset interface if implemented by this element. Otherwise it returns nil.
+// Elements such as encoders commonly implement this interface to ship tuned property sets.
+func (e *Element) Preset() Preset {
+	if !gobool(C.gstElementIsPreset(e.Instance())) {
+		return nil
+	}
+	return &gstPreset{ptr: e.Instance()}
+}
+
 // Query performs a query on the given element.
 //
 // For elements that don't implement a query handler, this function forwards the query to a random srcpad or
@@ -250,7 +299,30 @@ func (e *Element) QueryPosition(format Format) (bool, int64) {
 // This function takes ownership of the provided event so you should gst_event_ref it if you want to reuse the event
 // after this call.
 func (e *Element) SendEvent(ev *Event) bool {
-	return gobool(C.gst_element_send_event(e.Instance(), ev.Instance()))
+	return gobool(C.gst_element_send_event(e.Instance(), ev.give()))
+}
+
+// SignalHandle identifies a signal handler connected with ConnectHandle.
+type SignalHandle struct {
+	object *weakRef
+	id     glib.SignalHandle
+}
+
+// ID returns the numeric ID of the handler, as returned from Connect.
+func (h *SignalHandle) ID() glib.SignalHandle { return h.id }
+
+// Disconnect disconnects the handler. The go function is released once the handler is disconnected, and
+// will not be called again. It is safe to call this function more than once, and after the object the
+// handler was connected to has been finalized.
+func (h *SignalHandle) Disconnect() {
+	object := h.object.get()
+	if object == nil {
+		return
+	}
+	defer C.g_object_unref(object)
+	if gobool(C.g_signal_handler_is_connected(object, C.gulong(h.id))) {
+		C.g_signal_handler_disconnect(object, C.gulong(h.id))
+	}
 }
 
 // Connect connects to the given signal on this element, and applies f as the callback. The callback must
@@ -267,10 +339,34 @@ func (e *Element) Connect(signal string, f interface{}) (glib.SignalHandle, erro
 	return e.Object.Connect(signal, f, nil)
 }
 
+// ConnectHandle is like Connect, but returns a handle that disconnects the handler. Unlike the ID returned
+// from Connect, the handle can still be used after the element has been finalized. Handlers that are never
+// disconnected are released when the element is finalized.
+func (e *Element) ConnectHandle(signal string, f interface{}) (*SignalHandle, error) {
+	id, err := e.Connect(signal, f)
+	if err != nil {
+		return nil, err
+	}
+	return &SignalHandle{object: newWeakRef(e.Unsafe()), id: id}, nil
+}
+
+// Disconnect disconnects a handler previously connected with Connect. The go function is released once
+// the handler is disconnected, and will not be called again. Handlers that are never disconnected are
+// released when the element is finalized.
+func (e *Element) Disconnect(handle glib.SignalHandle) {
+	e.Object.HandlerDisconnect(handle)
+}
+
 // Emit is a wrapper around g_signal_emitv() and emits the signal specified by the string s to an Object. Arguments to
 // callback functions connected to this signal must be specified in args. Emit() returns an interface{} which must be
 // type asserted as the Go equivalent type to the return value for native C callback.
 //
+// Arguments can also be gst types passed by value to signals, such as a *Promise, *Caps or *Structure, e.g. for
+// webrtcbin's create-offer signal:
+//
+//   promise := gst.NewPromise()
+//   webrtcbin.Emit("create-offer", nil, promise)
+//
 // Note that this code is unsafe in that the types of values in args are not checked against whether they are suitable
 // for the callback.
 func (e *Element) Emit(signal string, args ...interface{}) (interface{}, error) {
@@ -278,9 +374,63 @@ func (e *Element) Emit(signal string, args ...interface{}) (interface{}, error)
 	if e.TypeFromInstance() != glib.Type(C.GST_TYPE_ELEMENT) {
 		glib.RegisterGValueMarshalers([]glib.TypeMarshaler{{T: e.TypeFromInstance(), F: marshalElement}})
 	}
+	for _, arg := range args {
+		switch arg.(type) {
+		case *Promise, *Caps, *Structure:
+			return e.emitBoxed(signal, args...)
+		}
+	}
 	return e.Object.Emit(signal, args...)
 }
 
+// emitBoxed is like Emit, except it also converts the gst boxed types in args to GValues, which the glib
+// bindings do not know about.
+func (e *Element) emitBoxed(signal string, args ...interface{}) (interface{}, error) {
+	cSignal := C.CString(signal)
+	defer C.free(unsafe.Pointer(cSignal))
+	id := C.g_signal_lookup((*C.gchar)(unsafe.Pointer(cSignal)), C.GType(e.TypeFromInstance()))
+	if id == 0 {
+		return nil, fmt.Errorf("Signal %s does not exist on %s", signal, e.TypeFromInstance().Name())
+	}
+
+	nValues := C.guint(len(args) + 1)
+	values := C.allocSignalValues(nValues)
+	defer C.freeSignalValues(values, nValues)
+
+	C.setSignalInstance(C.signalValueAt(values, 0), (C.gpointer)(e.Unsafe()))
+	for i, arg := range args {
+		value := C.signalValueAt(values, C.guint(i+1))
+		switch arg := arg.(type) {
+		case *Promise:
+			C.g_value_init(value, C.GST_TYPE_PROMISE)
+			C.g_value_set_boxed(value, (C.gconstpointer)(unsafe.Pointer(arg.Instance())))
+		case *Caps:
+			C.g_value_init(value, C.GST_TYPE_CAPS)
+			C.g_value_set_boxed(value, (C.gconstpointer)(unsafe.Pointer(arg.Instance())))
+		case *Structure:
+			C.g_value_init(value, C.GST_TYPE_STRUCTURE)
+			C.g_value_set_boxed(value, (C.gconstpointer)(unsafe.Pointer(arg.Instance())))
+		default:
+			gval, err := glib.GValue(arg)
+			if err != nil {
+				return nil, fmt.Errorf("Error converting arg %d to GValue: %s", i, err.Error())
+			}
+			C.copySignalValue(value, (*C.GValue)(gval.Native()))
+		}
+	}
+
+	retType := C.signalReturnType(id)
+	if retType == C.G_TYPE_NONE {
+		C.g_signal_emitv(values, id, 0, nil)
+		return nil, nil
+	}
+	var ret C.GValue
+	C.g_value_init(&ret, retType)
+	defer C.g_value_unset(&ret)
+	C.g_signal_emitv(values, id, 0, &ret)
+	return glib.ValueFromNative(unsafe.Pointer(&ret)).GoValue()
+}
+
 // SyncStateWithParent tries to change the state of the element to the same as its parent. If this function returns
 // FALSE, the state of element is undefined.
 func (e *Element) SyncStateWithParent() bool {
@@ -289,6 +439,9 @@ func (e *Element) SyncStateWithParent() bool {
 
 // AbortState aborts the state change of the element. This function is used by elements that do asynchronous state changes
 // and find out something is wrong.
+//
+// Aborting only marks the pending state change as failed and wakes up anyone waiting on it. It does not stop the element
+// from working towards the target state, so the element may still complete the state change afterwards.
 func (e *Element) AbortState() { C.gst_element_abort_state(e.Instance()) }
 
 // AddPad adds a pad (link point) to element. pad's parent will be set to element
@@ -307,8 +460,10 @@ func (e *Element) AddPad(pad *Pad) bool {
 //
 // Calling those functions directly from the streaming thread will cause deadlocks in many situations, as they might involve waiting
 // for the streaming thread to shut down from this very streaming thread.
+//
+// f is released after it has been called, or when the element is finalized before it could be.
 func (e *Element) CallAsync(f func()) {
-	ptr := gopointer.Save(f)
+	ptr := savePointer(f)
 	C.gst_element_call_async(
 		e.Instance(),
 		C.GstElementCallAsyncFunc(C.cgoElementCallAsync),
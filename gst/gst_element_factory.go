@@ -22,7 +22,9 @@ func NewElement(name string) (*Element, error) {
 	if elem == nil {
 		return nil, fmt.Errorf("Could not create element: %s", name)
 	}
-	return wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))}), nil
+	obj := wrapElement(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(elem))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
 }
 
 // NewElementMany is a convenience wrapper around building many GstElements in a
@@ -51,7 +53,9 @@ func Find(name string) *ElementFactory {
 	if factory == nil {
 		return nil
 	}
-	return wrapElementFactory(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(factory))})
+	obj := wrapElementFactory(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(factory))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the C GstFactory instance
@@ -86,6 +90,18 @@ func (e *ElementFactory) GetMetadata(key string) string {
 	return C.GoString(res)
 }
 
+// ElementMetadata is the metadata of an element class, as returned by GetMetadata on its factory.
+type ElementMetadata struct {
+	// LongName is the long, english name of the element, e.g. "Vorbis audio encoder".
+	LongName string
+	// Klass describes the type of the element, e.g. "Codec/Encoder/Audio".
+	Klass string
+	// Description is a sentence describing the purpose of the element.
+	Description string
+	// Author is the name and contact details of the author(s) of the element.
+	Author string
+}
+
 // GetMetadataKeys gets the available keys for the metadata on this factory.
 func (e *ElementFactory) GetMetadataKeys() []string {
 	keys := C.gst_element_factory_get_metadata_keys((*C.GstElementFactory)(e.Instance()))
@@ -96,3 +112,54 @@ func (e *ElementFactory) GetMetadataKeys() []string {
 	size := C.sizeOfGCharArray(keys)
 	return goStrings(size, keys)
 }
+
+// ListElementFactories returns the element factories of the given type with at least the given rank,
+// sorted by rank from highest to lowest. Unref each factory after usage.
+//
+//   // all the video encoders that can produce h264
+//   encoders := gst.FilterElementFactories(
+//       gst.ListElementFactories(gst.ElementFactoryTypeVideoEncoder, gst.RankMarginal),
+//       gst.NewCapsFromString("video/x-h264"),
+//       gst.PadSource,
+//       false,
+//   )
+func ListElementFactories(listType ElementFactoryListType, minRank Rank) []*ElementFactory {
+	glist := C.gst_element_factory_list_get_elements(C.GstElementFactoryListType(listType), C.GstRank(minRank))
+	glist = C.g_list_sort(glist, C.GCompareFunc(C.gst_plugin_feature_rank_compare_func))
+	return takeElementFactoryList(glist)
+}
+
+// FilterElementFactories returns the factories that have a pad template in the given direction that can
+// handle the given caps. If subsetOnly is true, the caps of the pad template must be a superset of caps,
+// otherwise it only needs to intersect with them. The order of the factories is preserved. Unref each
+// returned factory after usage.
+func FilterElementFactories(factories []*ElementFactory, caps *Caps, direction PadDirection, subsetOnly bool) []*ElementFactory {
+	var glist *C.GList
+	for _, factory := range factories {
+		glist = C.g_list_prepend(glist, (C.gpointer)(unsafe.Pointer(factory.Instance())))
+	}
+	glist = C.g_list_reverse(glist)
+	defer C.g_list_free(glist)
+	filtered := C.gst_element_factory_list_filter(glist, caps.Instance(), C.GstPadDirection(direction), gboolean(subsetOnly))
+	return takeElementFactoryList(filtered)
+}
+
+// ListIsType returns true if the factory is of the given type.
+func (e *ElementFactory) ListIsType(listType ElementFactoryListType) bool {
+	return gobool(C.gst_element_factory_list_is_type(e.Instance(), C.GstElementFactoryListType(listType)))
+}
+
+// takeElementFactoryList wraps the factories in the given list, taking their references, and frees the list.
+func takeElementFactoryList(glist *C.GList) []*ElementFactory {
+	if glist == nil {
+		return nil
+	}
+	defer C.g_list_free(glist)
+	out := make([]*ElementFactory, 0)
+	for l := glist; l != nil; l = l.next {
+		obj := wrapElementFactory(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(l.data))})
+		adoptObject(obj.BaseObject())
+		out = append(out, obj)
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package gst
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+func registerTestEncoder(t *testing.T, name string, rank Rank, srcCaps string) {
+	metadata := &ElementMetadata{
+		LongName:    name,
+		Klass:       "Codec/Encoder/Video",
+		Description: "Encodes nothing",
+		Author:      "go-gst",
+	}
+	err := RegisterGoElementUnsafe(nil, name, rank, glib.TypeFromName("GstElement"), metadata,
+		NewCapsFromString("video/x-raw"), NewCapsFromString(srcCaps), struct{}{}, func(unsafe.Pointer) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testFactoryNames returns the names of the factories registered by the test, in order, and unrefs
+// all of them.
+func testFactoryNames(factories []*ElementFactory) []string {
+	var names []string
+	for _, factory := range factories {
+		switch name := factory.Name(); name {
+		case "gotestenca", "gotestencb", "gotestencvp8":
+			names = append(names, name)
+		}
+		factory.Unref()
+	}
+	return names
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestListAndFilterElementFactories(t *testing.T) {
+	registerTestEncoder(t, "gotestenca", RankSecondary, "video/x-h264")
+	registerTestEncoder(t, "gotestencb", RankPrimary, "video/x-h264")
+	registerTestEncoder(t, "gotestencvp8", RankPrimary, "video/x-vp8")
+
+	factories := ListElementFactories(ElementFactoryTypeVideoEncoder, RankNone)
+	for i := 1; i < len(factories); i++ {
+		prev, cur := factories[i-1], factories[i]
+		if prev.GetRank() < cur.GetRank() || (prev.GetRank() == cur.GetRank() && prev.Name() > cur.Name()) {
+			t.Errorf("Expected the factories to be sorted by rank and name, got %s (%d) before %s (%d)",
+				prev.Name(), prev.GetRank(), cur.Name(), cur.GetRank())
+		}
+	}
+
+	// Only the h264 encoders can output h264, and their order is kept.
+	filtered := FilterElementFactories(factories, NewCapsFromString("video/x-h264"), PadSource, false)
+	if names := testFactoryNames(filtered); !equalNames(names, []string{"gotestencb", "gotestenca"}) {
+		t.Errorf("Unexpected filtered factories: %v", names)
+	}
+	if names := testFactoryNames(factories); !equalNames(names, []string{"gotestencb", "gotestencvp8", "gotestenca"}) {
+		t.Errorf("Unexpected listed factories: %v", names)
+	}
+
+	// Factories below the minimum rank are left out.
+	if names := testFactoryNames(ListElementFactories(ElementFactoryTypeVideoEncoder, RankPrimary)); !equalNames(names, []string{"gotestencb", "gotestencvp8"}) {
+		t.Errorf("Unexpected factories of at least RankPrimary: %v", names)
+	}
+
+	// A rank override moves the factory up.
+	feature, err := GetRegistry().LookupFeature("gotestenca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	feature.SetRank(RankPrimary + 1)
+	feature.Unref()
+	if names := testFactoryNames(ListElementFactories(ElementFactoryTypeVideoEncoder, RankNone)); !equalNames(names, []string{"gotestenca", "gotestencb", "gotestencvp8"}) {
+		t.Errorf("Unexpected factories after the rank override: %v", names)
+	}
+}
@@ -0,0 +1,199 @@
+package gst
+
+// #include "gst.go.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// stateChangePollInterval is the interval at which SetStateContext re-checks the state of an
+// element while waiting on an asynchronous state change. Elements without a bus rely on this
+// entirely.
+var stateChangePollInterval = 100 * time.Millisecond
+
+// StateChangeError is returned from SetStateContext when a state change could not be completed.
+// It identifies the element responsible for the failure when one was reported on the bus.
+type StateChangeError struct {
+	// Element is the name of the element that caused the failure. When an error message was
+	// posted on the bus this is the source of that message, otherwise it is the name of the
+	// element whose state was being changed.
+	Element string
+	// From is the state the element was in when the state change was started.
+	From State
+	// To is the state that was requested.
+	To State
+	// Return is the last StateChangeReturn observed for the state change.
+	Return StateChangeReturn
+	// Err is the underlying cause. This is a *GError when an error was posted on the bus,
+	// or the context error when the state change was cancelled.
+	Err error
+}
+
+// Error implements the error interface.
+func (s *StateChangeError) Error() string {
+	if s.Err != nil {
+		return fmt.Sprintf("Failed to change state of %s from %s to %s: %s", s.Element, s.From.String(), s.To.String(), s.Err.Error())
+	}
+	return fmt.Sprintf("Failed to change state of %s from %s to %s", s.Element, s.From.String(), s.To.String())
+}
+
+// Unwrap returns the underlying cause of the failure, if any.
+func (s *StateChangeError) Unwrap() error { return s.Err }
+
+// GetStateTimeout gets the state of the element, waiting up to timeout for any asynchronous state
+// change to complete. A negative timeout waits forever, and a timeout of zero returns immediately.
+//
+// For elements that did not complete an asynchronous state change, the returned StateChangeReturn
+// is StateChangeAsync and pending holds the state the element is transitioning to. StateChangeSuccess
+// is returned when the element has no pending state change, and StateChangeNoPreroll when it is live.
+// StateChangeFailure is returned if the element failed its last state change.
+func (e *Element) GetStateTimeout(timeout time.Duration) (current, pending State, ret StateChangeReturn) {
+	var gcurrent, gpending C.GstState
+	gret := C.gst_element_get_state(
+		e.Instance(),
+		&gcurrent, &gpending,
+		C.GstClockTime(durationToClockTime(timeout)),
+	)
+	return State(gcurrent), State(gpending), StateChangeReturn(gret)
+}
+
+// SetStateContext sets the state of the element and waits for the state change to complete.
+//
+// Unlike BlockSetState, this function watches the element's bus (when it has one) for an ASYNC_DONE
+// message from the element, or an ERROR message from the element or any of its children. If an error
+// is posted, a *StateChangeError is returned naming the element that posted it. If ctx is cancelled or
+// its deadline is exceeded before the state is reached, the state change is aborted and a *StateChangeError
+// wrapping the context error is returned. Aborting only marks the pending state change as failed, the element
+// may still complete it later on, so use GetStateTimeout to find out which state it ended up in.
+//
+// The bus is observed via the "sync-message" signal, so messages are not removed from it and any other
+// watches installed on the bus will still receive them.
+func (e *Element) SetStateContext(ctx context.Context, state State) error {
+	from := e.GetState()
+	if err := ctx.Err(); err != nil {
+		return &StateChangeError{Element: e.Name(), From: from, To: state, Return: StateChangeFailure, Err: err}
+	}
+
+	// The watch is installed before the state change is started so an early ASYNC_DONE or ERROR
+	// cannot be missed.
+	watcher := newStateChangeWatcher(e)
+	defer watcher.stop()
+
+	ret := StateChangeReturn(C.gst_element_set_state(e.Instance(), C.GstState(state)))
+	if state == StateNull {
+		closeFlushedBusSubscriptions(e.Instance())
+	}
+	switch ret {
+	case StateChangeSuccess, StateChangeNoPreroll:
+		return nil
+	case StateChangeFailure:
+		return watcher.failure(from, state, ret)
+	}
+
+	ticker := time.NewTicker(stateChangePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.AbortState()
+			return &StateChangeError{Element: e.Name(), From: from, To: state, Return: StateChangeAsync, Err: ctx.Err()}
+		case err := <-watcher.errors:
+			err.From, err.To = from, state
+			return err
+		case <-watcher.asyncDone:
+			// The async-done message is posted right before the state is committed, so wait
+			// for it to be applied.
+			if _, _, ret = e.GetStateTimeout(stateChangePollInterval); ret != StateChangeAsync {
+				if ret == StateChangeFailure {
+					return watcher.failure(from, state, ret)
+				}
+				return nil
+			}
+		case <-ticker.C:
+			if _, _, ret = e.GetStateTimeout(0); ret != StateChangeAsync {
+				if ret == StateChangeFailure {
+					return watcher.failure(from, state, ret)
+				}
+				return nil
+			}
+		}
+	}
+}
+
+// stateChangeWatcher observes the bus of an element for messages relevant to a state change.
+// All channels are nil when the element has no bus.
+type stateChangeWatcher struct {
+	elem      *Element
+	bus       *Bus
+	handle    glib.SignalHandle
+	errors    chan *StateChangeError
+	asyncDone chan struct{}
+}
+
+func newStateChangeWatcher(elem *Element) *stateChangeWatcher {
+	watcher := &stateChangeWatcher{elem: elem}
+	bus := elem.GetBus()
+	if bus == nil {
+		return watcher
+	}
+	watcher.errors = make(chan *StateChangeError, 1)
+	watcher.asyncDone = make(chan struct{}, 1)
+	bus.EnableSyncMessageEmission()
+	handle, err := bus.Connect("sync-message", func(_ *Bus, msg *Message) {
+		switch msg.Type() {
+		case MessageError:
+			// Errors from siblings of the element belong to other state changes.
+			if !gobool(C.gst_object_has_as_ancestor(msg.Instance().src, (*C.GstObject)(elem.Unsafe()))) {
+				return
+			}
+			select {
+			case watcher.errors <- &StateChangeError{Element: msg.Source(), Return: StateChangeFailure, Err: msg.ParseError()}:
+			default:
+			}
+		case MessageAsyncDone:
+			if unsafe.Pointer(msg.Instance().src) != elem.Unsafe() {
+				return
+			}
+			select {
+			case watcher.asyncDone <- struct{}{}:
+			default:
+			}
+		}
+	})
+	if err != nil {
+		bus.DisableSyncMessageEmission()
+		bus.Unref()
+		return &stateChangeWatcher{elem: elem}
+	}
+	watcher.bus = bus
+	watcher.handle = handle
+	return watcher
+}
+
+// failure builds the error for a state change that returned StateChangeFailure, preferring any
+// error that was posted on the bus.
+func (s *stateChangeWatcher) failure(from, to State, ret StateChangeReturn) *StateChangeError {
+	select {
+	case err := <-s.errors:
+		err.From, err.To, err.Return = from, to, ret
+		return err
+	default:
+	}
+	return &StateChangeError{Element: s.elem.Name(), From: from, To: to, Return: ret}
+}
+
+func (s *stateChangeWatcher) stop() {
+	if s.bus == nil {
+		return
+	}
+	s.bus.HandlerDisconnect(s.handle)
+	s.bus.DisableSyncMessageEmission()
+	s.bus.Unref()
+}
@@ -0,0 +1,47 @@
+package gst
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetStateContextIgnoresSiblingErrors(t *testing.T) {
+	pipeline, err := NewPipeline("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeline.Destroy()
+	elems, err := NewElementMany("fakesink", "fakesink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink, sibling := elems[0], elems[1]
+	if err := pipeline.AddMany(sink, sibling); err != nil {
+		t.Fatal(err)
+	}
+
+	postError := func(src *Element) {
+		time.Sleep(100 * time.Millisecond)
+		src.GetBus().Post(NewErrorMessage(src, NewGError(1, errors.New("failure")), "", nil))
+	}
+
+	// The sink never prerolls, so only an error from itself ends the state change early.
+	go postError(sibling)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = sink.SetStateContext(ctx, StatePaused)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the error of the sibling to be ignored, got %v", err)
+	}
+
+	go postError(sink)
+	ctx, cancel = context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	err = sink.SetStateContext(ctx, StatePaused)
+	var stateErr *StateChangeError
+	if !errors.As(err, &stateErr) || stateErr.Element != sink.Name() || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the error of the sink to fail the state change, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package gst
+
+import "testing"
+
+func TestElementConnectAndConnectHandle(t *testing.T) {
+	elem, err := NewElement("identity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var byID, byHandle int
+	id, err := elem.Connect("no-more-pads", func(*Element) { byID++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	handle, err := elem.ConnectHandle("no-more-pads", func(*Element) { byHandle++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handle.ID() == id {
+		t.Fatal("Expected the handlers to have different IDs")
+	}
+
+	elem.Emit("no-more-pads")
+	elem.Disconnect(id)
+	handle.Disconnect()
+	elem.Emit("no-more-pads")
+	if byID != 1 || byHandle != 1 {
+		t.Errorf("Expected each handler to be called once before being disconnected, got %d and %d", byID, byHandle)
+	}
+
+	elem.Unref()
+	// The element is gone, disconnecting again must not touch it.
+	handle.Disconnect()
+}
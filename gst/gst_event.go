@@ -12,9 +12,19 @@ import (
 
 // Event is a go wrapper around a GstEvent.
 type Event struct {
-	ptr *C.GstEvent
+	ptr  *C.GstEvent
+	refs refTracker
 }
 
+// FromGstEventUnsafe wraps the pointer to the given C GstEvent with the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstEventUnsafe(ptr unsafe.Pointer) *Event { return wrapEvent(C.toGstEvent(ptr)) }
+
+// FromGstEventUnsafeFull wraps the pointer to the given C GstEvent with the go type, taking ownership
+// of the reference the caller holds on it (transfer full).
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstEventUnsafeFull(ptr unsafe.Pointer) *Event { return takeEvent(C.toGstEvent(ptr)) }
+
 // Instance returns the underlying GstEvent instance.
 func (e *Event) Instance() *C.GstEvent { return C.toGstEvent(unsafe.Pointer(e.ptr)) }
 
@@ -33,7 +43,14 @@ func (e *Event) Seqnum() uint32 {
 }
 
 // Copy copies the event using the event specific copy function.
-func (e *Event) Copy() *Event { return wrapEvent(C.gst_event_copy(e.Instance())) }
+func (e *Event) Copy() *Event { return takeEvent(C.gst_event_copy(e.Instance())) }
+
+// MakeMut makes this event writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and e refers to the copy from then on.
+// Unlike MakeWritable, e remains valid after the call and must be released as usual.
+func (e *Event) MakeMut() {
+	e.ptr = C.toGstEvent(unsafe.Pointer(makeMutMiniObject(e)))
+}
 
 // CopySegment parses a segment event and copies the Segment into the location given by segment.
 func (e *Event) CopySegment(segment *Segment) {
@@ -214,7 +231,7 @@ func (e *Event) ParseSelectStreams() []*Stream {
 func (e *Event) ParseSinkMessage() *Message {
 	var msg *C.GstMessage
 	C.gst_event_parse_sink_message(e.Instance(), &msg)
-	return wrapMessage(msg)
+	return takeMessage(msg)
 }
 
 // ParseStep parses a step message
@@ -231,14 +248,18 @@ func (e *Event) ParseStep() (format Format, amount uint64, rate float64, flush,
 func (e *Event) ParseStream() *Stream {
 	var stream *C.GstStream
 	C.gst_event_parse_stream(e.Instance(), &stream)
-	return wrapStream(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	obj := wrapStream(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // ParseStreamCollection parses a stream collection from the event.
 func (e *Event) ParseStreamCollection() *StreamCollection {
 	stream := &C.GstStreamCollection{}
 	C.gst_event_parse_stream_collection(e.Instance(), &stream)
-	return wrapStreamCollection(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	obj := wrapStreamCollection(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // ParseStreamFlags parses the stream flags from an event.
@@ -275,7 +296,7 @@ func (e *Event) ParseTOC() (toc *TOC, updated bool) {
 	var out *C.GstToc
 	var gupdated C.gboolean
 	C.gst_event_parse_toc(e.Instance(), &out, &gupdated)
-	return wrapTOC(out), gobool(gupdated)
+	return takeTOC(out), gobool(gupdated)
 }
 
 // ParseTOCSelect parses a TOC select event and store the results in the given uid location.
@@ -288,7 +309,7 @@ func (e *Event) ParseTOCSelect() string {
 
 // Ref increases the ref count on the event by one.
 func (e *Event) Ref() *Event {
-	C.gst_event_ref(e.Instance())
+	refMiniObject(e)
 	return e
 }
 
@@ -342,7 +363,7 @@ func (e *Event) SetStreamFlags(flags StreamFlags) {
 }
 
 // Unref decreases the refcount of an event, freeing it if the refcount reaches 0.
-func (e *Event) Unref() { C.gst_event_unref(e.Instance()) }
+func (e *Event) Unref() { unrefMiniObject(e) }
 
 // WritableStructure returns a writable version of the structure.
 func (e *Event) WritableStructure() *Structure {
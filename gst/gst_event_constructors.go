@@ -13,7 +13,7 @@ import (
 //
 // When the async flag is set, a thread boundary is preferred.
 func NewBufferSizeEvent(format Format, minSize, maxSize int64, async bool) *Event {
-	return wrapEvent(C.gst_event_new_buffer_size(
+	return takeEvent(C.gst_event_new_buffer_size(
 		C.GstFormat(format),
 		C.gint64(minSize),
 		C.gint64(maxSize),
@@ -24,7 +24,7 @@ func NewBufferSizeEvent(format Format, minSize, maxSize int64, async bool) *Even
 // NewCapsEvent creates a new CAPS event for caps. The caps event can only travel downstream synchronized with
 // the buffer flow and contains the format of the buffers that will follow after the event.
 func NewCapsEvent(caps *Caps) *Event {
-	return wrapEvent(C.gst_event_new_caps(
+	return takeEvent(C.gst_event_new_caps(
 		caps.Instance(),
 	))
 }
@@ -38,7 +38,7 @@ func NewCapsEvent(caps *Caps) *Event {
 // When all sinks have posted an EOS message, an EOS message is forwarded to the application.
 //
 // The EOS event itself will not cause any state transitions of the pipeline.
-func NewEOSEvent() *Event { return wrapEvent(C.gst_event_new_eos()) }
+func NewEOSEvent() *Event { return takeEvent(C.gst_event_new_eos()) }
 
 // NewFlushStartEvent allocates a new flush start event. The flush start event can be sent upstream and downstream and
 // travels out-of-bounds with the dataflow.
@@ -50,7 +50,7 @@ func NewEOSEvent() *Event { return wrapEvent(C.gst_event_new_eos()) }
 // Elements should unlock any blocking functions and exit their streaming functions as fast as possible when this event is received.
 //
 // This event is typically generated after a seek to flush out all queued data in the pipeline so that the new media is played as soon as possible.
-func NewFlushStartEvent() *Event { return wrapEvent(C.gst_event_new_flush_start()) }
+func NewFlushStartEvent() *Event { return takeEvent(C.gst_event_new_flush_start()) }
 
 // NewFlushStopEvent allocates a new flush stop event. The flush stop event can be sent upstream and downstream and travels serialized with the
 // dataflow. It is typically sent after sending a FLUSH_START event to make the pads accept data again.
@@ -59,14 +59,14 @@ func NewFlushStartEvent() *Event { return wrapEvent(C.gst_event_new_flush_start(
 //
 // This event is typically generated to complete a seek and to resume dataflow.
 func NewFlushStopEvent(resetTime bool) *Event {
-	return wrapEvent(C.gst_event_new_flush_stop(gboolean(resetTime)))
+	return takeEvent(C.gst_event_new_flush_stop(gboolean(resetTime)))
 }
 
 // NewGapEvent creates a new GAP event. A gap event can be thought of as conceptually equivalent to a buffer to signal that there is no data for a
 //certain amount of time. This is useful to signal a gap to downstream elements which may wait for data, such as muxers or mixers or overlays,
 // especially for sparse streams such as subtitle streams.
 func NewGapEvent(timestamp, duration time.Duration) *Event {
-	return wrapEvent(C.gst_event_new_gap(
+	return takeEvent(C.gst_event_new_gap(
 		C.GstClockTime(durationToClockTime(timestamp)),
 		C.GstClockTime(durationToClockTime(duration)),
 	))
@@ -104,14 +104,14 @@ func NewGapEvent(timestamp, duration time.Duration) *Event {
 //
 // The latency is mostly used in live sinks and is always expressed in the time format.
 func NewLatencyEvent(latency time.Duration) *Event {
-	return wrapEvent(C.gst_event_new_latency(
+	return takeEvent(C.gst_event_new_latency(
 		C.GstClockTime(durationToClockTime(latency)),
 	))
 }
 
 // NewNavigationEvent creates a new navigation event from the given description. The event will take ownership of the structure.
 func NewNavigationEvent(structure *Structure) *Event {
-	return wrapEvent(C.gst_event_new_navigation(
+	return takeEvent(C.gst_event_new_navigation(
 		structure.Instance(),
 	))
 }
@@ -136,7 +136,7 @@ func NewProtectionEvent(systemID string, buffer *Buffer, origin string) *Event {
 	cOrigin := C.CString(origin)
 	defer C.free(unsafe.Pointer(cSystemID))
 	defer C.free(unsafe.Pointer(cOrigin))
-	return wrapEvent(C.gst_event_new_protection(
+	return takeEvent(C.gst_event_new_protection(
 		(*C.gchar)(unsafe.Pointer(cSystemID)),
 		buffer.Instance(),
 		(*C.gchar)(unsafe.Pointer(cOrigin)),
@@ -164,7 +164,7 @@ func NewProtectionEvent(systemID string, buffer *Buffer, origin string) *Event {
 //
 // The application can use general event probes to intercept the QoS event and implement custom application specific QoS handling.
 func NewQOSEvent(qType QOSType, proportion float64, diff ClockTimeDiff, timestamp time.Duration) *Event {
-	return wrapEvent(C.gst_event_new_qos(
+	return takeEvent(C.gst_event_new_qos(
 		C.GstQOSType(qType),
 		C.gdouble(proportion),
 		C.GstClockTimeDiff(diff),
@@ -175,7 +175,7 @@ func NewQOSEvent(qType QOSType, proportion float64, diff ClockTimeDiff, timestam
 // NewReconfigureEvent creates a new reconfigure event. The purpose of the reconfigure event is to travel upstream and make elements renegotiate their caps or reconfigure their buffer pools.
 // This is useful when changing properties on elements or changing the topology of the pipeline.
 func NewReconfigureEvent() *Event {
-	return wrapEvent(C.gst_event_new_reconfigure())
+	return takeEvent(C.gst_event_new_reconfigure())
 }
 
 // NewSeekEvent allocates a new seek event with the given parameters.
@@ -198,7 +198,7 @@ func NewReconfigureEvent() *Event {
 // It is not possible to seek relative to the current playback position, to do this, PAUSE the pipeline, query the current playback position with GST_QUERY_POSITION and update the playback segment
 // current position with a GST_SEEK_TYPE_SET to the desired position.
 func NewSeekEvent(rate float64, format Format, flags SeekFlags, startType SeekType, start int64, stopType SeekType, stop int64) *Event {
-	return wrapEvent(C.gst_event_new_seek(
+	return takeEvent(C.gst_event_new_seek(
 		C.gdouble(rate),
 		C.GstFormat(format),
 		C.GstSeekFlags(flags),
@@ -228,14 +228,14 @@ func NewSeekEvent(rate float64, format Format, flags SeekFlags, startType SeekTy
 //
 // time + (TIMESTAMP(buf) - start) * ABS (rate * applied_rate)
 func NewSegmentEvent(segment *Segment) *Event {
-	return wrapEvent(C.gst_event_new_segment(
+	return takeEvent(C.gst_event_new_segment(
 		segment.Instance(),
 	))
 }
 
 // NewSegmentDoneEvent creates a new segment-done event. This event is sent by elements that finish playback of a segment as a result of a segment seek.
 func NewSegmentDoneEvent(format Format, position int64) *Event {
-	return wrapEvent(C.gst_event_new_segment_done(
+	return takeEvent(C.gst_event_new_segment_done(
 		C.GstFormat(format), C.gint64(position),
 	))
 }
@@ -249,7 +249,7 @@ func NewSegmentDoneEvent(format Format, position int64) *Event {
 //
 // Note: The list of streams can not be empty.
 func NewSelectStreamsEvent(streams []*Stream) *Event {
-	return wrapEvent(C.gst_event_new_select_streams(
+	return takeEvent(C.gst_event_new_select_streams(
 		streamSliceToGlist(streams),
 	))
 }
@@ -261,7 +261,7 @@ func NewSelectStreamsEvent(streams []*Stream) *Event {
 func NewSinkMessageEvent(name string, msg *Message) *Event {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return wrapEvent(C.gst_event_new_sink_message(
+	return takeEvent(C.gst_event_new_sink_message(
 		(*C.gchar)(unsafe.Pointer(cName)),
 		msg.Instance(),
 	))
@@ -277,7 +277,7 @@ func NewSinkMessageEvent(name string, msg *Message) *Event {
 //
 // The intermediate flag instructs the pipeline that this step operation is part of a larger step operation.
 func NewStepEvent(format Format, amount uint64, rate float64, flush, intermediate bool) *Event {
-	return wrapEvent(C.gst_event_new_step(
+	return takeEvent(C.gst_event_new_step(
 		C.GstFormat(format),
 		C.guint64(amount),
 		C.gdouble(rate),
@@ -291,7 +291,7 @@ func NewStepEvent(format Format, amount uint64, rate float64, flush, intermediat
 // Source elements, demuxers and other elements that manage collections of streams and post GstStreamCollection messages on the bus also send this event downstream
 // on each pad involved in the collection, so that activation of a new collection can be tracked through the downstream data flow.
 func NewStreamCollectionEvent(collection *StreamCollection) *Event {
-	return wrapEvent(C.gst_event_new_stream_collection(
+	return takeEvent(C.gst_event_new_stream_collection(
 		collection.Instance(),
 	))
 }
@@ -303,7 +303,7 @@ func NewStreamCollectionEvent(collection *StreamCollection) *Event {
 // This event is followed by EOS at some point in the future, and is generally used when switching pads - to unblock downstream so that new pads can be exposed before
 // sending EOS on the existing pads.
 func NewStreamGroupDoneEvent(groupID uint) *Event {
-	return wrapEvent(C.gst_event_new_stream_group_done(C.guint(groupID)))
+	return takeEvent(C.gst_event_new_stream_group_done(C.guint(groupID)))
 }
 
 // NewStreamStartEvent creates a new STREAM_START event. The stream start event can only travel downstream synchronized with the buffer flow. It is expected to be the
@@ -320,7 +320,7 @@ func NewStreamGroupDoneEvent(groupID uint) *Event {
 func NewStreamStartEvent(streamID string) *Event {
 	cName := C.CString(streamID)
 	defer C.free(unsafe.Pointer(cName))
-	return wrapEvent(C.gst_event_new_stream_start(
+	return takeEvent(C.gst_event_new_stream_start(
 		(*C.gchar)(unsafe.Pointer(cName)),
 	))
 }
@@ -330,14 +330,14 @@ func NewStreamStartEvent(streamID string) *Event {
 // The scope of the taglist specifies if the taglist applies to the complete medium or only to this specific stream. As the tag event is a sticky event, elements should merge
 // tags received from upstream with a given scope with their own tags with the same scope and create a new tag event from it.
 func NewTagEvent(tagList *TagList) *Event {
-	return wrapEvent(C.gst_event_new_tag(
-		tagList.Instance(),
+	return takeEvent(C.gst_event_new_tag(
+		tagList.give(),
 	))
 }
 
 // NewTOCEvent generates a TOC event from the given toc. The purpose of the TOC event is to inform elements that some kind of the TOC was found.
 func NewTOCEvent(toc *TOC, updated bool) *Event {
-	return wrapEvent(C.gst_event_new_toc(
+	return takeEvent(C.gst_event_new_toc(
 		toc.Instance(), gboolean(updated),
 	))
 }
@@ -347,7 +347,7 @@ func NewTOCEvent(toc *TOC, updated bool) *Event {
 func NewTOCSelectEvent(uid string) *Event {
 	cUID := C.CString(uid)
 	defer C.free(unsafe.Pointer(cUID))
-	return wrapEvent(C.gst_event_new_toc_select(
+	return takeEvent(C.gst_event_new_toc_select(
 		(*C.gchar)(unsafe.Pointer(cUID)),
 	))
 }
@@ -355,7 +355,7 @@ func NewTOCSelectEvent(uid string) *Event {
 // NewCustomEvent creates a new custom-typed event. This can be used for anything not handled by other event-specific functions to pass an event
 // to another element.
 func NewCustomEvent(eventType EventType, structure *Structure) *Event {
-	return wrapEvent(C.gst_event_new_custom(
+	return takeEvent(C.gst_event_new_custom(
 		C.GstEventType(eventType), structure.Instance(),
 	))
 }
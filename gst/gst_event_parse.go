@@ -0,0 +1,391 @@
+package gst
+
+// #include "gst.go.h"
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// ParsedEvent is implemented by all the values returned from Event.Parse. It allows for
+// idiomatic handling of events with a type switch:
+//
+//   switch ev := event.Parse().(type) {
+//   case *gst.CapsEvent:
+//       fmt.Println("New caps:", ev.Caps)
+//   case *gst.SegmentEvent:
+//       fmt.Println("New segment starting at", ev.Start)
+//   case *gst.EOSEvent:
+//       fmt.Println("End of stream")
+//   }
+//
+// All values are plain Go copies of the event contents. They hold no references to the original
+// event and can be safely marshaled to JSON for logging.
+type ParsedEvent interface {
+	// Header returns the fields common to all events.
+	Header() *EventHeader
+}
+
+// EventHeader contains the fields common to every ParsedEvent.
+type EventHeader struct {
+	// Type is the name of the type of the event.
+	Type string `json:"type"`
+	// Seqnum is the sequence number of the event.
+	Seqnum uint32 `json:"seqnum"`
+	// Timestamp is the time the event was created, or -1 if not set.
+	Timestamp time.Duration `json:"timestamp"`
+	// RunningTimeOffset is the running time offset of the event.
+	RunningTimeOffset int64 `json:"runningTimeOffset"`
+}
+
+// Header implements ParsedEvent.
+func (h *EventHeader) Header() *EventHeader { return h }
+
+// FlushStartEvent is the parsed value of an EventTypeFlushStart.
+type FlushStartEvent struct {
+	EventHeader
+}
+
+// FlushStopEvent is the parsed value of an EventTypeFlushStop.
+type FlushStopEvent struct {
+	EventHeader
+	// ResetTime is true if the running time should be reset.
+	ResetTime bool `json:"resetTime"`
+}
+
+// StreamStartEvent is the parsed value of an EventTypeStreamStart.
+type StreamStartEvent struct {
+	EventHeader
+	StreamID string      `json:"streamID"`
+	Flags    StreamFlags `json:"flags"`
+	// GroupID is the group id of the stream, valid if HasGroupID is true.
+	GroupID    uint `json:"groupID"`
+	HasGroupID bool `json:"hasGroupID"`
+}
+
+// CapsEvent is the parsed value of an EventTypeCaps.
+type CapsEvent struct {
+	EventHeader
+	// Caps is the serialized caps. Use Event.ParseCaps to retrieve the Caps themselves.
+	Caps string `json:"caps"`
+}
+
+// SegmentEvent is the parsed value of an EventTypeSegment.
+type SegmentEvent struct {
+	EventHeader
+	Format      Format       `json:"format"`
+	Flags       SegmentFlags `json:"flags"`
+	Rate        float64      `json:"rate"`
+	AppliedRate float64      `json:"appliedRate"`
+	Base        uint64       `json:"base"`
+	Offset      uint64       `json:"offset"`
+	Start       uint64       `json:"start"`
+	Stop        uint64       `json:"stop"`
+	Time        uint64       `json:"time"`
+	Position    uint64       `json:"position"`
+	Duration    uint64       `json:"duration"`
+}
+
+// StreamCollectionEvent is the parsed value of an EventTypeStreamCollection.
+type StreamCollectionEvent struct {
+	EventHeader
+	UpstreamID string       `json:"upstreamID"`
+	Streams    []StreamInfo `json:"streams"`
+}
+
+// TagEvent is the parsed value of an EventTypeTag.
+type TagEvent struct {
+	EventHeader
+	// Tags is the serialized tag list. Use Event.ParseTag to retrieve the TagList itself.
+	Tags  string   `json:"tags"`
+	Scope TagScope `json:"scope"`
+}
+
+// BufferSizeEvent is the parsed value of an EventTypeBufferSize.
+type BufferSizeEvent struct {
+	EventHeader
+	Format  Format `json:"format"`
+	MinSize int64  `json:"minSize"`
+	MaxSize int64  `json:"maxSize"`
+	Async   bool   `json:"async"`
+}
+
+// SinkMessageEvent is the parsed value of an EventTypeSinkMessage.
+type SinkMessageEvent struct {
+	EventHeader
+	// Name is the name of the structure of the event.
+	Name string `json:"name"`
+	// Message is the parsed message carried by the event.
+	Message ParsedMessage `json:"message,omitempty"`
+}
+
+// StreamGroupDoneEvent is the parsed value of an EventTypeStreamGroupDone.
+type StreamGroupDoneEvent struct {
+	EventHeader
+	GroupID uint `json:"groupID"`
+}
+
+// EOSEvent is the parsed value of an EventTypeEOS.
+type EOSEvent struct {
+	EventHeader
+}
+
+// TOCEvent is the parsed value of an EventTypeTOC.
+type TOCEvent struct {
+	EventHeader
+	// Updated is true if the TOC was updated rather than newly found. Use Event.ParseTOC
+	// to retrieve the TOC itself.
+	Updated bool `json:"updated"`
+}
+
+// ProtectionEvent is the parsed value of an EventTypeProtection.
+type ProtectionEvent struct {
+	EventHeader
+	SystemID string `json:"systemID"`
+	Origin   string `json:"origin"`
+	// DataSize is the size of the protection system specific data. Use Event.ParseProtection
+	// to retrieve the data itself.
+	DataSize int64 `json:"dataSize"`
+}
+
+// SegmentDoneEvent is the parsed value of an EventTypeSegmentDone.
+type SegmentDoneEvent struct {
+	EventHeader
+	Format   Format `json:"format"`
+	Position int64  `json:"position"`
+}
+
+// GapEvent is the parsed value of an EventTypeGap.
+type GapEvent struct {
+	EventHeader
+	// GapTimestamp is the start of the gap.
+	GapTimestamp time.Duration `json:"gapTimestamp"`
+	// Duration is the duration of the gap, or -1 if unknown.
+	Duration time.Duration `json:"duration"`
+}
+
+// QoSEvent is the parsed value of an EventTypeQOS.
+type QoSEvent struct {
+	EventHeader
+	QoSType    QOSType       `json:"qosType"`
+	Proportion float64       `json:"proportion"`
+	Diff       ClockTimeDiff `json:"diff"`
+	// BufferTimestamp is the timestamp of the buffer that triggered the event.
+	BufferTimestamp time.Duration `json:"bufferTimestamp"`
+}
+
+// SeekEvent is the parsed value of an EventTypeSeek.
+type SeekEvent struct {
+	EventHeader
+	Rate      float64   `json:"rate"`
+	Format    Format    `json:"format"`
+	Flags     SeekFlags `json:"flags"`
+	StartType SeekType  `json:"startType"`
+	Start     int64     `json:"start"`
+	StopType  SeekType  `json:"stopType"`
+	Stop      int64     `json:"stop"`
+}
+
+// NavigationEvent is the parsed value of an EventTypeNavigation.
+type NavigationEvent struct {
+	EventHeader
+	// Structure is the serialized structure of the navigation event.
+	Structure string `json:"structure"`
+}
+
+// LatencyEvent is the parsed value of an EventTypeLatency.
+type LatencyEvent struct {
+	EventHeader
+	Latency time.Duration `json:"latency"`
+}
+
+// StepEvent is the parsed value of an EventTypeStep.
+type StepEvent struct {
+	EventHeader
+	Format       Format  `json:"format"`
+	Amount       uint64  `json:"amount"`
+	Rate         float64 `json:"rate"`
+	Flush        bool    `json:"flush"`
+	Intermediate bool    `json:"intermediate"`
+}
+
+// ReconfigureEvent is the parsed value of an EventTypeReconfigure.
+type ReconfigureEvent struct {
+	EventHeader
+}
+
+// TOCSelectEvent is the parsed value of an EventTypeTOCSelect.
+type TOCSelectEvent struct {
+	EventHeader
+	UID string `json:"uid"`
+}
+
+// SelectStreamsEvent is the parsed value of an EventTypeSelectStreams.
+type SelectStreamsEvent struct {
+	EventHeader
+	// Streams are the ids of the selected streams.
+	Streams []string `json:"streams"`
+}
+
+// CustomEvent is the parsed value of any of the custom event types.
+type CustomEvent struct {
+	EventHeader
+	// Name is the name of the structure carried by the event.
+	Name string `json:"name"`
+	// Structure is the serialized structure carried by the event.
+	Structure string `json:"structure"`
+}
+
+// UnknownEvent is returned from Event.Parse for event types without a dedicated value.
+type UnknownEvent struct {
+	EventHeader
+	// Structure is the serialized structure of the event, if it has one.
+	Structure string `json:"structure,omitempty"`
+}
+
+// Parse parses the event into a ParsedEvent. The concrete type of the returned value depends on
+// the type of the event, for example an EventTypeCaps is parsed into a *CapsEvent. Events without
+// a dedicated type are returned as an *UnknownEvent.
+func (e *Event) Parse() ParsedEvent {
+	ev := e.Instance()
+	hdr := EventHeader{
+		Type:              e.Type().String(),
+		Seqnum:            e.Seqnum(),
+		Timestamp:         clockTimeToDuration(ClockTime(ev.timestamp)),
+		RunningTimeOffset: e.GetRunningTimeOffset(),
+	}
+
+	switch e.Type() {
+	case EventTypeFlushStart:
+		return &FlushStartEvent{hdr}
+	case EventTypeFlushStop:
+		return &FlushStopEvent{EventHeader: hdr, ResetTime: e.ParseFlushStop()}
+	case EventTypeStreamStart:
+		var streamID *C.gchar
+		var groupID C.guint
+		C.gst_event_parse_stream_start(ev, &streamID)
+		ok := C.gst_event_parse_group_id(ev, &groupID)
+		return &StreamStartEvent{
+			EventHeader: hdr,
+			StreamID:    C.GoString(streamID),
+			Flags:       e.ParseStreamFlags(),
+			GroupID:     uint(groupID),
+			HasGroupID:  gobool(ok),
+		}
+	case EventTypeCaps:
+		var caps *C.GstCaps
+		C.gst_event_parse_caps(ev, &caps)
+		out := &CapsEvent{EventHeader: hdr}
+		if caps != nil {
+			out.Caps = takeGString(C.gst_caps_to_string(caps))
+		}
+		return out
+	case EventTypeSegment:
+		var segment *C.GstSegment
+		C.gst_event_parse_segment(ev, &segment)
+		out := &SegmentEvent{EventHeader: hdr}
+		if segment != nil {
+			out.Format = Format(segment.format)
+			out.Flags = SegmentFlags(segment.flags)
+			out.Rate, out.AppliedRate = float64(segment.rate), float64(segment.applied_rate)
+			out.Base, out.Offset = uint64(segment.base), uint64(segment.offset)
+			out.Start, out.Stop = uint64(segment.start), uint64(segment.stop)
+			out.Time, out.Position, out.Duration = uint64(segment.time), uint64(segment.position), uint64(segment.duration)
+		}
+		return out
+	case EventTypeStreamCollection:
+		var collection *C.GstStreamCollection
+		C.gst_event_parse_stream_collection(ev, &collection)
+		out := &StreamCollectionEvent{EventHeader: hdr}
+		if collection != nil {
+			defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(collection)))
+			out.UpstreamID, out.Streams = streamCollectionInfo(collection)
+		}
+		return out
+	case EventTypeTag:
+		var tagList *C.GstTagList
+		C.gst_event_parse_tag(ev, &tagList)
+		out := &TagEvent{EventHeader: hdr}
+		if tagList != nil {
+			out.Tags = takeGString(C.gst_tag_list_to_string(tagList))
+			out.Scope = TagScope(C.gst_tag_list_get_scope(tagList))
+		}
+		return out
+	case EventTypeBufferSize:
+		format, minSize, maxSize, async := e.ParseBufferSize()
+		return &BufferSizeEvent{EventHeader: hdr, Format: format, MinSize: minSize, MaxSize: maxSize, Async: async}
+	case EventTypeSinkMessage:
+		out := &SinkMessageEvent{EventHeader: hdr, Name: gstStructureName(C.gst_event_get_structure(ev))}
+		var msg *C.GstMessage
+		C.gst_event_parse_sink_message(ev, &msg)
+		if msg != nil {
+			defer C.gst_message_unref(msg)
+			out.Message = wrapMessage(msg).Parse()
+		}
+		return out
+	case EventTypeStreamGroupDone:
+		return &StreamGroupDoneEvent{EventHeader: hdr, GroupID: e.ParseStreamGroupDone()}
+	case EventTypeEOS:
+		return &EOSEvent{hdr}
+	case EventTypeTOC:
+		var toc *C.GstToc
+		var updated C.gboolean
+		C.gst_event_parse_toc(ev, &toc, &updated)
+		if toc != nil {
+			C.gst_toc_unref(toc)
+		}
+		return &TOCEvent{EventHeader: hdr, Updated: gobool(updated)}
+	case EventTypeProtection:
+		var systemID, origin *C.gchar
+		var data *C.GstBuffer
+		C.gst_event_parse_protection(ev, &systemID, &data, &origin)
+		out := &ProtectionEvent{EventHeader: hdr, SystemID: C.GoString(systemID), Origin: C.GoString(origin)}
+		if data != nil {
+			out.DataSize = int64(C.gst_buffer_get_size(data))
+		}
+		return out
+	case EventTypeSegmentDone:
+		format, position := e.ParseSegmentDone()
+		return &SegmentDoneEvent{EventHeader: hdr, Format: format, Position: position}
+	case EventTypeGap:
+		timestamp, duration := e.ParseGap()
+		return &GapEvent{EventHeader: hdr, GapTimestamp: timestamp, Duration: duration}
+	case EventTypeQOS:
+		qosType, proportion, diff, timestamp := e.ParseQOS()
+		return &QoSEvent{EventHeader: hdr, QoSType: qosType, Proportion: proportion, Diff: diff, BufferTimestamp: timestamp}
+	case EventTypeSeek:
+		out := &SeekEvent{EventHeader: hdr}
+		out.Rate, out.Format, out.Flags, out.StartType, out.Start, out.StopType, out.Stop = e.ParseSeek()
+		return out
+	case EventTypeNavigation:
+		return &NavigationEvent{EventHeader: hdr, Structure: gstStructureString(C.gst_event_get_structure(ev))}
+	case EventTypeLatency:
+		return &LatencyEvent{EventHeader: hdr, Latency: e.ParseLatency()}
+	case EventTypeStep:
+		out := &StepEvent{EventHeader: hdr}
+		out.Format, out.Amount, out.Rate, out.Flush, out.Intermediate = e.ParseStep()
+		return out
+	case EventTypeReconfigure:
+		return &ReconfigureEvent{hdr}
+	case EventTypeTOCSelect:
+		var uid *C.gchar
+		C.gst_event_parse_toc_select(ev, &uid)
+		return &TOCSelectEvent{EventHeader: hdr, UID: takeGString(uid)}
+	case EventTypeSelectStreams:
+		var streams *C.GList
+		C.gst_event_parse_select_streams(ev, &streams)
+		out := &SelectStreamsEvent{EventHeader: hdr, Streams: make([]string, 0)}
+		for l := streams; l != nil; l = l.next {
+			out.Streams = append(out.Streams, C.GoString((*C.gchar)(unsafe.Pointer(l.data))))
+		}
+		C.g_list_free_full(streams, C.GDestroyNotify(C.g_free))
+		return out
+	case EventTypeCustomUpstream, EventTypeCustomDownstream, EventTypeCustomOOB,
+		EventTypeCustomDownstreamSticky, EventTypeCustomBoth, EventTypeCustomBothOOB:
+		st := C.gst_event_get_structure(ev)
+		return &CustomEvent{EventHeader: hdr, Name: gstStructureName(st), Structure: gstStructureString(st)}
+	}
+
+	return &UnknownEvent{EventHeader: hdr, Structure: gstStructureString(C.gst_event_get_structure(ev))}
+}
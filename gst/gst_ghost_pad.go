@@ -28,7 +28,9 @@ func NewGhostPad(name string, target *Pad) *GhostPad {
 	if pad == nil {
 		return nil
 	}
-	return wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // NewGhostPadFromTemplate creates a new ghostpad with target as the target. The direction will be taken
@@ -51,7 +53,9 @@ func NewGhostPadFromTemplate(name string, target *Pad, tmpl *PadTemplate) *Ghost
 	if pad == nil {
 		return nil
 	}
-	return wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // NewGhostPadNoTarget creates a new ghostpad without a target with the given direction. A target can be set on the
@@ -72,7 +76,9 @@ func NewGhostPadNoTarget(name string, direction PadDirection) *GhostPad {
 	if pad == nil {
 		return nil
 	}
-	return wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // NewGhostPadNoTargetFromTemplate creates a new ghostpad based on templ, without setting a target. The direction will be taken
@@ -91,7 +97,9 @@ func NewGhostPadNoTargetFromTemplate(name string, tmpl *PadTemplate) *GhostPad {
 	if pad == nil {
 		return nil
 	}
-	return wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapGhostPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the underlying ghost pad instance.
@@ -103,7 +111,9 @@ func (g *GhostPad) GetTarget() *Pad {
 	if pad == nil {
 		return nil
 	}
-	return wrapPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // SetTarget sets the new target of the ghostpad gpad. Any existing target is unlinked and links to the new target are
@@ -142,7 +152,9 @@ func (p *ProxyPad) Instance() *C.GstProxyPad { return C.toGstProxyPad(p.Unsafe()
 // The internal pad of a GhostPad is the internally used pad of opposite direction, which is used to link to the target.
 func (p *ProxyPad) GetInternal() *ProxyPad {
 	pad := C.gst_proxy_pad_get_internal(p.Instance())
-	return wrapProxyPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapProxyPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // ChainDefault invokes the default chain function of the proxy pad.
@@ -160,7 +172,7 @@ func (p *ProxyPad) GetRangeDefault(parent *Object, offset uint64, size uint) (Fl
 	var buf *C.GstBuffer
 	ret := FlowReturn(C.gst_proxy_pad_getrange_default(p.toPad(), parent.Instance(), C.guint64(offset), C.guint(size), &buf))
 	if ret != FlowError {
-		return ret, wrapBuffer(buf)
+		return ret, takeBuffer(buf)
 	}
 	return ret, nil
 }
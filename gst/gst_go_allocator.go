@@ -0,0 +1,301 @@
+package gst
+
+/*
+#include <string.h>
+#include "gst.go.h"
+
+extern gpointer    goAllocatorAlloc  (GstAllocator * allocator, gsize maxsize, gsize align, gpointer * handle);
+extern void        goAllocatorFree   (GstAllocator * allocator, gpointer data, gsize maxsize, gpointer handle);
+extern gboolean    goAllocatorMap    (GstAllocator * allocator, GstMemory * mem, GstMapFlags flags);
+extern void        goAllocatorUnmap  (GstAllocator * allocator, GstMemory * mem);
+extern GstMemory * goAllocatorShare  (GstAllocator * allocator, GstMemory * mem, gssize offset, gssize size);
+extern GstMemory * goAllocatorCopy   (GstAllocator * allocator, GstMemory * mem, gssize offset, gssize size);
+extern gboolean    goAllocatorIsSpan (GstAllocator * allocator, GstMemory * mem1, GstMemory * mem2, gsize * offset);
+extern void        goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+typedef struct {
+	GstAllocator parent;
+	gpointer     impl;
+	gboolean     has_share;
+} GstGoAllocator;
+
+typedef struct {
+	GstAllocatorClass parent_class;
+} GstGoAllocatorClass;
+
+typedef struct {
+	GstMemory mem;
+	gpointer  data;
+	gpointer  handle;
+} GstGoMemory;
+
+G_DEFINE_TYPE(GstGoAllocator, gst_go_allocator, GST_TYPE_ALLOCATOR);
+
+static GstMemory * goAllocatorAllocImpl (GstAllocator * allocator, gsize size, GstAllocationParams * params)
+{
+	GstGoMemory * mem;
+	gpointer handle = NULL;
+	gsize maxsize = size + params->prefix + params->padding;
+	gpointer data = goAllocatorAlloc(allocator, maxsize, params->align, &handle);
+	if (data == NULL)
+		return NULL;
+
+	mem = g_slice_new(GstGoMemory);
+	gst_memory_init(GST_MEMORY_CAST(mem), params->flags, allocator, NULL, maxsize, params->align, params->prefix, size);
+	mem->data = data;
+	mem->handle = handle;
+
+	if (params->prefix && (params->flags & GST_MEMORY_FLAG_ZERO_PREFIXED))
+		memset(data, 0, params->prefix);
+	if (params->padding && (params->flags & GST_MEMORY_FLAG_ZERO_PADDED))
+		memset((guint8 *) data + params->prefix + size, 0, params->padding);
+
+	return GST_MEMORY_CAST(mem);
+}
+
+static void goAllocatorFreeImpl (GstAllocator * allocator, GstMemory * memory)
+{
+	GstGoMemory * mem = (GstGoMemory *) memory;
+	if (memory->parent == NULL)
+		goAllocatorFree(allocator, mem->data, memory->maxsize, mem->handle);
+	g_slice_free(GstGoMemory, mem);
+}
+
+static gpointer goMemoryMap (GstMemory * memory, gsize maxsize, GstMapFlags flags)
+{
+	if (!goAllocatorMap(memory->allocator, memory, flags))
+		return NULL;
+	return ((GstGoMemory *) memory)->data;
+}
+
+static void goMemoryUnmap (GstMemory * memory)
+{
+	goAllocatorUnmap(memory->allocator, memory);
+}
+
+static GstMemory * goMemoryShare (GstMemory * memory, gssize offset, gssize size)
+{
+	GstGoMemory * sub;
+	GstMemory * parent;
+
+	if (((GstGoAllocator *) memory->allocator)->has_share)
+		return goAllocatorShare(memory->allocator, memory, offset, size);
+
+	if ((parent = memory->parent) == NULL)
+		parent = memory;
+	if (size == -1)
+		size = memory->size - offset;
+
+	// the shared memory is always readonly
+	sub = g_slice_new(GstGoMemory);
+	gst_memory_init(GST_MEMORY_CAST(sub),
+		GST_MINI_OBJECT_FLAGS(parent) | GST_MINI_OBJECT_FLAG_LOCK_READONLY,
+		memory->allocator, parent, memory->maxsize, memory->align, memory->offset + offset, size);
+	sub->data = ((GstGoMemory *) memory)->data;
+	sub->handle = NULL;
+
+	return GST_MEMORY_CAST(sub);
+}
+
+static GstMemory * goMemoryCopy (GstMemory * memory, gssize offset, gssize size)
+{
+	return goAllocatorCopy(memory->allocator, memory, offset, size);
+}
+
+static gboolean goMemoryIsSpan (GstMemory * mem1, GstMemory * mem2, gsize * offset)
+{
+	return goAllocatorIsSpan(mem1->allocator, mem1, mem2, offset);
+}
+
+static void gst_go_allocator_finalize (GObject * object)
+{
+	goGDestroyNotifyFuncNoRun(((GstGoAllocator *) object)->impl);
+	G_OBJECT_CLASS(gst_go_allocator_parent_class)->finalize(object);
+}
+
+static void gst_go_allocator_class_init (GstGoAllocatorClass * klass)
+{
+	GstAllocatorClass * allocator_class = GST_ALLOCATOR_CLASS(klass);
+	allocator_class->alloc = goAllocatorAllocImpl;
+	allocator_class->free = goAllocatorFreeImpl;
+	G_OBJECT_CLASS(klass)->finalize = gst_go_allocator_finalize;
+}
+
+static void gst_go_allocator_init (GstGoAllocator * self)
+{
+	GstAllocator * alloc = GST_ALLOCATOR_CAST(self);
+	alloc->mem_map = goMemoryMap;
+	alloc->mem_unmap = goMemoryUnmap;
+	alloc->mem_share = goMemoryShare;
+}
+
+GstAllocator * newGoAllocator (const gchar * mem_type, gpointer impl, gboolean has_share, gboolean has_copy, gboolean has_is_span)
+{
+	GstGoAllocator * self = g_object_new(gst_go_allocator_get_type(), NULL);
+	GstAllocator * alloc = GST_ALLOCATOR_CAST(self);
+
+	self->impl = impl;
+	self->has_share = has_share;
+	alloc->mem_type = g_intern_string(mem_type);
+	if (has_copy)
+		alloc->mem_copy = goMemoryCopy;
+	if (has_is_span)
+		alloc->mem_is_span = goMemoryIsSpan;
+
+	return alloc;
+}
+
+gboolean isGoAllocator (GstAllocator * allocator)
+{
+	return allocator != NULL && G_TYPE_CHECK_INSTANCE_TYPE(allocator, gst_go_allocator_get_type());
+}
+
+gpointer goAllocatorImpl (GstAllocator * allocator) { return ((GstGoAllocator *) allocator)->impl; }
+
+gpointer goMemoryHandle (GstMemory * mem)
+{
+	if (mem->parent != NULL)
+		mem = mem->parent;
+	return ((GstGoMemory *) mem)->handle;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	gopointer "github.com/mattn/go-pointer"
+)
+
+// AllocatorImpl is the interface implemented by allocators written in go. See NewGoAllocator.
+//
+// The memory returned from Alloc is retained by C for the lifetime of the GstMemory, so it must not be
+// allocated on the go heap. Use AllocCBytes, or memory obtained with syscall.Mmap (e.g. backed by huge
+// pages or a memfd), or a slice of a larger arena allocated in the same way.
+type AllocatorImpl interface {
+	// Alloc allocates a block of at least size bytes, aligned to align + 1 bytes. Blocks that are too
+	// short or misaligned are passed back to Free and the allocation fails. The returned handle is
+	// stored with the memory and passed back to Free. It can be used to keep state such as a file
+	// descriptor, and can be retrieved from the memory with Memory.GoHandle. A nil slice should be returned
+	// if the memory could not be allocated.
+	Alloc(size, align int64) (data []byte, handle interface{})
+	// Free frees a block previously returned from Alloc.
+	Free(data []byte, handle interface{})
+}
+
+// AllocatorMapper can be implemented by an AllocatorImpl to be notified when its memory is mapped and
+// unmapped, e.g. to synchronize memory shared with another device or process. Map should return false
+// if the memory cannot be mapped with the given flags.
+type AllocatorMapper interface {
+	Map(mem *Memory, flags MapFlags) bool
+	Unmap(mem *Memory)
+}
+
+// AllocatorSharer can be implemented by an AllocatorImpl to override how a sub-region of its memory is
+// shared. The returned memory should not be writable. By default, a read-only memory referencing the
+// same data is created.
+type AllocatorSharer interface {
+	Share(mem *Memory, offset, size int64) *Memory
+}
+
+// AllocatorCopier can be implemented by an AllocatorImpl to override how its memory is copied. By default
+// the data is copied into memory allocated from the default allocator.
+type AllocatorCopier interface {
+	Copy(mem *Memory, offset, size int64) *Memory
+}
+
+// AllocatorSpanChecker can be implemented by an AllocatorImpl to report whether two of its memory blocks
+// are contiguous, in which case they can be merged without copying. It should return the offset of mem1
+// in the parent memory they share.
+type AllocatorSpanChecker interface {
+	IsSpan(mem1, mem2 *Memory) (offset int64, ok bool)
+}
+
+// NewGoAllocator returns a new Allocator implemented by impl. The memory it allocates has the given
+// memType. The allocator can be used directly with Alloc, set on a BufferPoolConfig with SetAllocator,
+// proposed in an allocation query with AddAllocationParam, or registered by name with RegisterAllocator.
+//
+//   type cAllocator struct{}
+//
+//   func (cAllocator) Alloc(size, align int64) ([]byte, interface{}) {
+//       // over-allocate and return an aligned slice, keeping the whole block to free it
+//       block := gst.AllocCBytes(int(size + align))
+//       offset := int64(-uintptr(unsafe.Pointer(&block[0])) & uintptr(align))
+//       return block[offset : offset+size], block
+//   }
+//
+//   func (cAllocator) Free(data []byte, handle interface{}) { gst.FreeCBytes(handle.([]byte)) }
+//
+//   allocator := gst.NewGoAllocator("CMemory", cAllocator{})
+//   gst.RegisterAllocator("cmemory", allocator)
+func NewGoAllocator(memType string, impl AllocatorImpl) *Allocator {
+	cMemType := C.CString(memType)
+	defer C.free(unsafe.Pointer(cMemType))
+	_, hasShare := impl.(AllocatorSharer)
+	_, hasCopy := impl.(AllocatorCopier)
+	_, hasIsSpan := impl.(AllocatorSpanChecker)
+	alloc := C.newGoAllocator(
+		(*C.gchar)(unsafe.Pointer(cMemType)),
+		(C.gpointer)(savePointer(impl)),
+		gboolean(hasShare), gboolean(hasCopy), gboolean(hasIsSpan),
+	)
+	obj := wrapAllocator(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(alloc))})
+	adoptObject(obj.BaseObject())
+	return obj
+}
+
+// RegisterAllocator registers the allocator with name, so it can be retrieved with FindAllocator by any
+// code in the process.
+func RegisterAllocator(name string, allocator *Allocator) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.gst_object_ref((C.gpointer)(allocator.Unsafe()))
+	C.gst_allocator_register((*C.gchar)(unsafe.Pointer(cName)), allocator.Instance())
+}
+
+// FindAllocator returns the allocator registered with name, or nil if there is none.
+func FindAllocator(name string) *Allocator {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	alloc := C.gst_allocator_find((*C.gchar)(unsafe.Pointer(cName)))
+	if alloc == nil {
+		return nil
+	}
+	obj := wrapAllocator(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(alloc))})
+	adoptObject(obj.BaseObject())
+	return obj
+}
+
+// SetDefault sets this allocator as the default allocator, used for allocations when no allocator
+// is specified.
+func (a *Allocator) SetDefault() {
+	C.gst_object_ref((C.gpointer)(a.Unsafe()))
+	C.gst_allocator_set_default(a.Instance())
+}
+
+// Impl returns the AllocatorImpl if this allocator was created with NewGoAllocator, or nil otherwise.
+func (a *Allocator) Impl() AllocatorImpl {
+	if !gobool(C.isGoAllocator(a.Instance())) {
+		return nil
+	}
+	return getGoAllocatorImpl(a.Instance())
+}
+
+// GoHandle returns the handle that was returned from the AllocatorImpl that allocated this memory, or
+// nil if it was not allocated by an allocator created with NewGoAllocator.
+func (m *Memory) GoHandle() interface{} {
+	if !gobool(C.isGoAllocator(m.Instance().allocator)) {
+		return nil
+	}
+	handle := C.goMemoryHandle(m.Instance())
+	if handle == nil {
+		return nil
+	}
+	return gopointer.Restore(unsafe.Pointer(handle))
+}
+
+// getGoAllocatorImpl returns the go implementation of the given allocator.
+func getGoAllocatorImpl(allocator *C.GstAllocator) AllocatorImpl {
+	return gopointer.Restore(unsafe.Pointer(C.goAllocatorImpl(allocator))).(AllocatorImpl)
+}
@@ -0,0 +1,49 @@
+package gst
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// offsetAllocator returns blocks starting offset bytes past an aligned address, and records the frees.
+type offsetAllocator struct {
+	offset int64
+	freed  int
+}
+
+func (o *offsetAllocator) Alloc(size, align int64) ([]byte, interface{}) {
+	block := AllocCBytes(int(size + align + o.offset))
+	start := int64(-uintptr(unsafe.Pointer(&block[0]))&uintptr(align)) + o.offset
+	return block[start : start+size], block
+}
+
+func (o *offsetAllocator) Free(data []byte, handle interface{}) {
+	o.freed++
+	FreeCBytes(handle.([]byte))
+}
+
+func TestGoAllocatorAlignment(t *testing.T) {
+	params := NewAllocationParams()
+	defer params.Free()
+	params.SetAlignment(15)
+
+	impl := &offsetAllocator{}
+	allocator := NewGoAllocator("TestMemory", impl)
+	mem := allocator.Alloc(64, params)
+	if mem == nil {
+		t.Fatal("Expected an aligned block to be accepted")
+	}
+	mem.Unref()
+	if impl.freed != 1 {
+		t.Errorf("Expected the block to be freed once, got %d", impl.freed)
+	}
+
+	impl = &offsetAllocator{offset: 1}
+	allocator = NewGoAllocator("TestMemory", impl)
+	if mem := allocator.Alloc(64, params); mem != nil {
+		t.Error("Expected a misaligned block to be rejected")
+	}
+	if impl.freed != 1 {
+		t.Errorf("Expected the misaligned block to be freed, got %d frees", impl.freed)
+	}
+}
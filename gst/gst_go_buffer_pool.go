@@ -0,0 +1,377 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+extern gchar **      goBufferPoolGetOptions    (GstBufferPool * pool);
+extern gboolean      goBufferPoolSetConfig     (GstBufferPool * pool, GstStructure * config);
+extern gboolean      goBufferPoolStart         (GstBufferPool * pool);
+extern gboolean      goBufferPoolStop          (GstBufferPool * pool);
+extern GstFlowReturn goBufferPoolAcquireBuffer (GstBufferPool * pool, GstBuffer ** buffer, GstBufferPoolAcquireParams * params);
+extern GstFlowReturn goBufferPoolAllocBuffer   (GstBufferPool * pool, GstBuffer ** buffer, GstBufferPoolAcquireParams * params);
+extern void          goBufferPoolResetBuffer   (GstBufferPool * pool, GstBuffer * buffer);
+extern void          goBufferPoolReleaseBuffer (GstBufferPool * pool, GstBuffer * buffer);
+extern void          goBufferPoolFreeBuffer    (GstBufferPool * pool, GstBuffer * buffer);
+extern void          goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+#define GO_POOL_GET_OPTIONS    (1 << 0)
+#define GO_POOL_SET_CONFIG     (1 << 1)
+#define GO_POOL_START          (1 << 2)
+#define GO_POOL_STOP           (1 << 3)
+#define GO_POOL_ACQUIRE_BUFFER (1 << 4)
+#define GO_POOL_ALLOC_BUFFER   (1 << 5)
+#define GO_POOL_RESET_BUFFER   (1 << 6)
+#define GO_POOL_RELEASE_BUFFER (1 << 7)
+#define GO_POOL_FREE_BUFFER    (1 << 8)
+
+typedef struct {
+	GstBufferPool parent;
+	gpointer      callbacks;
+	guint         funcs;
+	gchar **      options;
+} GstGoBufferPool;
+
+typedef struct {
+	GstBufferPoolClass parent_class;
+} GstGoBufferPoolClass;
+
+G_DEFINE_TYPE(GstGoBufferPool, gst_go_buffer_pool, GST_TYPE_BUFFER_POOL);
+
+#define GO_POOL(pool)           ((GstGoBufferPool *) (pool))
+#define GO_POOL_HAS(pool, func) (GO_POOL(pool)->funcs & (func))
+#define GO_POOL_PARENT_CLASS    (GST_BUFFER_POOL_CLASS(gst_go_buffer_pool_parent_class))
+
+const gchar ** parentBufferPoolGetOptions (GstBufferPool * pool)
+{
+	return GO_POOL_PARENT_CLASS->get_options(pool);
+}
+
+gboolean parentBufferPoolSetConfig (GstBufferPool * pool, GstStructure * config)
+{
+	return GO_POOL_PARENT_CLASS->set_config(pool, config);
+}
+
+gboolean parentBufferPoolStart (GstBufferPool * pool) { return GO_POOL_PARENT_CLASS->start(pool); }
+gboolean parentBufferPoolStop  (GstBufferPool * pool) { return GO_POOL_PARENT_CLASS->stop(pool); }
+
+GstFlowReturn parentBufferPoolAcquireBuffer (GstBufferPool * pool, GstBuffer ** buffer, GstBufferPoolAcquireParams * params)
+{
+	return GO_POOL_PARENT_CLASS->acquire_buffer(pool, buffer, params);
+}
+
+GstFlowReturn parentBufferPoolAllocBuffer (GstBufferPool * pool, GstBuffer ** buffer, GstBufferPoolAcquireParams * params)
+{
+	return GO_POOL_PARENT_CLASS->alloc_buffer(pool, buffer, params);
+}
+
+void parentBufferPoolResetBuffer   (GstBufferPool * pool, GstBuffer * buffer) { GO_POOL_PARENT_CLASS->reset_buffer(pool, buffer); }
+void parentBufferPoolReleaseBuffer (GstBufferPool * pool, GstBuffer * buffer) { GO_POOL_PARENT_CLASS->release_buffer(pool, buffer); }
+void parentBufferPoolFreeBuffer    (GstBufferPool * pool, GstBuffer * buffer) { GO_POOL_PARENT_CLASS->free_buffer(pool, buffer); }
+
+static const gchar ** goPoolGetOptions (GstBufferPool * pool)
+{
+	gchar ** options;
+	if (!GO_POOL_HAS(pool, GO_POOL_GET_OPTIONS))
+		return parentBufferPoolGetOptions(pool);
+	// The returned array belongs to the pool and may be read by other threads at any time, so the
+	// options are computed once and kept until the pool is finalized.
+	GST_OBJECT_LOCK(pool);
+	options = GO_POOL(pool)->options;
+	GST_OBJECT_UNLOCK(pool);
+	if (options != NULL)
+		return (const gchar **) options;
+	options = goBufferPoolGetOptions(pool);
+	GST_OBJECT_LOCK(pool);
+	if (GO_POOL(pool)->options == NULL) {
+		GO_POOL(pool)->options = options;
+		options = NULL;
+	}
+	GST_OBJECT_UNLOCK(pool);
+	g_strfreev(options);
+	return (const gchar **) GO_POOL(pool)->options;
+}
+
+static gboolean goPoolSetConfig (GstBufferPool * pool, GstStructure * config)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_SET_CONFIG))
+		return parentBufferPoolSetConfig(pool, config);
+	return goBufferPoolSetConfig(pool, config);
+}
+
+static gboolean goPoolStart (GstBufferPool * pool)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_START))
+		return parentBufferPoolStart(pool);
+	return goBufferPoolStart(pool);
+}
+
+static gboolean goPoolStop (GstBufferPool * pool)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_STOP))
+		return parentBufferPoolStop(pool);
+	return goBufferPoolStop(pool);
+}
+
+static GstFlowReturn goPoolAcquireBuffer (GstBufferPool * pool, GstBuffer ** buffer, GstBufferPoolAcquireParams * params)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_ACQUIRE_BUFFER))
+		return parentBufferPoolAcquireBuffer(pool, buffer, params);
+	return goBufferPoolAcquireBuffer(pool, buffer, params);
+}
+
+static GstFlowReturn goPoolAllocBuffer (GstBufferPool * pool, GstBuffer ** buffer, GstBufferPoolAcquireParams * params)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_ALLOC_BUFFER))
+		return parentBufferPoolAllocBuffer(pool, buffer, params);
+	return goBufferPoolAllocBuffer(pool, buffer, params);
+}
+
+static void goPoolResetBuffer (GstBufferPool * pool, GstBuffer * buffer)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_RESET_BUFFER))
+		parentBufferPoolResetBuffer(pool, buffer);
+	else
+		goBufferPoolResetBuffer(pool, buffer);
+}
+
+static void goPoolReleaseBuffer (GstBufferPool * pool, GstBuffer * buffer)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_RELEASE_BUFFER))
+		parentBufferPoolReleaseBuffer(pool, buffer);
+	else
+		goBufferPoolReleaseBuffer(pool, buffer);
+}
+
+static void goPoolFreeBuffer (GstBufferPool * pool, GstBuffer * buffer)
+{
+	if (!GO_POOL_HAS(pool, GO_POOL_FREE_BUFFER))
+		parentBufferPoolFreeBuffer(pool, buffer);
+	else
+		goBufferPoolFreeBuffer(pool, buffer);
+}
+
+static void gst_go_buffer_pool_finalize (GObject * object)
+{
+	goGDestroyNotifyFuncNoRun(GO_POOL(object)->callbacks);
+	g_strfreev(GO_POOL(object)->options);
+	G_OBJECT_CLASS(gst_go_buffer_pool_parent_class)->finalize(object);
+}
+
+static void gst_go_buffer_pool_class_init (GstGoBufferPoolClass * klass)
+{
+	GstBufferPoolClass * pool_class = GST_BUFFER_POOL_CLASS(klass);
+	pool_class->get_options = goPoolGetOptions;
+	pool_class->set_config = goPoolSetConfig;
+	pool_class->start = goPoolStart;
+	pool_class->stop = goPoolStop;
+	pool_class->acquire_buffer = goPoolAcquireBuffer;
+	pool_class->alloc_buffer = goPoolAllocBuffer;
+	pool_class->reset_buffer = goPoolResetBuffer;
+	pool_class->release_buffer = goPoolReleaseBuffer;
+	pool_class->free_buffer = goPoolFreeBuffer;
+	G_OBJECT_CLASS(klass)->finalize = gst_go_buffer_pool_finalize;
+}
+
+static void gst_go_buffer_pool_init (GstGoBufferPool * self) { }
+
+GstBufferPool * newGoBufferPool (gpointer callbacks, guint funcs)
+{
+	GstGoBufferPool * self = g_object_new(gst_go_buffer_pool_get_type(), NULL);
+	self->callbacks = callbacks;
+	self->funcs = funcs;
+	return GST_BUFFER_POOL_CAST(self);
+}
+
+gpointer goBufferPoolCallbacks (GstBufferPool * pool) { return GO_POOL(pool)->callbacks; }
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	gopointer "github.com/mattn/go-pointer"
+)
+
+// BufferPoolCallbacks are the functions implementing a BufferPool created with NewGoBufferPool. Any of them
+// can be nil, in which case the default GstBufferPool implementation is used. Implementations can call the
+// default implementation themselves with the Parent methods on the pool, e.g. ParentAllocBuffer.
+type BufferPoolCallbacks struct {
+	// GetOptionsFunc returns the options supported by the pool. It is only called the first time the
+	// options are requested, and the result is kept for the lifetime of the pool.
+	GetOptionsFunc func(self *BufferPool) []string
+	// SetConfigFunc applies the config to the pool. The config is owned by the pool. Implementations
+	// usually validate the config and then call ParentSetConfig.
+	SetConfigFunc func(self *BufferPool, config *BufferPoolConfig) bool
+	// StartFunc is called when the pool is activated. The default implementation preallocates the
+	// minimum number of buffers from the config.
+	StartFunc func(self *BufferPool) bool
+	// StopFunc is called when the pool is deactivated. The default implementation frees all the
+	// buffers in the pool.
+	StopFunc func(self *BufferPool) bool
+	// AcquireBufferFunc gets a buffer from the pool. The default implementation takes a buffer from the
+	// queue of released buffers, or calls AllocBufferFunc when it is empty. params can be nil.
+	AcquireBufferFunc func(self *BufferPool, params *BufferPoolAcquireParams) (*Buffer, FlowReturn)
+	// AllocBufferFunc allocates a new buffer for the pool. This is the place to attach metas and fill in
+	// data that should be present on every buffer of the pool. params can be nil.
+	AllocBufferFunc func(self *BufferPool, params *BufferPoolAcquireParams) (*Buffer, FlowReturn)
+	// ResetBufferFunc resets a buffer to its initial state when it is released back to the pool. The
+	// default implementation resets the timestamps, offsets and flags, and removes metas that are not
+	// pooled.
+	ResetBufferFunc func(self *BufferPool, buffer *Buffer)
+	// ReleaseBufferFunc returns a buffer to the pool. The function owns the buffer, and must either pass it
+	// on to ParentReleaseBuffer or ParentFreeBuffer, or unref it.
+	ReleaseBufferFunc func(self *BufferPool, buffer *Buffer)
+	// FreeBufferFunc frees a buffer of the pool. The function owns the buffer, and must either pass it on
+	// to ParentFreeBuffer or unref it.
+	FreeBufferFunc func(self *BufferPool, buffer *Buffer)
+}
+
+func (b *BufferPoolCallbacks) funcs() C.guint {
+	var funcs C.guint
+	if b.GetOptionsFunc != nil {
+		funcs |= C.GO_POOL_GET_OPTIONS
+	}
+	if b.SetConfigFunc != nil {
+		funcs |= C.GO_POOL_SET_CONFIG
+	}
+	if b.StartFunc != nil {
+		funcs |= C.GO_POOL_START
+	}
+	if b.StopFunc != nil {
+		funcs |= C.GO_POOL_STOP
+	}
+	if b.AcquireBufferFunc != nil {
+		funcs |= C.GO_POOL_ACQUIRE_BUFFER
+	}
+	if b.AllocBufferFunc != nil {
+		funcs |= C.GO_POOL_ALLOC_BUFFER
+	}
+	if b.ResetBufferFunc != nil {
+		funcs |= C.GO_POOL_RESET_BUFFER
+	}
+	if b.ReleaseBufferFunc != nil {
+		funcs |= C.GO_POOL_RELEASE_BUFFER
+	}
+	if b.FreeBufferFunc != nil {
+		funcs |= C.GO_POOL_FREE_BUFFER
+	}
+	return funcs
+}
+
+// NewGoBufferPool returns a new BufferPool implemented by the given callbacks. The pool is configured and
+// used like any other BufferPool, and buffers acquired from it return to it once their last reference is
+// dropped, including when they are pushed into a pipeline, e.g. with an app.Source.
+//
+//   pool := gst.NewGoBufferPool(&gst.BufferPoolCallbacks{
+//       AllocBufferFunc: func(self *gst.BufferPool, params *gst.BufferPoolAcquireParams) (*gst.Buffer, gst.FlowReturn) {
+//           buf, ret := self.ParentAllocBuffer(params)
+//           if ret != gst.FlowOK {
+//               return nil, ret
+//           }
+//           writeHeader(buf)
+//           return buf, gst.FlowOK
+//       },
+//   })
+//
+//   cfg := pool.GetConfig()
+//   cfg.SetParams(caps, frameSize, 4, 0)
+//   pool.SetConfig(*cfg)
+//   pool.SetActive(true)
+//
+//   buf, ret := pool.AcquireBuffer(nil)
+//   if ret == gst.FlowOK {
+//       src.PushBuffer(buf)
+//   }
+func NewGoBufferPool(cbs *BufferPoolCallbacks) *BufferPool {
+	pool := C.newGoBufferPool((C.gpointer)(savePointer(cbs)), cbs.funcs())
+	obj := wrapBufferPool(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pool))})
+	adoptObject(obj.BaseObject())
+	return obj
+}
+
+func getBufferPoolCallbacks(pool *C.GstBufferPool) *BufferPoolCallbacks {
+	return gopointer.Restore(unsafe.Pointer(C.goBufferPoolCallbacks(pool))).(*BufferPoolCallbacks)
+}
+
+// ParentGetOptions calls the default GetOptions implementation of the pool.
+func (b *BufferPool) ParentGetOptions() []string {
+	opts := C.parentBufferPoolGetOptions(b.Instance())
+	if opts == nil {
+		return nil
+	}
+	return goStrings(C.sizeOfGCharArray((**C.gchar)(unsafe.Pointer(opts))), (**C.gchar)(unsafe.Pointer(opts)))
+}
+
+// ParentSetConfig calls the default SetConfig implementation of the pool.
+func (b *BufferPool) ParentSetConfig(config *BufferPoolConfig) bool {
+	return gobool(C.parentBufferPoolSetConfig(b.Instance(), config.Instance()))
+}
+
+// ParentStart calls the default Start implementation of the pool.
+func (b *BufferPool) ParentStart() bool { return gobool(C.parentBufferPoolStart(b.Instance())) }
+
+// ParentStop calls the default Stop implementation of the pool.
+func (b *BufferPool) ParentStop() bool { return gobool(C.parentBufferPoolStop(b.Instance())) }
+
+// ParentAcquireBuffer calls the default AcquireBuffer implementation of the pool.
+func (b *BufferPool) ParentAcquireBuffer(params *BufferPoolAcquireParams) (*Buffer, FlowReturn) {
+	var buf *C.GstBuffer
+	ret := C.parentBufferPoolAcquireBuffer(b.Instance(), &buf, params.toC())
+	if buf == nil {
+		return nil, FlowReturn(ret)
+	}
+	return takeBuffer(buf), FlowReturn(ret)
+}
+
+// ParentAllocBuffer calls the default AllocBuffer implementation of the pool, which allocates a buffer with
+// the allocator and size of the config.
+func (b *BufferPool) ParentAllocBuffer(params *BufferPoolAcquireParams) (*Buffer, FlowReturn) {
+	var buf *C.GstBuffer
+	ret := C.parentBufferPoolAllocBuffer(b.Instance(), &buf, params.toC())
+	if buf == nil {
+		return nil, FlowReturn(ret)
+	}
+	return takeBuffer(buf), FlowReturn(ret)
+}
+
+// ParentResetBuffer calls the default ResetBuffer implementation of the pool.
+func (b *BufferPool) ParentResetBuffer(buffer *Buffer) {
+	C.parentBufferPoolResetBuffer(b.Instance(), buffer.Instance())
+}
+
+// ParentReleaseBuffer calls the default ReleaseBuffer implementation of the pool. It takes ownership of
+// the buffer.
+func (b *BufferPool) ParentReleaseBuffer(buffer *Buffer) {
+	C.parentBufferPoolReleaseBuffer(b.Instance(), buffer.give())
+}
+
+// ParentFreeBuffer calls the default FreeBuffer implementation of the pool. It takes ownership of the buffer.
+func (b *BufferPool) ParentFreeBuffer(buffer *Buffer) {
+	C.parentBufferPoolFreeBuffer(b.Instance(), buffer.give())
+}
+
+// toC returns the C representation of the params, or nil if p is nil.
+func (p *BufferPoolAcquireParams) toC() *C.GstBufferPoolAcquireParams {
+	if p == nil {
+		return nil
+	}
+	return &C.GstBufferPoolAcquireParams{
+		format: C.GstFormat(p.Format),
+		start:  C.gint64(p.Start),
+		stop:   C.gint64(p.Stop),
+		flags:  C.GstBufferPoolAcquireFlags(p.Flags),
+	}
+}
+
+func wrapBufferPoolAcquireParams(params *C.GstBufferPoolAcquireParams) *BufferPoolAcquireParams {
+	if params == nil {
+		return nil
+	}
+	return &BufferPoolAcquireParams{
+		Format: Format(params.format),
+		Start:  int64(params.start),
+		Stop:   int64(params.stop),
+		Flags:  BufferPoolAcquireFlags(params.flags),
+	}
+}
@@ -0,0 +1,68 @@
+package gst
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestGoBufferPoolOptionsComputedOnce(t *testing.T) {
+	var calls int32
+	pool := NewGoBufferPool(&BufferPoolCallbacks{
+		GetOptionsFunc: func(self *BufferPool) []string {
+			atomic.AddInt32(&calls, 1)
+			return []string{"my-option"}
+		},
+	})
+	defer pool.Unref()
+
+	for i := 0; i < 3; i++ {
+		if opts := pool.GetOptions(); len(opts) != 1 || opts[0] != "my-option" {
+			t.Fatalf("Unexpected options: %v", opts)
+		}
+	}
+	if !pool.HasOption("my-option") {
+		t.Error("Expected the pool to have the option")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected the options to be computed once, got %d calls", n)
+	}
+}
+
+func TestGoBufferPoolResetsWritableBuffers(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	writable := make(chan bool, 1)
+	pool := NewGoBufferPool(&BufferPoolCallbacks{
+		ResetBufferFunc: func(self *BufferPool, buffer *Buffer) {
+			writable <- buffer.IsWritable()
+			self.ParentResetBuffer(buffer)
+		},
+	})
+	defer pool.Unref()
+
+	cfg := pool.GetConfig()
+	cfg.SetParams(nil, 64, 1, 1)
+	if !pool.SetConfig(*cfg) || !pool.SetActive(true) {
+		t.Fatal("Could not activate the pool")
+	}
+	defer pool.SetActive(false)
+
+	buf, ret := pool.AcquireBuffer(nil)
+	if ret != FlowOK {
+		t.Fatalf("Could not acquire a buffer: %s", ret)
+	}
+	buf.Unref()
+	if !<-writable {
+		t.Fatal("Expected the buffer being reset to be writable")
+	}
+
+	// The pool holds a single buffer, which must be writable again once acquired.
+	buf, ret = pool.AcquireBuffer(nil)
+	if ret != FlowOK {
+		t.Fatalf("Could not acquire the buffer again: %s", ret)
+	}
+	defer buf.Unref()
+	if !buf.IsWritable() {
+		t.Error("Expected the reused buffer to be writable")
+	}
+}
@@ -0,0 +1,165 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+extern void goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+void cgoGoElementDataDestroyNotify (gpointer user_data)
+{
+	goGDestroyNotifyFuncNoRun(user_data);
+}
+
+typedef struct {
+	gpointer   callbacks;
+	gchar *    longname;
+	gchar *    klass;
+	gchar *    description;
+	gchar *    author;
+	GstCaps *  sinkCaps;
+	GstCaps *  srcCaps;
+} GoElementClassData;
+
+#define GO_ELEMENT_CALLBACKS_QUARK (g_quark_from_static_string("go-gst-element-callbacks"))
+#define GO_ELEMENT_DATA_QUARK      (g_quark_from_static_string("go-gst-element-data"))
+
+static void goElementClassInit (gpointer g_class, gpointer class_data)
+{
+	GoElementClassData * data = (GoElementClassData *) class_data;
+	GstElementClass * klass = GST_ELEMENT_CLASS(g_class);
+
+	g_type_set_qdata(G_TYPE_FROM_CLASS(g_class), GO_ELEMENT_CALLBACKS_QUARK, data->callbacks);
+	gst_element_class_set_metadata(klass, data->longname, data->klass, data->description, data->author);
+	gst_element_class_add_pad_template(klass, gst_pad_template_new("sink", GST_PAD_SINK, GST_PAD_ALWAYS, data->sinkCaps));
+	gst_element_class_add_pad_template(klass, gst_pad_template_new("src", GST_PAD_SRC, GST_PAD_ALWAYS, data->srcCaps));
+}
+
+GType registerGoElementType (GType parent, const gchar * name, GoElementClassData * data)
+{
+	GTypeQuery query;
+	GTypeInfo info = { 0 };
+
+	g_type_query(parent, &query);
+	if (query.type == 0)
+		return 0;
+	info.class_size = query.class_size;
+	info.class_init = goElementClassInit;
+	info.class_data = data;
+	info.instance_size = query.instance_size;
+	return g_type_register_static(parent, name, &info, 0);
+}
+
+gpointer goElementCallbacks (GstElement * element)
+{
+	GType type;
+	gpointer callbacks = NULL;
+	for (type = G_OBJECT_TYPE(element); type != 0 && callbacks == NULL; type = g_type_parent(type))
+		callbacks = g_type_get_qdata(type, GO_ELEMENT_CALLBACKS_QUARK);
+	return callbacks;
+}
+
+gpointer goElementData (GstElement * element)
+{
+	return g_object_get_qdata(G_OBJECT(element), GO_ELEMENT_DATA_QUARK);
+}
+
+void setGoElementData (GstElement * element, gpointer data)
+{
+	// The qdata releases the previous value when it is replaced, and the last one when the element is finalized.
+	g_object_set_qdata_full(G_OBJECT(element), GO_ELEMENT_DATA_QUARK, data,
+		data == NULL ? NULL : cgoGoElementDataDestroyNotify);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	gopointer "github.com/mattn/go-pointer"
+)
+
+// RegisterGoElementUnsafe registers a new element type deriving from the base class parent, along with an
+// element factory for it with the given name and rank. The type has a sink and a source pad template with the
+// given caps, and implements the GstPreset interface with AddPresetInterface. plugin may be nil to register
+// the element statically.
+//
+// callbacks holds the go implementation of the element and can be retrieved from its instances with
+// GoElementCallbacksUnsafe. It lives for as long as the type, so it is not counted by LiveCallbacks. classInit
+// is called with a pointer to the class of the new type before the factory is registered, to install the
+// virtual methods of the base class that callbacks implements.
+//
+// This is meant for internal usage and is exported for visibility to other packages.
+func RegisterGoElementUnsafe(plugin *Plugin, name string, rank Rank, parent glib.Type, metadata *ElementMetadata, sinkCaps, srcCaps *Caps, callbacks interface{}, classInit func(klass unsafe.Pointer)) error {
+	parentName := C.GoString((*C.char)(unsafe.Pointer(C.g_type_name(C.GType(parent)))))
+	typeName := C.CString(fmt.Sprintf("GstGo%s-%s", strings.TrimPrefix(parentName, "Gst"), name))
+	defer C.free(unsafe.Pointer(typeName))
+	if C.g_type_from_name((*C.gchar)(unsafe.Pointer(typeName))) != 0 {
+		return fmt.Errorf("An element named %s is already registered", name)
+	}
+
+	data := (*C.GoElementClassData)(C.g_malloc0(C.sizeof_GoElementClassData))
+	data.callbacks = C.gpointer(gopointer.Save(callbacks))
+	data.longname = (*C.gchar)(unsafe.Pointer(C.CString(metadata.LongName)))
+	data.klass = (*C.gchar)(unsafe.Pointer(C.CString(metadata.Klass)))
+	data.description = (*C.gchar)(unsafe.Pointer(C.CString(metadata.Description)))
+	data.author = (*C.gchar)(unsafe.Pointer(C.CString(metadata.Author)))
+	data.sinkCaps = C.gst_caps_ref(sinkCaps.Instance())
+	data.srcCaps = C.gst_caps_ref(srcCaps.Instance())
+
+	gtype := C.registerGoElementType(C.GType(parent), (*C.gchar)(unsafe.Pointer(typeName)), data)
+	if gtype == 0 {
+		return fmt.Errorf("Could not register a type for element %s", name)
+	}
+	AddPresetInterface(glib.Type(gtype))
+	// The class is never released, since the type is never unregistered.
+	classInit(unsafe.Pointer(C.g_type_class_ref(gtype)))
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cPlugin *C.GstPlugin
+	if plugin != nil {
+		cPlugin = plugin.Instance()
+	}
+	if !gobool(C.gst_element_register(cPlugin, (*C.gchar)(unsafe.Pointer(cName)), C.guint(rank), gtype)) {
+		return fmt.Errorf("Could not register element %s", name)
+	}
+	return nil
+}
+
+// GoElementCallbacksUnsafe returns the callbacks the type of the given element was registered with through
+// RegisterGoElementUnsafe, or nil if it was not registered from go.
+//
+// This is meant for internal usage and is exported for visibility to other packages.
+func GoElementCallbacksUnsafe(elem unsafe.Pointer) interface{} {
+	ptr := C.goElementCallbacks((*C.GstElement)(elem))
+	if ptr == nil {
+		return nil
+	}
+	return gopointer.Restore(unsafe.Pointer(ptr))
+}
+
+// SetGoElementDataUnsafe stores the given value with the given element instance. Any previous value is
+// released, and the last one is released when the element is finalized.
+//
+// This is meant for internal usage and is exported for visibility to other packages.
+func SetGoElementDataUnsafe(elem unsafe.Pointer, data interface{}) {
+	var ptr unsafe.Pointer
+	if data != nil {
+		ptr = savePointer(data)
+	}
+	C.setGoElementData((*C.GstElement)(elem), C.gpointer(ptr))
+}
+
+// GoElementDataUnsafe returns the value stored with SetGoElementDataUnsafe, or nil if there is none.
+//
+// This is meant for internal usage and is exported for visibility to other packages.
+func GoElementDataUnsafe(elem unsafe.Pointer) interface{} {
+	ptr := C.goElementData((*C.GstElement)(elem))
+	if ptr == nil {
+		return nil
+	}
+	return gopointer.Restore(unsafe.Pointer(ptr))
+}
@@ -21,26 +21,17 @@ import (
 //
 // Use the Buffer and its Map methods to interact with memory in both a read and writable way.
 type Memory struct {
-	ptr *C.GstMemory
+	ptr  *C.GstMemory
+	refs refTracker
 }
 
-// NewMemoryWrapped allocates a new memory block that wraps the given data.
+// NewMemoryWrapped allocates a new memory block that wraps the given data. The data is copied
+// unless it was allocated with AllocCBytes, see NewMemoryWrappedFull.
 //
 // The prefix/padding must be filled with 0 if flags contains MemoryFlagZeroPrefixed
 // and MemoryFlagZeroPadded respectively.
 func NewMemoryWrapped(flags MemoryFlags, data []byte, maxSize, offset, size int64) *Memory {
-	str := string(data)
-	dataPtr := unsafe.Pointer(C.CString(str))
-	mem := C.gst_memory_new_wrapped(
-		C.GstMemoryFlags(flags),
-		(C.gpointer)(dataPtr),
-		C.gsize(maxSize),
-		C.gsize(offset),
-		C.gsize(size),
-		nil, // TODO: Allow user to set userdata for destroy notify function
-		nil, // TODO: Allow user to set destroy notify function
-	)
-	return wrapMemory(mem)
+	return NewMemoryWrappedFull(flags, data, maxSize, offset, size, nil)
 }
 
 // Instance returns the underlying GstMemory instance.
@@ -48,12 +39,12 @@ func (m *Memory) Instance() *C.GstMemory { return C.toGstMemory(unsafe.Pointer(m
 
 // Ref increases the ref count on this memory block by one.
 func (m *Memory) Ref() *Memory {
-	return wrapMemory(C.gst_memory_ref(m.Instance()))
+	return takeMemory(C.gst_memory_ref(m.Instance()))
 }
 
 // Unref decreases the ref count on this memory block by one. When the refcount reaches
 // zero the memory is freed.
-func (m *Memory) Unref() { C.gst_memory_unref(m.Instance()) }
+func (m *Memory) Unref() { unrefMiniObject(m) }
 
 // Allocator returns the allocator for this memory.
 func (m *Memory) Allocator() *Allocator {
@@ -80,7 +71,7 @@ func (m *Memory) Size() int64 { return int64(m.Instance().size) }
 // to the end of the memory region.
 func (m *Memory) Copy(offset, size int64) *Memory {
 	mem := C.gst_memory_copy(m.Instance(), C.gssize(offset), C.gssize(size))
-	return wrapMemory(mem)
+	return takeMemory(mem)
 }
 
 // Map the data inside the memory. This function can return nil if the memory is not readable.
@@ -14,32 +14,59 @@ import (
 // Message is a Go wrapper around a GstMessage. It provides convenience methods for
 // unref-ing and parsing the underlying messages.
 type Message struct {
-	msg *C.GstMessage
+	msg  *C.GstMessage
+	refs refTracker
 }
 
+// FromGstMessageUnsafe wraps the pointer to the given C GstMessage with the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstMessageUnsafe(ptr unsafe.Pointer) *Message { return wrapMessage(C.toGstMessage(ptr)) }
+
+// FromGstMessageUnsafeFull wraps the pointer to the given C GstMessage with the go type, taking ownership
+// of the reference the caller holds on it (transfer full).
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstMessageUnsafeFull(ptr unsafe.Pointer) *Message { return takeMessage(C.toGstMessage(ptr)) }
+
 // Instance returns the underlying GstMessage object.
 func (m *Message) Instance() *C.GstMessage { return C.toGstMessage(unsafe.Pointer(m.msg)) }
 
 // Unref will call `gst_message_unref` on the underlying GstMessage, freeing it from memory.
-func (m *Message) Unref() { C.gst_message_unref((*C.GstMessage)(m.Instance())) }
+func (m *Message) Unref() { unrefMiniObject(m) }
 
 // Ref will increase the ref count on this message. This increases the total amount of times
 // Unref needs to be called before the object is freed from memory. It returns the underlying
 // message object for convenience.
 func (m *Message) Ref() *Message {
-	C.gst_message_ref((*C.GstMessage)(m.Instance()))
+	refMiniObject(m)
 	return m
 }
 
 // Copy will copy this object into a new Message that can be Unrefed separately.
 func (m *Message) Copy() *Message {
 	newNative := C.gst_message_copy((*C.GstMessage)(m.Instance()))
-	return wrapMessage(newNative)
+	return takeMessage(newNative)
+}
+
+// MakeMut makes this message writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and m refers to the copy from then on.
+// Unlike MakeWritable, m remains valid after the call and must be released as usual.
+func (m *Message) MakeMut() {
+	m.msg = C.toGstMessage(unsafe.Pointer(makeMutMiniObject(m)))
 }
 
 // Source returns the source of the message.
 func (m *Message) Source() string { return C.GoString(m.Instance().src.name) }
 
+// Seqnum returns the sequence number of the message. Messages that are caused by other messages
+// or events, such as an EOS message following an EOS event, share their sequence number.
+func (m *Message) Seqnum() uint32 { return uint32(C.gst_message_get_seqnum(m.Instance())) }
+
+// Timestamp returns the timestamp of the message. This is the time at which the message was
+// created, as reported by the system clock, or -1 if it is not set.
+func (m *Message) Timestamp() time.Duration {
+	return clockTimeToDuration(ClockTime(m.Instance().timestamp))
+}
+
 // Type returns the MessageType of the message.
 func (m *Message) Type() MessageType {
 	return MessageType(m.Instance()._type)
@@ -141,7 +168,7 @@ func (m *Message) ParseTags() *TagList {
 	if tagList == nil {
 		return nil
 	}
-	return wrapTagList(tagList)
+	return takeTagList(tagList)
 }
 
 // ParseTOC extracts the TOC from the GstMessage. The TOC returned in the output argument is
@@ -150,7 +177,7 @@ func (m *Message) ParseTOC() (toc *TOC, updated bool) {
 	var gtoc *C.GstToc
 	var gupdated C.gboolean
 	C.gst_message_parse_toc(m.Instance(), &gtoc, &gupdated)
-	return wrapTOC(gtoc), gobool(gupdated)
+	return takeTOC(gtoc), gobool(gupdated)
 }
 
 // ParseStreamStatus parses the stream status type of the message as well as the element
@@ -394,7 +421,9 @@ func (m *Message) ParseResetTime() time.Duration {
 func (m *Message) ParseDeviceAdded() *Device {
 	var device *C.GstDevice
 	C.gst_message_parse_device_added((*C.GstMessage)(m.Instance()), &device)
-	return wrapDevice(toGObject(unsafe.Pointer(device)))
+	obj := wrapDevice(toGObject(unsafe.Pointer(device)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // ParseDeviceRemoved parses a device-removed message. The device-removed message
@@ -403,7 +432,9 @@ func (m *Message) ParseDeviceAdded() *Device {
 func (m *Message) ParseDeviceRemoved() *Device {
 	var device *C.GstDevice
 	C.gst_message_parse_device_removed((*C.GstMessage)(m.Instance()), &device)
-	return wrapDevice(toGObject(unsafe.Pointer(device)))
+	obj := wrapDevice(toGObject(unsafe.Pointer(device)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // ParseDeviceChanged Parses a device-changed message. The device-changed message is
@@ -441,7 +472,9 @@ func (m *Message) ParseStreamCollection() *StreamCollection {
 		(*C.GstMessage)(m.Instance()),
 		&collection,
 	)
-	return wrapStreamCollection(toGObject(unsafe.Pointer(collection)))
+	obj := wrapStreamCollection(toGObject(unsafe.Pointer(collection)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // ParseStreamsSelected parses a streams-selected message.
@@ -451,7 +484,9 @@ func (m *Message) ParseStreamsSelected() *StreamCollection {
 		(*C.GstMessage)(m.Instance()),
 		&collection,
 	)
-	return wrapStreamCollection(toGObject(unsafe.Pointer(collection)))
+	obj := wrapStreamCollection(toGObject(unsafe.Pointer(collection)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // NumRedirectEntries returns the number of redirect entries in a MessageRedirect.
@@ -481,5 +516,5 @@ func (m *Message) ParseRedirectEntryAt(idx int64) (location string, tags *TagLis
 func (m *Message) ParseHaveContext() *Context {
 	var ctx *C.GstContext
 	C.gst_message_parse_have_context(m.Instance(), &ctx)
-	return wrapContext(ctx)
+	return takeContext(ctx)
 }
@@ -27,7 +27,7 @@ func NewApplicationMessage(src interface{}, structure *Structure) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_application(srcObj, structure.Instance()))
+	return takeMessage(C.gst_message_new_application(srcObj, structure.Instance()))
 }
 
 // NewAsyncDoneMessage builds a message that is posted when elements completed an ASYNC state change.
@@ -45,7 +45,7 @@ func NewAsyncDoneMessage(src interface{}, runningTime time.Duration) *Message {
 	} else {
 		cTime = C.GstClockTime(durationToClockTime(runningTime))
 	}
-	return wrapMessage(C.gst_message_new_async_done(
+	return takeMessage(C.gst_message_new_async_done(
 		srcObj,
 		cTime,
 	))
@@ -57,7 +57,7 @@ func NewAsyncStartMessage(src interface{}) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_async_start(srcObj))
+	return takeMessage(C.gst_message_new_async_start(srcObj))
 }
 
 // NewBufferingMessage returns a message that can be posted by an element that needs to buffer data before it
@@ -72,7 +72,7 @@ func NewBufferingMessage(src interface{}, percent int) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_buffering(srcObj, C.gint(percent)))
+	return takeMessage(C.gst_message_new_buffering(srcObj, C.gint(percent)))
 }
 
 // NewClockLostMessage creates a clock lost message. This message is posted whenever the clock is not valid anymore.
@@ -84,7 +84,7 @@ func NewClockLostMessage(src interface{}, clock *Clock) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_clock_lost(srcObj, clock.Instance()))
+	return takeMessage(C.gst_message_new_clock_lost(srcObj, clock.Instance()))
 }
 
 // NewClockProvideMessage creates a clock provide message. This message is posted whenever an element is ready to provide a
@@ -96,7 +96,7 @@ func NewClockProvideMessage(src interface{}, clock *Clock, ready bool) *Message
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_clock_provide(srcObj, clock.Instance(), gboolean(ready)))
+	return takeMessage(C.gst_message_new_clock_provide(srcObj, clock.Instance(), gboolean(ready)))
 }
 
 // NewCustomMessage creates a new custom-typed message. This can be used for anything not handled by other message-specific
@@ -107,9 +107,9 @@ func NewCustomMessage(src interface{}, msgType MessageType, structure *Structure
 		return nil
 	}
 	if structure == nil {
-		return wrapMessage(C.gst_message_new_custom(C.GstMessageType(msgType), srcObj, nil))
+		return takeMessage(C.gst_message_new_custom(C.GstMessageType(msgType), srcObj, nil))
 	}
-	return wrapMessage(C.gst_message_new_custom(C.GstMessageType(msgType), srcObj, structure.Instance()))
+	return takeMessage(C.gst_message_new_custom(C.GstMessageType(msgType), srcObj, structure.Instance()))
 }
 
 // NewDeviceAddedMessage creates a new device-added message. The device-added message is produced by a DeviceProvider or a DeviceMonitor.
@@ -119,7 +119,7 @@ func NewDeviceAddedMessage(src interface{}, device *Device) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_device_added(srcObj, device.Instance()))
+	return takeMessage(C.gst_message_new_device_added(srcObj, device.Instance()))
 }
 
 // NewDeviceChangedMessage creates a new device-changed message. The device-changed message is produced by a DeviceProvider or a DeviceMonitor.
@@ -139,7 +139,7 @@ func NewDeviceRemovedMessage(src interface{}, device *Device) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_device_removed(srcObj, device.Instance()))
+	return takeMessage(C.gst_message_new_device_removed(srcObj, device.Instance()))
 }
 
 // NewDurationChangedMessage creates a new duration changed message. This message is posted by elements that know the duration of a
@@ -149,7 +149,7 @@ func NewDurationChangedMessage(src interface{}) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_duration_changed(srcObj))
+	return takeMessage(C.gst_message_new_duration_changed(srcObj))
 }
 
 // NewElementMessage creates a new element-specific message. This is meant as a generic way of allowing one-way communication from an
@@ -161,9 +161,9 @@ func NewElementMessage(src interface{}, structure *Structure) *Message {
 		return nil
 	}
 	if structure == nil {
-		return wrapMessage(C.gst_message_new_element(srcObj, nil))
+		return takeMessage(C.gst_message_new_element(srcObj, nil))
 	}
-	return wrapMessage(C.gst_message_new_element(srcObj, structure.Instance()))
+	return takeMessage(C.gst_message_new_element(srcObj, structure.Instance()))
 }
 
 // NewEOSMessage creates a new eos message. This message is generated and posted in the sink elements of a Bin. The bin will only forward
@@ -173,7 +173,7 @@ func NewEOSMessage(src interface{}) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_eos(srcObj))
+	return takeMessage(C.gst_message_new_eos(srcObj))
 }
 
 func getErrorMessageParams(err *GError, debugStr string) (*C.GError, *C.gchar) {
@@ -204,7 +204,7 @@ func NewErrorMessage(src interface{}, err *GError, debugStr string, structure *S
 	gerr, gdebugStr := getErrorMessageParams(err, debugStr)
 
 	if structure != nil {
-		return wrapMessage(C.gst_message_new_error_with_details(
+		return takeMessage(C.gst_message_new_error_with_details(
 			srcObj,
 			gerr,
 			gdebugStr,
@@ -212,7 +212,7 @@ func NewErrorMessage(src interface{}, err *GError, debugStr string, structure *S
 		))
 	}
 
-	return wrapMessage(C.gst_message_new_error(
+	return takeMessage(C.gst_message_new_error(
 		srcObj,
 		gerr,
 		gdebugStr,
@@ -225,9 +225,9 @@ func NewHaveContextMessage(src interface{}, ctx *Context) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_have_context(
+	return takeMessage(C.gst_message_new_have_context(
 		srcObj,
-		ctx.Instance(),
+		ctx.give(),
 	))
 }
 
@@ -241,7 +241,7 @@ func NewInfoMessage(src interface{}, err *GError, debugStr string, structure *St
 	gerr, gdebugStr := getErrorMessageParams(err, debugStr)
 
 	if structure != nil {
-		return wrapMessage(C.gst_message_new_info_with_details(
+		return takeMessage(C.gst_message_new_info_with_details(
 			srcObj,
 			gerr,
 			gdebugStr,
@@ -249,7 +249,7 @@ func NewInfoMessage(src interface{}, err *GError, debugStr string, structure *St
 		))
 	}
 
-	return wrapMessage(C.gst_message_new_info(
+	return takeMessage(C.gst_message_new_info(
 		srcObj,
 		gerr,
 		gdebugStr,
@@ -262,7 +262,7 @@ func NewLatencyMessage(src interface{}) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_latency(srcObj))
+	return takeMessage(C.gst_message_new_latency(srcObj))
 }
 
 // NewNeedContextMessage creates a message that is posted when an element needs a specific Context.
@@ -272,7 +272,7 @@ func NewNeedContextMessage(src interface{}, ctxType string) *Message {
 		return nil
 	}
 	cStr := C.CString(ctxType)
-	return wrapMessage(C.gst_message_new_need_context(srcObj, (*C.gchar)(unsafe.Pointer(cStr))))
+	return takeMessage(C.gst_message_new_need_context(srcObj, (*C.gchar)(unsafe.Pointer(cStr))))
 }
 
 // NewNewClockMessage creates a new clock message. This message is posted whenever the pipeline selects a new clock for the pipeline.
@@ -281,7 +281,7 @@ func NewNewClockMessage(src interface{}, clock *Clock) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_new_clock(srcObj, clock.Instance()))
+	return takeMessage(C.gst_message_new_new_clock(srcObj, clock.Instance()))
 }
 
 // NewProgressMessage creates messages that are posted by elements when they use an asynchronous task to perform actions triggered by a state change.
@@ -294,7 +294,7 @@ func NewProgressMessage(src interface{}, progressType ProgressType, code, text s
 	}
 	cCode := (*C.gchar)(unsafe.Pointer(C.CString(code)))
 	cText := (*C.gchar)(unsafe.Pointer(C.CString(text)))
-	return wrapMessage(C.gst_message_new_progress(srcObj, C.GstProgressType(progressType), cCode, cText))
+	return takeMessage(C.gst_message_new_progress(srcObj, C.GstProgressType(progressType), cCode, cText))
 }
 
 // NewPropertyNotifyMessage creates a new message notifying an object's properties have changed. If the
@@ -309,7 +309,7 @@ func NewPropertyNotifyMessage(src interface{}, propName string, val interface{})
 		return nil
 	}
 	cName := (*C.gchar)(unsafe.Pointer(C.CString(propName)))
-	return wrapMessage(C.gst_message_new_property_notify(
+	return takeMessage(C.gst_message_new_property_notify(
 		srcObj,
 		cName,
 		(*C.GValue)(gVal.Native()),
@@ -329,7 +329,7 @@ func NewQoSMessage(src interface{}, live bool, runningTime, streamTime, timestam
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_qos(
+	return takeMessage(C.gst_message_new_qos(
 		srcObj,
 		gboolean(live),
 		C.guint64(durationToClockTime(runningTime)),
@@ -371,7 +371,7 @@ func NewRedirectMessage(src interface{}, location string, tagList *TagList, entr
 	if entryStructure != nil {
 		st = entryStructure.Instance()
 	}
-	return wrapMessage(C.gst_message_new_redirect(
+	return takeMessage(C.gst_message_new_redirect(
 		srcObj,
 		loc, tl, st,
 	))
@@ -401,7 +401,7 @@ func NewRequestStateMessage(src interface{}, state State) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_request_state(srcObj, C.GstState(state)))
+	return takeMessage(C.gst_message_new_request_state(srcObj, C.GstState(state)))
 }
 
 // NewResetTimeMessage creates a message that is posted when the pipeline running-time should be reset to running_time, like after a flushing seek.
@@ -410,7 +410,7 @@ func NewResetTimeMessage(src interface{}, runningTime time.Duration) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_reset_time(srcObj, C.GstClockTime(durationToClockTime(runningTime))))
+	return takeMessage(C.gst_message_new_reset_time(srcObj, C.GstClockTime(durationToClockTime(runningTime))))
 }
 
 // NewSegmentDoneMessage creates a new segment done message. This message is posted by elements that finish playback of a segment as a result of a
@@ -420,7 +420,7 @@ func NewSegmentDoneMessage(src interface{}, format Format, position int64) *Mess
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_segment_done(
+	return takeMessage(C.gst_message_new_segment_done(
 		srcObj,
 		C.GstFormat(format),
 		C.gint64(position),
@@ -434,7 +434,7 @@ func NewSegmentStartMessage(src interface{}, format Format, position int64) *Mes
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_segment_start(
+	return takeMessage(C.gst_message_new_segment_start(
 		srcObj,
 		C.GstFormat(format),
 		C.gint64(position),
@@ -447,7 +447,7 @@ func NewStateChangedMessage(src interface{}, oldState, newState, pendingState St
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_state_changed(
+	return takeMessage(C.gst_message_new_state_changed(
 		srcObj,
 		C.GstState(oldState), C.GstState(newState), C.GstState(pendingState),
 	))
@@ -460,7 +460,7 @@ func NewStateDirtyMessage(src interface{}) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_state_dirty(srcObj))
+	return takeMessage(C.gst_message_new_state_dirty(srcObj))
 }
 
 // NewStepDoneMessage creates a message that is posted by elements when they complete a part, when intermediate set to TRUE, or a
@@ -472,7 +472,7 @@ func NewStepDoneMessage(src interface{}, format Format, amount uint64, rate floa
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_step_done(
+	return takeMessage(C.gst_message_new_step_done(
 		srcObj,
 		C.GstFormat(format),
 		C.guint64(amount),
@@ -495,7 +495,7 @@ func NewStepStartMessage(src interface{}, active bool, format Format, amount uin
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_step_start(
+	return takeMessage(C.gst_message_new_step_start(
 		srcObj,
 		gboolean(active),
 		C.GstFormat(format),
@@ -512,7 +512,7 @@ func NewStreamCollectionMessage(src interface{}, collection *StreamCollection) *
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_stream_collection(srcObj, collection.Instance()))
+	return takeMessage(C.gst_message_new_stream_collection(srcObj, collection.Instance()))
 }
 
 // NewStreamStartMessage creates a new stream_start message. This message is generated and posted in the sink elements of a Bin.
@@ -522,7 +522,7 @@ func NewStreamStartMessage(src interface{}) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_stream_start(srcObj))
+	return takeMessage(C.gst_message_new_stream_start(srcObj))
 }
 
 // NewStreamStatusMessage creates a new stream status message. This message is posted when a streaming thread is created/destroyed or
@@ -532,7 +532,7 @@ func NewStreamStatusMessage(src interface{}, stType StreamStatusType, owner *Ele
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_stream_status(srcObj, C.GstStreamStatusType(stType), owner.Instance()))
+	return takeMessage(C.gst_message_new_stream_status(srcObj, C.GstStreamStatusType(stType), owner.Instance()))
 }
 
 // NewStreamSelectedMessage creates a new steams-selected message. The message is used to announce that an array of streams has been selected.
@@ -546,7 +546,7 @@ func NewStreamSelectedMessage(src interface{}, collection *StreamCollection) *Me
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_streams_selected(srcObj, collection.Instance()))
+	return takeMessage(C.gst_message_new_streams_selected(srcObj, collection.Instance()))
 }
 
 // StreamsSelectedAdd adds the stream to the message
@@ -565,7 +565,9 @@ func (m *Message) StreamsSelectedGetStream(index uint) *Stream {
 	if stream == nil {
 		return nil
 	}
-	return wrapStream(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	obj := wrapStream(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // NewStructureChangeMessage creates a new structure change message. This message is posted when the structure of a pipeline is in the process
@@ -577,7 +579,7 @@ func NewStructureChangeMessage(src interface{}, chgType StructureChangeType, own
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_structure_change(
+	return takeMessage(C.gst_message_new_structure_change(
 		srcObj,
 		C.GstStructureChangeType(chgType),
 		owner.Instance(),
@@ -591,7 +593,7 @@ func NewTagMessage(src interface{}, tagList *TagList) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_tag(srcObj, tagList.Instance()))
+	return takeMessage(C.gst_message_new_tag(srcObj, tagList.give()))
 }
 
 // NewTOCMessage creates a new TOC message. The message is posted by elements that discovered or updated a TOC.
@@ -600,7 +602,7 @@ func NewTOCMessage(src interface{}, toc *TOC, updated bool) *Message {
 	if srcObj == nil {
 		return nil
 	}
-	return wrapMessage(C.gst_message_new_toc(
+	return takeMessage(C.gst_message_new_toc(
 		srcObj,
 		toc.Instance(),
 		gboolean(updated),
@@ -617,7 +619,7 @@ func NewWarningMessage(src interface{}, err *GError, debugStr string, structure
 	gerr, gdebugStr := getErrorMessageParams(err, debugStr)
 
 	if structure != nil {
-		return wrapMessage(C.gst_message_new_warning_with_details(
+		return takeMessage(C.gst_message_new_warning_with_details(
 			srcObj,
 			gerr,
 			gdebugStr,
@@ -625,7 +627,7 @@ func NewWarningMessage(src interface{}, err *GError, debugStr string, structure
 		))
 	}
 
-	return wrapMessage(C.gst_message_new_warning(
+	return takeMessage(C.gst_message_new_warning(
 		srcObj,
 		gerr,
 		gdebugStr,
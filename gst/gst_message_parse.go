@@ -0,0 +1,680 @@
+package gst
+
+// #include "gst.go.h"
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// ParsedMessage is implemented by all the values returned from Message.Parse. It allows for
+// idiomatic handling of messages with a type switch:
+//
+//   switch m := msg.Parse().(type) {
+//   case *gst.ErrorMessage:
+//       fmt.Println("ERROR from", m.Source, ":", m.Message)
+//   case *gst.StateChangedMessage:
+//       fmt.Println(m.Source, "changed state to", m.New)
+//   case *gst.EOSMessage:
+//       fmt.Println("End of stream")
+//   }
+//
+// All values are plain Go copies of the message contents. They hold no references to the original
+// message and can be safely marshaled to JSON for logging.
+type ParsedMessage interface {
+	// Header returns the fields common to all messages.
+	Header() *MessageHeader
+}
+
+// MessageHeader contains the fields common to every ParsedMessage.
+type MessageHeader struct {
+	// Type is the name of the type of the message.
+	Type string `json:"type"`
+	// Source is the name of the object that posted the message, if any.
+	Source string `json:"source,omitempty"`
+	// Seqnum is the sequence number of the message.
+	Seqnum uint32 `json:"seqnum"`
+	// Timestamp is the time the message was created, or -1 if not set.
+	Timestamp time.Duration `json:"timestamp"`
+}
+
+// Header implements ParsedMessage.
+func (h *MessageHeader) Header() *MessageHeader { return h }
+
+// EOSMessage is the parsed value of a MessageEOS.
+type EOSMessage struct {
+	MessageHeader
+}
+
+// ErrorMessage is the parsed value of a MessageError.
+type ErrorMessage struct {
+	MessageHeader
+	// Message is the message of the GError.
+	Message string `json:"message"`
+	// Domain is the name of the GError domain.
+	Domain string `json:"domain"`
+	// Code is the code of the GError within its domain.
+	Code int `json:"code"`
+	// Debug contains any additional debug information.
+	Debug string `json:"debug,omitempty"`
+	// Details is the serialized details structure, if one was attached to the message.
+	Details string `json:"details,omitempty"`
+}
+
+// WarningMessage is the parsed value of a MessageWarning. It contains the same fields as an
+// ErrorMessage.
+type WarningMessage ErrorMessage
+
+// InfoMessage is the parsed value of a MessageInfo. It contains the same fields as an
+// ErrorMessage.
+type InfoMessage ErrorMessage
+
+// TagMessage is the parsed value of a MessageTag.
+type TagMessage struct {
+	MessageHeader
+	// Tags is the serialized tag list. Use Message.ParseTags to retrieve the TagList itself.
+	Tags string `json:"tags"`
+}
+
+// BufferingMessage is the parsed value of a MessageBuffering.
+type BufferingMessage struct {
+	MessageHeader
+	// Percent is the buffering percentage.
+	Percent int `json:"percent"`
+	// Mode is the buffering mode.
+	Mode BufferingMode `json:"mode"`
+	// AverageIn is the average input rate.
+	AverageIn int `json:"averageIn"`
+	// AverageOut is the average output rate.
+	AverageOut int `json:"averageOut"`
+	// BufferingLeft is the amount of time until buffering is complete.
+	BufferingLeft time.Duration `json:"bufferingLeft"`
+}
+
+// StateChangedMessage is the parsed value of a MessageStateChanged.
+type StateChangedMessage struct {
+	MessageHeader
+	Old     State `json:"old"`
+	New     State `json:"new"`
+	Pending State `json:"pending"`
+}
+
+// StateDirtyMessage is the parsed value of a MessageStateDirty.
+type StateDirtyMessage struct {
+	MessageHeader
+}
+
+// StepDoneMessage is the parsed value of a MessageStepDone.
+type StepDoneMessage struct {
+	MessageHeader
+	StepDoneValues
+}
+
+// StepStartMessage is the parsed value of a MessageStepStart.
+type StepStartMessage struct {
+	MessageHeader
+	StepStartValues
+}
+
+// ClockProvideMessage is the parsed value of a MessageClockProvide.
+type ClockProvideMessage struct {
+	MessageHeader
+	// Clock is the name of the clock being provided.
+	Clock string `json:"clock"`
+	// Ready is true if the clock is ready to be used.
+	Ready bool `json:"ready"`
+}
+
+// ClockLostMessage is the parsed value of a MessageClockLost.
+type ClockLostMessage struct {
+	MessageHeader
+	// Clock is the name of the clock that was lost.
+	Clock string `json:"clock"`
+}
+
+// NewClockMessage is the parsed value of a MessageNewClock.
+type NewClockMessage struct {
+	MessageHeader
+	// Clock is the name of the new clock.
+	Clock string `json:"clock"`
+}
+
+// StructureChangeMessage is the parsed value of a MessageStructureChange.
+type StructureChangeMessage struct {
+	MessageHeader
+	ChangeType StructureChangeType `json:"changeType"`
+	// Owner is the name of the element owning the pad being changed.
+	Owner string `json:"owner"`
+	// Busy is true while the change is still in progress.
+	Busy bool `json:"busy"`
+}
+
+// StreamStatusMessage is the parsed value of a MessageStreamStatus.
+type StreamStatusMessage struct {
+	MessageHeader
+	Status StreamStatusType `json:"status"`
+	// Owner is the name of the element owning the stream thread.
+	Owner string `json:"owner"`
+}
+
+// ApplicationMessage is the parsed value of a MessageApplication.
+type ApplicationMessage struct {
+	MessageHeader
+	// Name is the name of the structure carried by the message.
+	Name string `json:"name"`
+	// Structure is the serialized structure carried by the message.
+	Structure string `json:"structure"`
+}
+
+// ElementMessage is the parsed value of a MessageElement.
+type ElementMessage struct {
+	MessageHeader
+	// Name is the name of the structure carried by the message.
+	Name string `json:"name"`
+	// Structure is the serialized structure carried by the message.
+	Structure string `json:"structure"`
+}
+
+// SegmentStartMessage is the parsed value of a MessageSegmentStart.
+type SegmentStartMessage struct {
+	MessageHeader
+	Format   Format `json:"format"`
+	Position int64  `json:"position"`
+}
+
+// SegmentDoneMessage is the parsed value of a MessageSegmentDone.
+type SegmentDoneMessage struct {
+	MessageHeader
+	Format   Format `json:"format"`
+	Position int64  `json:"position"`
+}
+
+// DurationChangedMessage is the parsed value of a MessageDurationChanged.
+type DurationChangedMessage struct {
+	MessageHeader
+}
+
+// LatencyMessage is the parsed value of a MessageLatency.
+type LatencyMessage struct {
+	MessageHeader
+}
+
+// AsyncStartMessage is the parsed value of a MessageAsyncStart.
+type AsyncStartMessage struct {
+	MessageHeader
+}
+
+// AsyncDoneMessage is the parsed value of a MessageAsyncDone.
+type AsyncDoneMessage struct {
+	MessageHeader
+	// RunningTime is the desired running time, or -1 if none.
+	RunningTime time.Duration `json:"runningTime"`
+}
+
+// RequestStateMessage is the parsed value of a MessageRequestState.
+type RequestStateMessage struct {
+	MessageHeader
+	State State `json:"state"`
+}
+
+// QoSMessage is the parsed value of a MessageQoS. The values reflect those of the dropped buffer.
+// Values of -1 mean unknown values.
+type QoSMessage struct {
+	MessageHeader
+	// Live is true if the message was generated by a live element.
+	Live bool `json:"live"`
+	// RunningTime is the running time of the buffer that generated the message.
+	RunningTime time.Duration `json:"runningTime"`
+	// StreamTime is the stream time of the buffer that generated the message.
+	StreamTime time.Duration `json:"streamTime"`
+	// BufferTimestamp is the timestamp of the buffer that generated the message.
+	BufferTimestamp time.Duration `json:"bufferTimestamp"`
+	// Duration is the duration of the buffer that generated the message.
+	Duration time.Duration `json:"duration"`
+	// Jitter is the difference between the running time of the buffer and the time it should
+	// have been rendered.
+	Jitter int64 `json:"jitter"`
+	// Proportion is the long term prediction of the ideal rate relative to normal rate.
+	Proportion float64 `json:"proportion"`
+	// Quality is an element dependant integer value that specifies the current quality level.
+	Quality int `json:"quality"`
+	// Format is the units of the Processed and Dropped fields.
+	Format Format `json:"format"`
+	// Processed is the total number of units correctly processed since the last state change.
+	Processed uint64 `json:"processed"`
+	// Dropped is the total number of units dropped since the last state change.
+	Dropped uint64 `json:"dropped"`
+}
+
+// ProgressMessage is the parsed value of a MessageProgress.
+type ProgressMessage struct {
+	MessageHeader
+	ProgressType ProgressType `json:"progressType"`
+	Code         string       `json:"code"`
+	Text         string       `json:"text"`
+}
+
+// TOCMessage is the parsed value of a MessageTOC.
+type TOCMessage struct {
+	MessageHeader
+	// Updated is true if the TOC was updated rather than newly found. Use Message.ParseTOC
+	// to retrieve the TOC itself.
+	Updated bool `json:"updated"`
+}
+
+// ResetTimeMessage is the parsed value of a MessageResetTime.
+type ResetTimeMessage struct {
+	MessageHeader
+	RunningTime time.Duration `json:"runningTime"`
+}
+
+// StreamStartMessage is the parsed value of a MessageStreamStart.
+type StreamStartMessage struct {
+	MessageHeader
+	// GroupID is the group id of the stream, valid if HasGroupID is true.
+	GroupID    uint `json:"groupID"`
+	HasGroupID bool `json:"hasGroupID"`
+}
+
+// NeedContextMessage is the parsed value of a MessageNeedContext.
+type NeedContextMessage struct {
+	MessageHeader
+	ContextType string `json:"contextType"`
+}
+
+// HaveContextMessage is the parsed value of a MessageHaveContext.
+type HaveContextMessage struct {
+	MessageHeader
+	ContextType string `json:"contextType"`
+	// Structure is the serialized structure of the context.
+	Structure string `json:"structure"`
+}
+
+// DeviceAddedMessage is the parsed value of a MessageDeviceAdded.
+type DeviceAddedMessage struct {
+	MessageHeader
+	// Device is the display name of the device.
+	Device string `json:"device"`
+}
+
+// DeviceRemovedMessage is the parsed value of a MessageDeviceRemoved.
+type DeviceRemovedMessage struct {
+	MessageHeader
+	// Device is the display name of the device.
+	Device string `json:"device"`
+}
+
+// PropertyNotifyMessage is the parsed value of a MessagePropertyNotify.
+type PropertyNotifyMessage struct {
+	MessageHeader
+	// Object is the path of the object whose property changed.
+	Object   string `json:"object"`
+	Property string `json:"property"`
+	// Value is a string representation of the new value, if it was included in the message.
+	Value string `json:"value,omitempty"`
+}
+
+// StreamInfo describes a Stream contained in a ParsedMessage or ParsedEvent.
+type StreamInfo struct {
+	StreamID string      `json:"streamID"`
+	Type     StreamType  `json:"type"`
+	Flags    StreamFlags `json:"flags"`
+	// Caps is the serialized caps of the stream, if known.
+	Caps string `json:"caps,omitempty"`
+}
+
+// StreamCollectionMessage is the parsed value of a MessageStreamCollection.
+type StreamCollectionMessage struct {
+	MessageHeader
+	UpstreamID string       `json:"upstreamID"`
+	Streams    []StreamInfo `json:"streams"`
+}
+
+// StreamsSelectedMessage is the parsed value of a MessageStreamsSelected.
+type StreamsSelectedMessage struct {
+	MessageHeader
+	// UpstreamID is the upstream id of the collection the streams were selected from.
+	UpstreamID string `json:"upstreamID"`
+	// Streams are the streams that were selected.
+	Streams []StreamInfo `json:"streams"`
+}
+
+// RedirectEntry is a single entry in a RedirectMessage.
+type RedirectEntry struct {
+	Location  string `json:"location"`
+	Tags      string `json:"tags,omitempty"`
+	Structure string `json:"structure,omitempty"`
+}
+
+// RedirectMessage is the parsed value of a MessageRedirect.
+type RedirectMessage struct {
+	MessageHeader
+	Entries []RedirectEntry `json:"entries"`
+}
+
+// UnknownMessage is returned from Message.Parse for message types without a dedicated value.
+type UnknownMessage struct {
+	MessageHeader
+	// Structure is the serialized structure of the message, if it has one.
+	Structure string `json:"structure,omitempty"`
+}
+
+// Parse parses the message into a ParsedMessage. The concrete type of the returned value depends
+// on the type of the message, for example a MessageError is parsed into an *ErrorMessage. Messages
+// without a dedicated type are returned as an *UnknownMessage.
+func (m *Message) Parse() ParsedMessage {
+	msg := m.Instance()
+	hdr := MessageHeader{
+		Type:      m.TypeName(),
+		Seqnum:    m.Seqnum(),
+		Timestamp: m.Timestamp(),
+	}
+	if msg.src != nil {
+		hdr.Source = C.GoString(msg.src.name)
+	}
+
+	switch m.Type() {
+	case MessageEOS:
+		return &EOSMessage{hdr}
+	case MessageError:
+		out := ErrorMessage{MessageHeader: hdr}
+		var gerr *C.GError
+		var debugInfo *C.gchar
+		var details *C.GstStructure
+		C.gst_message_parse_error(msg, &gerr, &debugInfo)
+		C.gst_message_parse_error_details(msg, &details)
+		out.fill(gerr, debugInfo, details)
+		return &out
+	case MessageWarning:
+		out := ErrorMessage{MessageHeader: hdr}
+		var gerr *C.GError
+		var debugInfo *C.gchar
+		var details *C.GstStructure
+		C.gst_message_parse_warning(msg, &gerr, &debugInfo)
+		C.gst_message_parse_warning_details(msg, &details)
+		out.fill(gerr, debugInfo, details)
+		warn := WarningMessage(out)
+		return &warn
+	case MessageInfo:
+		out := ErrorMessage{MessageHeader: hdr}
+		var gerr *C.GError
+		var debugInfo *C.gchar
+		var details *C.GstStructure
+		C.gst_message_parse_info(msg, &gerr, &debugInfo)
+		C.gst_message_parse_info_details(msg, &details)
+		out.fill(gerr, debugInfo, details)
+		info := InfoMessage(out)
+		return &info
+	case MessageTag:
+		var tagList *C.GstTagList
+		C.gst_message_parse_tag(msg, &tagList)
+		out := &TagMessage{MessageHeader: hdr}
+		if tagList != nil {
+			defer C.gst_tag_list_unref(tagList)
+			out.Tags = takeGString(C.gst_tag_list_to_string(tagList))
+		}
+		return out
+	case MessageBuffering:
+		out := &BufferingMessage{MessageHeader: hdr, Percent: m.ParseBuffering()}
+		stats := m.ParseBufferingStats()
+		out.Mode, out.AverageIn, out.AverageOut, out.BufferingLeft = stats.BufferingMode, stats.AverageIn, stats.AverageOut, stats.BufferingLeft
+		return out
+	case MessageStateChanged:
+		var oldState, newState, pending C.GstState
+		C.gst_message_parse_state_changed(msg, &oldState, &newState, &pending)
+		return &StateChangedMessage{MessageHeader: hdr, Old: State(oldState), New: State(newState), Pending: State(pending)}
+	case MessageStateDirty:
+		return &StateDirtyMessage{hdr}
+	case MessageStepDone:
+		return &StepDoneMessage{MessageHeader: hdr, StepDoneValues: *m.ParseStepDone()}
+	case MessageStepStart:
+		return &StepStartMessage{MessageHeader: hdr, StepStartValues: *m.ParseStepStart()}
+	case MessageClockProvide:
+		var clock *C.GstClock
+		var ready C.gboolean
+		C.gst_message_parse_clock_provide(msg, &clock, &ready)
+		return &ClockProvideMessage{MessageHeader: hdr, Clock: gstObjectName(unsafe.Pointer(clock)), Ready: gobool(ready)}
+	case MessageClockLost:
+		var clock *C.GstClock
+		C.gst_message_parse_clock_lost(msg, &clock)
+		return &ClockLostMessage{MessageHeader: hdr, Clock: gstObjectName(unsafe.Pointer(clock))}
+	case MessageNewClock:
+		var clock *C.GstClock
+		C.gst_message_parse_new_clock(msg, &clock)
+		return &NewClockMessage{MessageHeader: hdr, Clock: gstObjectName(unsafe.Pointer(clock))}
+	case MessageStructureChange:
+		var chgType C.GstStructureChangeType
+		var owner *C.GstElement
+		var busy C.gboolean
+		C.gst_message_parse_structure_change(msg, &chgType, &owner, &busy)
+		return &StructureChangeMessage{MessageHeader: hdr, ChangeType: StructureChangeType(chgType), Owner: gstObjectName(unsafe.Pointer(owner)), Busy: gobool(busy)}
+	case MessageStreamStatus:
+		var statusType C.GstStreamStatusType
+		var owner *C.GstElement
+		C.gst_message_parse_stream_status(msg, &statusType, &owner)
+		return &StreamStatusMessage{MessageHeader: hdr, Status: StreamStatusType(statusType), Owner: gstObjectName(unsafe.Pointer(owner))}
+	case MessageApplication:
+		st := C.gst_message_get_structure(msg)
+		return &ApplicationMessage{MessageHeader: hdr, Name: gstStructureName(st), Structure: gstStructureString(st)}
+	case MessageElement:
+		st := C.gst_message_get_structure(msg)
+		return &ElementMessage{MessageHeader: hdr, Name: gstStructureName(st), Structure: gstStructureString(st)}
+	case MessageSegmentStart:
+		format, position := m.ParseSegmentStart()
+		return &SegmentStartMessage{MessageHeader: hdr, Format: format, Position: position}
+	case MessageSegmentDone:
+		format, position := m.ParseSegmentDone()
+		return &SegmentDoneMessage{MessageHeader: hdr, Format: format, Position: position}
+	case MessageDurationChanged:
+		return &DurationChangedMessage{hdr}
+	case MessageLatency:
+		return &LatencyMessage{hdr}
+	case MessageAsyncStart:
+		return &AsyncStartMessage{hdr}
+	case MessageAsyncDone:
+		return &AsyncDoneMessage{MessageHeader: hdr, RunningTime: m.ParseAsyncDone()}
+	case MessageRequestState:
+		return &RequestStateMessage{MessageHeader: hdr, State: m.ParseRequestState()}
+	case MessageQoS:
+		out := &QoSMessage{MessageHeader: hdr}
+		var live C.gboolean
+		var runningTime, streamTime, timestamp, duration C.guint64
+		var jitter C.gint64
+		var proportion C.gdouble
+		var quality C.gint
+		var format C.GstFormat
+		var processed, dropped C.guint64
+		C.gst_message_parse_qos(msg, &live, &runningTime, &streamTime, &timestamp, &duration)
+		C.gst_message_parse_qos_values(msg, &jitter, &proportion, &quality)
+		C.gst_message_parse_qos_stats(msg, &format, &processed, &dropped)
+		out.Live = gobool(live)
+		out.RunningTime, out.StreamTime = guint64ToDuration(runningTime), guint64ToDuration(streamTime)
+		out.BufferTimestamp, out.Duration = guint64ToDuration(timestamp), guint64ToDuration(duration)
+		out.Jitter, out.Proportion, out.Quality = int64(jitter), float64(proportion), int(quality)
+		out.Format, out.Processed, out.Dropped = Format(format), uint64(processed), uint64(dropped)
+		return out
+	case MessageProgress:
+		var progressType C.GstProgressType
+		var code, text *C.gchar
+		C.gst_message_parse_progress(msg, &progressType, &code, &text)
+		return &ProgressMessage{MessageHeader: hdr, ProgressType: ProgressType(progressType), Code: takeGString(code), Text: takeGString(text)}
+	case MessageTOC:
+		var toc *C.GstToc
+		var updated C.gboolean
+		C.gst_message_parse_toc(msg, &toc, &updated)
+		if toc != nil {
+			C.gst_toc_unref(toc)
+		}
+		return &TOCMessage{MessageHeader: hdr, Updated: gobool(updated)}
+	case MessageResetTime:
+		return &ResetTimeMessage{MessageHeader: hdr, RunningTime: m.ParseResetTime()}
+	case MessageStreamStart:
+		var groupID C.guint
+		ok := C.gst_message_parse_group_id(msg, &groupID)
+		return &StreamStartMessage{MessageHeader: hdr, GroupID: uint(groupID), HasGroupID: gobool(ok)}
+	case MessageNeedContext:
+		var ctxType *C.gchar
+		C.gst_message_parse_context_type(msg, &ctxType)
+		return &NeedContextMessage{MessageHeader: hdr, ContextType: C.GoString(ctxType)}
+	case MessageHaveContext:
+		var ctx *C.GstContext
+		C.gst_message_parse_have_context(msg, &ctx)
+		out := &HaveContextMessage{MessageHeader: hdr}
+		if ctx != nil {
+			defer C.gst_context_unref(ctx)
+			out.ContextType = C.GoString(C.gst_context_get_context_type(ctx))
+			out.Structure = gstStructureString(C.gst_context_get_structure(ctx))
+		}
+		return out
+	case MessageDeviceAdded:
+		var device *C.GstDevice
+		C.gst_message_parse_device_added(msg, &device)
+		return &DeviceAddedMessage{MessageHeader: hdr, Device: takeDeviceName(device)}
+	case MessageDeviceRemoved:
+		var device *C.GstDevice
+		C.gst_message_parse_device_removed(msg, &device)
+		return &DeviceRemovedMessage{MessageHeader: hdr, Device: takeDeviceName(device)}
+	case MessagePropertyNotify:
+		var obj *C.GstObject
+		var name *C.gchar
+		var value *C.GValue
+		C.gst_message_parse_property_notify(msg, &obj, &name, &value)
+		out := &PropertyNotifyMessage{MessageHeader: hdr, Property: C.GoString(name)}
+		if obj != nil {
+			out.Object = takeGString(C.gst_object_get_path_string(obj))
+		}
+		if value != nil {
+			out.Value = takeGString(C.g_strdup_value_contents(value))
+		}
+		return out
+	case MessageStreamCollection:
+		var collection *C.GstStreamCollection
+		C.gst_message_parse_stream_collection(msg, &collection)
+		out := &StreamCollectionMessage{MessageHeader: hdr}
+		if collection != nil {
+			defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(collection)))
+			out.UpstreamID, out.Streams = streamCollectionInfo(collection)
+		}
+		return out
+	case MessageStreamsSelected:
+		var collection *C.GstStreamCollection
+		C.gst_message_parse_streams_selected(msg, &collection)
+		out := &StreamsSelectedMessage{MessageHeader: hdr}
+		if collection != nil {
+			defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(collection)))
+			out.UpstreamID = C.GoString(C.gst_stream_collection_get_upstream_id(collection))
+		}
+		size := C.gst_message_streams_selected_get_size(msg)
+		out.Streams = make([]StreamInfo, 0, int(size))
+		for i := C.guint(0); i < size; i++ {
+			stream := C.gst_message_streams_selected_get_stream(msg, i)
+			if stream == nil {
+				continue
+			}
+			out.Streams = append(out.Streams, streamInfo(stream))
+			C.gst_object_unref((C.gpointer)(unsafe.Pointer(stream)))
+		}
+		return out
+	case MessageRedirect:
+		size := C.gst_message_get_num_redirect_entries(msg)
+		out := &RedirectMessage{MessageHeader: hdr, Entries: make([]RedirectEntry, 0, int(size))}
+		for i := C.gsize(0); i < size; i++ {
+			var location *C.gchar
+			var tags *C.GstTagList
+			var st *C.GstStructure
+			C.gst_message_parse_redirect_entry(msg, i, &location, &tags, &st)
+			entry := RedirectEntry{Location: C.GoString(location), Structure: gstStructureString(st)}
+			if tags != nil {
+				entry.Tags = takeGString(C.gst_tag_list_to_string(tags))
+			}
+			out.Entries = append(out.Entries, entry)
+		}
+		return out
+	}
+
+	return &UnknownMessage{MessageHeader: hdr, Structure: gstStructureString(C.gst_message_get_structure(msg))}
+}
+
+// fill populates the error fields from a parsed GError and takes ownership of the GError and debug
+// string. The details structure is owned by the message.
+func (e *ErrorMessage) fill(gerr *C.GError, debugInfo *C.gchar, details *C.GstStructure) {
+	e.Debug = takeGString(debugInfo)
+	e.Details = gstStructureString(details)
+	if gerr == nil {
+		return
+	}
+	defer C.g_error_free(gerr)
+	e.Message = C.GoString(gerr.message)
+	e.Domain = C.GoString(C.g_quark_to_string(gerr.domain))
+	e.Code = int(gerr.code)
+}
+
+// streamCollectionInfo returns the upstream id and stream information of a collection.
+func streamCollectionInfo(collection *C.GstStreamCollection) (string, []StreamInfo) {
+	size := C.gst_stream_collection_get_size(collection)
+	streams := make([]StreamInfo, 0, int(size))
+	for i := C.guint(0); i < size; i++ {
+		if stream := C.gst_stream_collection_get_stream(collection, i); stream != nil {
+			streams = append(streams, streamInfo(stream))
+		}
+	}
+	return C.GoString(C.gst_stream_collection_get_upstream_id(collection)), streams
+}
+
+// streamInfo copies the description of a stream into a StreamInfo.
+func streamInfo(stream *C.GstStream) StreamInfo {
+	info := StreamInfo{
+		StreamID: C.GoString(C.gst_stream_get_stream_id(stream)),
+		Type:     StreamType(C.gst_stream_get_stream_type(stream)),
+		Flags:    StreamFlags(C.gst_stream_get_stream_flags(stream)),
+	}
+	if caps := C.gst_stream_get_caps(stream); caps != nil {
+		info.Caps = takeGString(C.gst_caps_to_string(caps))
+		C.gst_caps_unref(caps)
+	}
+	return info
+}
+
+// takeDeviceName returns the display name of the device and releases the reference on it.
+func takeDeviceName(device *C.GstDevice) string {
+	if device == nil {
+		return ""
+	}
+	defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(device)))
+	return takeGString(C.gst_device_get_display_name(device))
+}
+
+// gstObjectName returns the name of the GstObject at the given pointer, or an empty string if
+// it is nil.
+func gstObjectName(obj unsafe.Pointer) string {
+	if obj == nil {
+		return ""
+	}
+	return C.GoString((*C.GstObject)(obj).name)
+}
+
+// gstStructureName returns the name of the structure, or an empty string if it is nil.
+func gstStructureName(st *C.GstStructure) string {
+	if st == nil {
+		return ""
+	}
+	return C.GoString(C.gst_structure_get_name(st))
+}
+
+// gstStructureString serializes the structure, returning an empty string if it is nil.
+func gstStructureString(st *C.GstStructure) string {
+	if st == nil {
+		return ""
+	}
+	return takeGString(C.gst_structure_to_string(st))
+}
+
+// takeGString converts the given string to a Go string and frees it.
+func takeGString(str *C.gchar) string {
+	if str == nil {
+		return ""
+	}
+	defer C.g_free((C.gpointer)(unsafe.Pointer(str)))
+	return C.GoString(str)
+}
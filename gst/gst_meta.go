@@ -55,8 +55,8 @@ type MetaInfo struct {
 
 // RegisterAPIType registers and returns a GType for the given api name and associates it with tags.
 func RegisterAPIType(name string, tags []string) glib.Type {
-	cTags := gcharStrings(tags)
-	defer C.g_free((C.gpointer)(unsafe.Pointer(cTags)))
+	cTags := newStrv(tags)
+	defer C.g_strfreev(cTags)
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	newType := C.gst_meta_api_type_register((*C.gchar)(cName), cTags)
@@ -89,10 +89,19 @@ type MetaInitFunc func(params interface{}, buffer *Buffer) bool
 type MetaFreeFunc func(buffer *Buffer)
 
 // MetaTransformFunc is a function called for each meta in buf as a result
-// of performing a transformation on transbuf. Additional type specific transform
-// data is passed to the function as data.
+// of performing a transformation on transbuf. The copy data is passed to the function as data
+// when mType is MetaTransformCopyType, otherwise data is nil.
 type MetaTransformFunc func(transBuf, buf *Buffer, mType string, data *MetaTransformCopy) bool
 
+// Transform types passed to a MetaTransformFunc.
+const (
+	// MetaTransformCopyType is used when a buffer, or a region of it, is copied. The transform data
+	// is a MetaTransformCopy.
+	MetaTransformCopyType = "gst-copy"
+	// MetaTransformScaleType is used by the video library when a video frame is scaled.
+	MetaTransformScaleType = "gst-video-scale"
+)
+
 // MetaTransformCopy is extra data passed to a MetaTransformFunc
 type MetaTransformCopy struct {
 	// true if only region is copied
@@ -103,6 +112,20 @@ type MetaTransformCopy struct {
 	Size int64
 }
 
+// wrapMetaTransformData returns the MetaTransformCopy for the data passed to a transform function, or nil if
+// the transform is not a copy.
+func wrapMetaTransformData(mType string, data C.gpointer) *MetaTransformCopy {
+	if mType != MetaTransformCopyType || data == nil {
+		return nil
+	}
+	transformData := (*C.GstMetaTransformCopy)(unsafe.Pointer(data))
+	return &MetaTransformCopy{
+		Region: gobool(transformData.region),
+		Offset: int64(transformData.offset),
+		Size:   int64(transformData.size),
+	}
+}
+
 // MetaInfoCallbackFuncs represents callback functions to includ when registering a new
 // meta type.
 type MetaInfoCallbackFuncs struct {
@@ -10,7 +10,10 @@ import (
 )
 
 // Object is a go representation of a GstObject.
-type Object struct{ *glib.InitiallyUnowned }
+type Object struct {
+	*glib.InitiallyUnowned
+	refs refTracker
+}
 
 // Unsafe returns the unsafe pointer to the underlying object. This method is primarily
 // for internal usage and is exposed for visibility in other packages.
@@ -0,0 +1,283 @@
+package gst
+
+// #include "gst.go.h"
+import "C"
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// OwnershipMode represents the way references to GstMiniObjects (buffers, caps, events, messages, etc.)
+// and GstObjects (elements, pads, buses, etc.) are managed by the go wrappers around them.
+type OwnershipMode int32
+
+// Type castings of OwnershipModes
+const (
+	// OwnershipManual is the default mode. Wrappers do not hold references of their own, and the caller
+	// is responsible for calling Unref on any object it owns, as documented on each function.
+	OwnershipManual OwnershipMode = iota
+	// OwnershipFinalizers makes every wrapper own a reference to the object it wraps. The reference is
+	// released by a finalizer when the wrapper is garbage collected, so calling Unref is only needed to
+	// release it early. Functions that take ownership of an object (e.g. Pad.Push or Bus.Post) take the
+	// reference held by the wrapper, after which the wrapper must no longer be used.
+	//
+	// Note that a wrapper keeping a reference also means the object is not writable by anyone else until
+	// the wrapper is released. Call Unref on buffers and events received in callbacks, such as pad probes,
+	// once you are done with them to avoid unnecessary copies further down the pipeline.
+	OwnershipFinalizers
+)
+
+// String implements a stringer on an OwnershipMode.
+func (o OwnershipMode) String() string {
+	switch o {
+	case OwnershipManual:
+		return "manual"
+	case OwnershipFinalizers:
+		return "finalizers"
+	}
+	return "unknown"
+}
+
+var ownershipMode int32
+
+// SetOwnershipMode sets the way references are managed by wrappers created from this point on. Wrappers
+// that already exist keep the mode they were created with, so this should be called once, right after Init
+// and before any objects are created.
+func SetOwnershipMode(mode OwnershipMode) { atomic.StoreInt32(&ownershipMode, int32(mode)) }
+
+// GetOwnershipMode returns the current OwnershipMode.
+func GetOwnershipMode() OwnershipMode { return OwnershipMode(atomic.LoadInt32(&ownershipMode)) }
+
+func managedOwnership() bool { return GetOwnershipMode() == OwnershipFinalizers }
+
+// LeakReport contains information about a wrapper that was garbage collected while it still
+// held references to the object it wraps.
+type LeakReport struct {
+	// The name of the type of the object, e.g. GstBuffer.
+	Type string
+	// The number of references that were still held by the wrapper.
+	Refs int
+	// True if the references were released by the finalizer (OwnershipFinalizers), false if
+	// they were leaked (OwnershipManual).
+	Released bool
+	// The stack of the goroutine that created the wrapper.
+	Stack string
+}
+
+var (
+	leakReporter   func(*LeakReport)
+	leakReporterMu sync.RWMutex
+)
+
+// SetLeakReporter enables the leak debug mode. While it is enabled, every wrapper that owns a reference
+// records the stack it was created from, and f is called from the finalizer of any wrapper that is garbage
+// collected without having released its references with Unref, or without having passed them on to a
+// function taking ownership.
+//
+// With OwnershipManual this reports objects that were leaked. With OwnershipFinalizers it reports objects
+// that were only released by the garbage collector, which is useful for finding large objects that should be
+// released early. Passing nil disables the leak debug mode. Recording stacks is expensive, so this should
+// only be used during development.
+//
+//   gst.SetLeakReporter(func(r *gst.LeakReport) {
+//       fmt.Printf("%s finalized with %d refs, created at:\n%s\n", r.Type, r.Refs, r.Stack)
+//   })
+func SetLeakReporter(f func(*LeakReport)) {
+	leakReporterMu.Lock()
+	defer leakReporterMu.Unlock()
+	leakReporter = f
+}
+
+func getLeakReporter() func(*LeakReport) {
+	leakReporterMu.RLock()
+	defer leakReporterMu.RUnlock()
+	return leakReporter
+}
+
+// refTracker keeps count of the references owned by a wrapper.
+type refTracker struct {
+	refs    int32
+	tracked bool
+	managed bool
+	stack   string
+}
+
+// start starts tracking a single reference owned by the wrapper.
+func (t *refTracker) start(managed bool) {
+	t.refs = 1
+	t.tracked = true
+	t.managed = managed
+	if getLeakReporter() != nil {
+		t.stack = string(debug.Stack())
+	}
+}
+
+// acquire records an additional reference owned by the wrapper.
+func (t *refTracker) acquire() {
+	if t.tracked {
+		atomic.AddInt32(&t.refs, 1)
+	}
+}
+
+// release records a reference released by the wrapper. It returns false if the
+// wrapper did not own any references.
+func (t *refTracker) release() bool {
+	for {
+		refs := atomic.LoadInt32(&t.refs)
+		if refs <= 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&t.refs, refs, refs-1) {
+			return true
+		}
+	}
+}
+
+// finalize releases all the references held by the wrapper, reporting them if the leak debug
+// mode is enabled. It returns the number of references that need to be unrefed.
+func (t *refTracker) finalize(typeName string) int {
+	refs := int(atomic.SwapInt32(&t.refs, 0))
+	if refs <= 0 {
+		return 0
+	}
+	if report := getLeakReporter(); report != nil {
+		report(&LeakReport{
+			Type:     typeName,
+			Refs:     refs,
+			Released: t.managed,
+			Stack:    t.stack,
+		})
+	}
+	if !t.managed {
+		return 0
+	}
+	return refs
+}
+
+// miniObjectWrapper is implemented by the wrappers of GstMiniObjects.
+type miniObjectWrapper interface {
+	miniObject() *C.GstMiniObject
+	tracker() *refTracker
+}
+
+// trackMiniObject starts tracking the references held by the given wrapper. If owned is false, the
+// object is borrowed and a reference is taken when the ownership mode is OwnershipFinalizers.
+func trackMiniObject(w miniObjectWrapper, owned bool) {
+	if w.miniObject() == nil {
+		return
+	}
+	managed := managedOwnership()
+	if !managed && (!owned || getLeakReporter() == nil) {
+		return
+	}
+	if !owned {
+		C.gst_mini_object_ref(w.miniObject())
+	}
+	w.tracker().start(managed)
+	runtime.SetFinalizer(w, finalizeMiniObject)
+}
+
+func finalizeMiniObject(w miniObjectWrapper) {
+	mini := w.miniObject()
+	for n := w.tracker().finalize(glib.Type(mini._type).Name()); n > 0; n-- {
+		C.gst_mini_object_unref(mini)
+	}
+}
+
+// refMiniObject increases the ref count of the object held by the wrapper.
+func refMiniObject(w miniObjectWrapper) {
+	C.gst_mini_object_ref(w.miniObject())
+	w.tracker().acquire()
+}
+
+// unrefMiniObject decreases the ref count of the object held by the wrapper. When the wrapper manages
+// its references, calling it more times than there are references held is a no-op.
+func unrefMiniObject(w miniObjectWrapper) {
+	if !w.tracker().release() && w.tracker().managed {
+		return
+	}
+	C.gst_mini_object_unref(w.miniObject())
+}
+
+// giveMiniObject returns the object held by the wrapper for passing to a function that takes
+// ownership of it. The reference held by the wrapper is transferred, or a new one is taken if
+// the wrapper manages its references and has none left.
+func giveMiniObject(w miniObjectWrapper) *C.GstMiniObject {
+	if !w.tracker().release() && w.tracker().managed {
+		C.gst_mini_object_ref(w.miniObject())
+	}
+	return w.miniObject()
+}
+
+// makeMutMiniObject returns a writable version of the object held by the wrapper, which the wrapper
+// should replace its own with. When the wrapper manages its references, it is made to hold exactly one
+// before the object is made writable.
+func makeMutMiniObject(w miniObjectWrapper) *C.GstMiniObject {
+	if t := w.tracker(); t.managed {
+		refs := atomic.SwapInt32(&t.refs, 1)
+		if refs == 0 {
+			C.gst_mini_object_ref(w.miniObject())
+		}
+		for ; refs > 1; refs-- {
+			C.gst_mini_object_unref(w.miniObject())
+		}
+	}
+	return C.gst_mini_object_make_writable(w.miniObject())
+}
+
+// trackObject starts tracking the wrapper around a GstObject. The object is assumed to be borrowed,
+// and a reference is taken when the ownership mode is OwnershipFinalizers. adoptObject should be
+// called for objects that are returned with transfer full or floating.
+func trackObject(o *Object) {
+	if o.Unsafe() == nil || !managedOwnership() {
+		return
+	}
+	C.g_object_ref((C.gpointer)(o.Unsafe()))
+	o.refs.start(true)
+	runtime.SetFinalizer(o, finalizeObject)
+}
+
+// adoptObject makes the wrapper take ownership of the reference returned by a transfer full
+// function. If the object is floating, the floating reference is sunk.
+func adoptObject(o *Object) {
+	if o.Unsafe() == nil {
+		return
+	}
+	if !o.refs.tracked {
+		if getLeakReporter() != nil {
+			o.refs.start(false)
+			runtime.SetFinalizer(o, finalizeObject)
+		}
+		return
+	}
+	if gobool(C.g_object_is_floating((C.gpointer)(o.Unsafe()))) {
+		C.g_object_ref_sink((C.gpointer)(o.Unsafe()))
+	}
+	C.g_object_unref((C.gpointer)(o.Unsafe()))
+}
+
+func finalizeObject(o *Object) {
+	for n := o.refs.finalize(o.TypeFromInstance().Name()); n > 0; n-- {
+		C.g_object_unref((C.gpointer)(o.Unsafe()))
+	}
+}
+
+// Ref increases the ref count on the object by one.
+func (o *Object) Ref() {
+	C.g_object_ref((C.gpointer)(o.Unsafe()))
+	o.refs.acquire()
+}
+
+// Unref decreases the ref count on the object by one. With OwnershipFinalizers, the reference held by
+// the wrapper is released early, and calling Unref more times than Ref has been called is a no-op.
+func (o *Object) Unref() {
+	if !o.refs.release() && o.refs.managed {
+		return
+	}
+	C.g_object_unref((C.gpointer)(o.Unsafe()))
+}
@@ -0,0 +1,176 @@
+package gst
+
+import (
+	"runtime"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func setOwnershipMode(t *testing.T, mode OwnershipMode) {
+	t.Helper()
+	prev := GetOwnershipMode()
+	SetOwnershipMode(mode)
+	t.Cleanup(func() { SetOwnershipMode(prev) })
+}
+
+func bufferRefs(buf *Buffer) int { return int(buf.Instance().mini_object.refcount) }
+
+func elementRefs(elem *Element) int { return int(elem.Instance().object.object.ref_count) }
+
+func waitRefs(t *testing.T, refs func() int, expected int) {
+	t.Helper()
+	deadline := time.Now().Add(testTimeout)
+	for refs() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d refs, got %d", expected, refs())
+		}
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWrapBorrowsManual(t *testing.T) {
+	setOwnershipMode(t, OwnershipManual)
+
+	buf := NewEmptyBuffer()
+	defer buf.Unref()
+
+	borrowed := FromGstBufferUnsafe(unsafe.Pointer(buf.Instance()))
+	if refs := bufferRefs(buf); refs != 1 {
+		t.Errorf("Wrapping a buffer took a reference in manual mode, refs: %d", refs)
+	}
+	if borrowed.Instance() != buf.Instance() {
+		t.Error("The wrapper does not point to the same buffer")
+	}
+}
+
+func TestWrapBorrowsWithFinalizers(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	buf := NewEmptyBuffer()
+	defer buf.Unref()
+
+	borrowed := FromGstBufferUnsafe(unsafe.Pointer(buf.Instance()))
+	if refs := bufferRefs(buf); refs != 2 {
+		t.Fatalf("Expected the borrowed wrapper to hold its own reference, refs: %d", refs)
+	}
+	borrowed.Unref()
+	if refs := bufferRefs(buf); refs != 1 {
+		t.Fatalf("Expected Unref to release the borrowed reference, refs: %d", refs)
+	}
+	// The wrapper holds no more references, so further calls are no-ops.
+	borrowed.Unref()
+	if refs := bufferRefs(buf); refs != 1 {
+		t.Fatalf("Unref released more references than the wrapper held, refs: %d", refs)
+	}
+}
+
+func TestTakeOwnsWithFinalizers(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	buf := NewEmptyBuffer()
+	defer buf.Unref()
+
+	// Ref returns a wrapper taking the new reference, without adding one of its own.
+	taken := buf.Ref()
+	if refs := bufferRefs(buf); refs != 2 {
+		t.Fatalf("Expected the taken wrapper to own exactly the new reference, refs: %d", refs)
+	}
+	taken.Unref()
+	if refs := bufferRefs(buf); refs != 1 {
+		t.Fatalf("Expected Unref to release the taken reference, refs: %d", refs)
+	}
+}
+
+func TestFinalizerReleasesReferences(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	buf := NewEmptyBuffer()
+	defer buf.Unref()
+
+	func() {
+		FromGstBufferUnsafe(unsafe.Pointer(buf.Instance()))
+		buf.Ref()
+	}()
+	waitRefs(t, func() int { return bufferRefs(buf) }, 1)
+}
+
+func TestObjectOwnershipWithFinalizers(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	elem, err := NewElement("fakesink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer elem.Unref()
+	if refs := elementRefs(elem); refs != 1 {
+		t.Fatalf("Expected the new element to be owned by its wrapper alone, refs: %d", refs)
+	}
+
+	borrowed := FromGstElementUnsafe(elem.Unsafe())
+	if refs := elementRefs(elem); refs != 2 {
+		t.Fatalf("Expected the borrowed wrapper to hold its own reference, refs: %d", refs)
+	}
+	borrowed.Unref()
+	borrowed.Unref()
+	if refs := elementRefs(elem); refs != 1 {
+		t.Fatalf("Expected Unref to release only the borrowed reference, refs: %d", refs)
+	}
+}
+
+func TestAllocatorFreeGivesMemoryWithFinalizers(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	mem := DefaultAllocator().Alloc(64, nil)
+	if mem == nil {
+		t.Fatal("Could not allocate memory")
+	}
+	DefaultAllocator().Free(mem)
+	if refs := mem.tracker().refs; refs != 0 {
+		t.Fatalf("Expected Free to take the reference of the wrapper, refs: %d", refs)
+	}
+	// The wrapper owns nothing anymore, so finalizing it must not touch the freed memory.
+	mem = nil
+	runtime.GC()
+}
+
+func TestPadChainGivesBufferWithFinalizers(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	pad := NewPad("sink", PadSink)
+	defer pad.Unref()
+
+	buf := NewEmptyBuffer()
+	keep := buf.Ref()
+	defer keep.Unref()
+
+	// The pad is not active, so the buffer is dropped, which still consumes the reference.
+	pad.Chain(buf)
+	if refs := buf.tracker().refs; refs != 0 {
+		t.Fatalf("Expected Chain to take the reference of the wrapper, refs: %d", refs)
+	}
+	if refs := bufferRefs(keep); refs != 1 {
+		t.Fatalf("Expected Chain to release exactly one reference, refs: %d", refs)
+	}
+}
+
+func TestPadChainListGivesBufferListWithFinalizers(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	pad := NewPad("sink", PadSink)
+	defer pad.Unref()
+
+	list := NewBufferList()
+	// BufferList.Ref records the new reference on the same wrapper.
+	list.Ref()
+	defer list.Unref()
+
+	pad.ChainList(list)
+	if refs := list.tracker().refs; refs != 1 {
+		t.Fatalf("Expected ChainList to take one reference of the wrapper, refs: %d", refs)
+	}
+	if refs := int(list.Instance().mini_object.refcount); refs != 1 {
+		t.Fatalf("Expected ChainList to release exactly one reference, refs: %d", refs)
+	}
+}
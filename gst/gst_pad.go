@@ -7,6 +7,7 @@ extern gboolean          goPadStickyEventForEachFunc  (GstPad * pad, GstEvent **
 extern GstPadProbeReturn goPadProbeFunc               (GstPad * pad, GstPadProbeInfo * info, gpointer user_data);
 extern gboolean          goPadForwardFunc             (GstPad * pad, gpointer user_data);
 extern void              goGDestroyNotifyFuncNoRun    (gpointer user_data);
+extern void              goPadProbeDestroyNotify      (gpointer user_data);
 
 GstPadProbeReturn cgoPadProbeFunc (GstPad * pad, GstPadProbeInfo * info, gpointer user_data)
 {
@@ -28,11 +29,25 @@ void cgoGDestroyNotifyFuncNoRun (gpointer user_data)
 	goGDestroyNotifyFuncNoRun(user_data);
 }
 
+void cgoPadProbeDestroyNotify (gpointer user_data)
+{
+	goPadProbeDestroyNotify(user_data);
+}
+
+void setPadElementPrivate (GstPad * pad, gpointer data)
+{
+	gst_pad_set_element_private(pad, data);
+	// The qdata releases the previous value when it is replaced, and the last one when the pad is finalized.
+	g_object_set_qdata_full(G_OBJECT(pad), g_quark_from_static_string("go-gst-element-private"), data,
+		data == NULL ? NULL : cgoGDestroyNotifyFuncNoRun);
+}
+
 */
 import "C"
 
 import (
 	"errors"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
@@ -54,7 +69,9 @@ func NewPad(name string, direction PadDirection) *Pad {
 	if pad == nil {
 		return nil
 	}
-	return wrapPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // NewPadFromTemplate creates a new pad with the given name from the given template. If name is empty, one will
@@ -70,7 +87,9 @@ func NewPadFromTemplate(tmpl *PadTemplate, name string) *Pad {
 	if pad == nil {
 		return nil
 	}
-	return wrapPad(toGObject(unsafe.Pointer(pad)))
+	obj := wrapPad(toGObject(unsafe.Pointer(pad)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the underlying C GstPad.
@@ -92,7 +111,7 @@ func (p *Pad) CurrentCaps() *Caps {
 	if caps == nil {
 		return nil
 	}
-	return wrapCaps(caps)
+	return takeCaps(caps)
 }
 
 // ActivateMode activates or deactivates the given pad in mode via dispatching to the pad's activatemodefunc.
@@ -106,23 +125,72 @@ func (p *Pad) ActivateMode(mode PadMode, active bool) {
 // PadProbeCallback is a callback used by Pad AddProbe. It gets called to notify about the current blocking type.
 type PadProbeCallback func(*Pad, *PadProbeInfo) PadProbeReturn
 
+// padProbe is the value saved for the callback of a probe.
+type padProbe struct {
+	f       PadProbeCallback
+	removed int32
+}
+
+// PadProbeHandle identifies a probe added with AddProbeHandle.
+type PadProbeHandle struct {
+	pad   *weakRef
+	id    C.gulong
+	probe *padProbe
+}
+
+// ID returns the numeric ID of the probe, as accepted by RemoveProbe.
+func (h *PadProbeHandle) ID() uint64 { return uint64(h.id) }
+
+// Remove removes the probe from its pad. The callback of the probe is released and will not be called
+// again. It is safe to call this function more than once, and after the probe removed itself by returning
+// PadProbeRemove or the pad was finalized.
+func (h *PadProbeHandle) Remove() {
+	if atomic.LoadInt32(&h.probe.removed) == 1 {
+		return
+	}
+	pad := h.pad.get()
+	if pad == nil {
+		return
+	}
+	defer C.gst_object_unref(pad)
+	C.gst_pad_remove_probe((*C.GstPad)(unsafe.Pointer(pad)), h.id)
+}
+
 // AddProbe adds a callback to be notified of different states of pads. The provided callback is called for every state that matches mask.
 //
 // Probes are called in groups: First GST_PAD_PROBE_TYPE_BLOCK probes are called, then others, then finally GST_PAD_PROBE_TYPE_IDLE. The only
 // exception here are GST_PAD_PROBE_TYPE_IDLE probes that are called immediately if the pad is already idle while calling gst_pad_add_probe.
 // In each of the groups, probes are called in the order in which they were added.
 //
-// A probe ID is returned that can be used to remove the probe.
+// A probe ID is returned that can be used to remove the probe. The callback is released when the probe is removed,
+// either with RemoveProbe, by returning PadProbeRemove, or when the pad is finalized.
 func (p *Pad) AddProbe(mask PadProbeType, f PadProbeCallback) uint64 {
-	ptr := gopointer.Save(f)
-	ret := C.gst_pad_add_probe(
+	id, _ := p.addProbe(mask, f)
+	return uint64(id)
+}
+
+// AddProbeHandle is like AddProbe, but returns a handle that removes the probe. Unlike the ID returned from
+// AddProbe, the handle can still be used after the probe removed itself or the pad was finalized. Nil is
+// returned if the probe was not added, which happens for idle probes that were called and removed right away.
+func (p *Pad) AddProbeHandle(mask PadProbeType, f PadProbeCallback) *PadProbeHandle {
+	id, probe := p.addProbe(mask, f)
+	if id == 0 {
+		return nil
+	}
+	return &PadProbeHandle{pad: newWeakRef(unsafe.Pointer(p.Instance())), id: id, probe: probe}
+}
+
+func (p *Pad) addProbe(mask PadProbeType, f PadProbeCallback) (C.gulong, *padProbe) {
+	probe := &padProbe{f: f}
+	ptr := savePointer(probe)
+	id := C.gst_pad_add_probe(
 		p.Instance(),
 		C.GstPadProbeType(mask),
 		C.GstPadProbeCallback(C.cgoPadProbeFunc),
 		(C.gpointer)(unsafe.Pointer(ptr)),
-		C.GDestroyNotify(C.cgoGDestroyNotifyFuncNoRun),
+		C.GDestroyNotify(C.cgoPadProbeDestroyNotify),
 	)
-	return uint64(ret)
+	return id, probe
 }
 
 // CanLink checks if this pad is compatible with the given sink pad.
@@ -141,7 +209,7 @@ func (p *Pad) CanLink(sink *Pad) bool {
 //
 // In all cases, success or failure, the caller loses its reference to buffer after calling this function.
 func (p *Pad) Chain(buffer *Buffer) FlowReturn {
-	return FlowReturn(C.gst_pad_chain(p.Instance(), buffer.Instance()))
+	return FlowReturn(C.gst_pad_chain(p.Instance(), buffer.give()))
 }
 
 // ChainList chains a bufferlist to pad.
@@ -155,7 +223,7 @@ func (p *Pad) Chain(buffer *Buffer) FlowReturn {
 //
 // In all cases, success or failure, the caller loses its reference to list after calling this function.
 func (p *Pad) ChainList(bufferList *BufferList) FlowReturn {
-	return FlowReturn(C.gst_pad_chain_list(p.Instance(), bufferList.Instance()))
+	return FlowReturn(C.gst_pad_chain_list(p.Instance(), bufferList.give()))
 }
 
 // CheckReconfigure checks and clear the PadFlagNeedReconfigure flag on pad and return TRUE if the flag was set.
@@ -206,8 +274,8 @@ type PadForwardFunc func(pad *Pad) bool
 //
 // When forward returns TRUE, no further pads will be processed.
 func (p *Pad) Forward(f PadForwardFunc) bool {
-	ptr := gopointer.Save(f)
-	defer gopointer.Unref(ptr)
+	ptr := savePointer(f)
+	defer unrefPointer(ptr)
 	return gobool(C.gst_pad_forward(
 		p.Instance(),
 		C.GstPadForwardFunction(C.cgoPadForwardFunc),
@@ -220,14 +288,14 @@ func (p *Pad) Forward(f PadForwardFunc) bool {
 // The allowed capabilities is calculated as the intersection of the results of calling QueryCaps on pad and its peer. The caller owns a reference on the
 // resulting caps.
 func (p *Pad) GetAllowedCaps() *Caps {
-	return wrapCaps(C.gst_pad_get_allowed_caps(
+	return takeCaps(C.gst_pad_get_allowed_caps(
 		p.Instance(),
 	))
 }
 
 // GetCurrentCaps gets the capabilities currently configured on pad with the last EventCaps event.
 func (p *Pad) GetCurrentCaps() *Caps {
-	return wrapCaps(C.gst_pad_get_current_caps(
+	return takeCaps(C.gst_pad_get_current_caps(
 		p.Instance(),
 	))
 }
@@ -261,7 +329,9 @@ func (p *Pad) GetPadTemplate() *PadTemplate {
 	if tmpl == nil {
 		return nil
 	}
-	return wrapPadTemplate(toGObject(unsafe.Pointer(tmpl)))
+	obj := wrapPadTemplate(toGObject(unsafe.Pointer(tmpl)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetPadTemplateCaps gets the capabilities for pad's template.
@@ -270,7 +340,7 @@ func (p *Pad) GetPadTemplateCaps() *Caps {
 	if caps == nil {
 		return nil
 	}
-	return wrapCaps(caps)
+	return takeCaps(caps)
 }
 
 // GetParentElement gets the parent of pad, cast to a Element. If a pad has no parent or its
@@ -280,7 +350,9 @@ func (p *Pad) GetParentElement() *Element {
 	if elem == nil {
 		return nil
 	}
-	return wrapElement(toGObject(unsafe.Pointer(elem)))
+	obj := wrapElement(toGObject(unsafe.Pointer(elem)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetPeer gets the peer of pad. This function refs the peer pad so you need to unref it after use.
@@ -289,7 +361,9 @@ func (p *Pad) GetPeer() *Pad {
 	if peer == nil {
 		return nil
 	}
-	return wrapPad(toGObject(unsafe.Pointer(peer)))
+	obj := wrapPad(toGObject(unsafe.Pointer(peer)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetRange calls the getrange function of pad, see PadGetRangeFunc for a description of a getrange function.
@@ -317,7 +391,7 @@ func (p *Pad) GetRange(offset uint64, size uint, buffer *Buffer) (FlowReturn, *B
 	ret := C.gst_pad_get_range(p.Instance(), C.guint64(offset), C.guint(size), &buf)
 	var newBuf *Buffer
 	if buf != nil {
-		newBuf = wrapBuffer(buf)
+		newBuf = takeBuffer(buf)
 	} else {
 		newBuf = nil
 	}
@@ -340,7 +414,7 @@ func (p *Pad) GetStickyEvent(eventType EventType, idx uint) *Event {
 	if ev == nil {
 		return nil
 	}
-	return wrapEvent(ev)
+	return takeEvent(ev)
 }
 
 // GetStream returns the current Stream for the pad, or nil if none has been set yet, i.e. the pad has not received a
@@ -352,7 +426,9 @@ func (p *Pad) GetStream() *Stream {
 	if st == nil {
 		return nil
 	}
-	return wrapStream(toGObject(unsafe.Pointer(st)))
+	obj := wrapStream(toGObject(unsafe.Pointer(st)))
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetStreamID returns the current stream-id for the pad, or an empty string if none has been set yet, i.e. the pad has not received
@@ -500,7 +576,7 @@ func (p *Pad) PeerQueryCaps(filter *Caps) *Caps {
 	if caps == nil {
 		return nil
 	}
-	return wrapCaps(caps)
+	return takeCaps(caps)
 }
 
 // PeerQueryConvert queries the peer pad of a given sink pad to convert src_val in src_format to dest_format.
@@ -564,7 +640,7 @@ func (p *Pad) PullRange(offset uint64, size uint, buffer *Buffer) (FlowReturn, *
 	ret := C.gst_pad_pull_range(p.Instance(), C.guint64(offset), C.guint(size), &buf)
 	var newBuf *Buffer
 	if buf != nil {
-		newBuf = wrapBuffer(buf)
+		newBuf = takeBuffer(buf)
 	} else {
 		newBuf = nil
 	}
@@ -579,14 +655,14 @@ func (p *Pad) PullRange(offset uint64, size uint, buffer *Buffer) (FlowReturn, *
 //
 // In all cases, success or failure, the caller loses its reference to buffer after calling this function.
 func (p *Pad) Push(buf *Buffer) FlowReturn {
-	return FlowReturn(C.gst_pad_push(p.Instance(), buf.Instance()))
+	return FlowReturn(C.gst_pad_push(p.Instance(), buf.give()))
 }
 
 // PushEvent sends the event to the peer of the given pad. This function is mainly used by elements to send events to their peer elements.
 //
 // This function takes ownership of the provided event so you should Ref it if you want to reuse the event after this call.
 func (p *Pad) PushEvent(ev *Event) bool {
-	return gobool(C.gst_pad_push_event(p.Instance(), ev.Instance()))
+	return gobool(C.gst_pad_push_event(p.Instance(), ev.give()))
 }
 
 // PushList pushes a buffer list to the peer of pad.
@@ -599,7 +675,7 @@ func (p *Pad) PushEvent(ev *Event) bool {
 //
 // In all cases, success or failure, the caller loses its reference to list after calling this function.
 func (p *Pad) PushList(bufList *BufferList) FlowReturn {
-	return FlowReturn(C.gst_pad_push_list(p.Instance(), bufList.Instance()))
+	return FlowReturn(C.gst_pad_push_list(p.Instance(), bufList.give()))
 }
 
 // Query dispatches a query to a pad. The query should have been allocated by the caller via one of the type-specific allocation functions. The element that the
@@ -635,7 +711,7 @@ func (p *Pad) QueryCaps(filter *Caps) *Caps {
 	if caps == nil {
 		return nil
 	}
-	return wrapCaps(caps)
+	return takeCaps(caps)
 }
 
 // QueryConvert queries a pad to convert src_val in src_format to dest_format.
@@ -665,7 +741,7 @@ func (p *Pad) QueryPosition(format Format) (bool, int64) {
 	return gobool(gok), int64(out)
 }
 
-// RemoveProbe removes the probe with id from pad.
+// RemoveProbe removes the probe with id from pad. The callback of the probe is released and will not be called again.
 func (p *Pad) RemoveProbe(id uint64) {
 	C.gst_pad_remove_probe(p.Instance(), C.gulong(id))
 }
@@ -683,7 +759,7 @@ func (p *Pad) RemoveProbe(id uint64) {
 //
 // This function takes ownership of the provided event so you should gst_event_ref it if you want to reuse the event after this call.
 func (p *Pad) SendEvent(ev *Event) bool {
-	return gobool(C.gst_pad_send_event(p.Instance(), ev.Instance()))
+	return gobool(C.gst_pad_send_event(p.Instance(), ev.give()))
 }
 
 // SetActive activates or deactivates the given pad. Normally called from within core state change functions.
@@ -697,10 +773,13 @@ func (p *Pad) SetActive(active bool) bool {
 }
 
 // SetElementPrivate sets the given private data pointer on the pad. This function can only be used by the element that owns the pad.
-// No locking is performed in this function.
+// No locking is performed in this function. Any previous value is released, as is the last one when the pad is finalized.
 func (p *Pad) SetElementPrivate(data interface{}) {
-	ptr := gopointer.Save(data)
-	C.gst_pad_set_element_private(p.Instance(), (C.gpointer)(unsafe.Pointer(ptr)))
+	var ptr unsafe.Pointer
+	if data != nil {
+		ptr = savePointer(data)
+	}
+	C.setPadElementPrivate(p.Instance(), (C.gpointer)(ptr))
 }
 
 // SetOffset sets the offset that will be applied to the running time of pad.
@@ -718,8 +797,8 @@ type StickyEventsForEachFunc func(pad *Pad, event *Event) bool
 // StickyEventsForEach iterates all sticky events on pad and calls foreach_func for every event. If foreach_func returns FALSE the iteration is
 // immediately stopped.
 func (p *Pad) StickyEventsForEach(f StickyEventsForEachFunc) {
-	ptr := gopointer.Save(f)
-	defer gopointer.Unref(ptr)
+	ptr := savePointer(f)
+	defer unrefPointer(ptr)
 	C.gst_pad_sticky_events_foreach(
 		p.Instance(),
 		C.GstPadStickyEventsForeachFunction(C.cgoPadStickyEventForEachFunc),
@@ -24,7 +24,9 @@ func NewPadTemplate(nameTemplate string, direction PadDirection, presence PadPre
 	if tmpl == nil {
 		return nil
 	}
-	return wrapPadTemplate(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(tmpl))})
+	obj := wrapPadTemplate(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(tmpl))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // NewPadTemplateWithGType creates a new pad template with a name according to the given template and with the given arguments.
@@ -41,7 +43,9 @@ func NewPadTemplateWithGType(nameTemplate string, direction PadDirection, presen
 	if tmpl == nil {
 		return nil
 	}
-	return wrapPadTemplate(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(tmpl))})
+	obj := wrapPadTemplate(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(tmpl))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the underlying C GstPadTemplate.
@@ -57,7 +61,7 @@ func (p *PadTemplate) Direction() PadDirection { return PadDirection(p.Instance(
 func (p *PadTemplate) Presence() PadPresence { return PadPresence(p.Instance().presence) }
 
 // Caps returns the caps of the pad template.
-func (p *PadTemplate) Caps() *Caps { return wrapCaps(C.gst_pad_template_get_caps(p.Instance())) }
+func (p *PadTemplate) Caps() *Caps { return takeCaps(C.gst_pad_template_get_caps(p.Instance())) }
 
 // PadCreated emits the pad-created signal for this template when created by this pad.
 func (p *PadTemplate) PadCreated(pad *Pad) {
@@ -0,0 +1,66 @@
+package gst
+
+import "testing"
+
+func TestPadProbeHandleReleasesCallback(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	before := LiveCallbacks()
+	pad := NewPad("src", PadSource)
+	defer pad.Unref()
+
+	probe := pad.AddProbeHandle(PadProbeTypeBuffer, func(*Pad, *PadProbeInfo) PadProbeReturn { return PadProbeOK })
+	if probe == nil {
+		t.Fatal("Expected a handle for the added probe")
+	}
+	if live := LiveCallbacks(); live != before+1 {
+		t.Fatalf("Expected the probe callback to be saved, live callbacks: %d", live-before)
+	}
+	probe.Remove()
+	// Removing a probe twice is a no-op.
+	probe.Remove()
+	if err := CheckCallbackLeaks(before, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPadReleasesCallbacksOnFinalize(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	before := LiveCallbacks()
+	pad := NewPad("sink", PadSink)
+
+	pad.SetElementPrivate("first")
+	pad.SetElementPrivate("second")
+	if data := pad.GetElementPrivate(); data != "second" {
+		t.Fatalf("Expected the replaced element private data, got %v", data)
+	}
+	if live := LiveCallbacks(); live != before+1 {
+		t.Fatalf("Expected the replaced element private data to be released, live callbacks: %d", live-before)
+	}
+	probe := pad.AddProbeHandle(PadProbeTypeBuffer, func(*Pad, *PadProbeInfo) PadProbeReturn { return PadProbeOK })
+
+	pad.Unref()
+	if err := CheckCallbackLeaks(before, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+	// The pad is gone, removing the probe must not touch it.
+	probe.Remove()
+}
+
+func TestPadAddProbeReturnsID(t *testing.T) {
+	setOwnershipMode(t, OwnershipFinalizers)
+
+	before := LiveCallbacks()
+	pad := NewPad("src", PadSource)
+	defer pad.Unref()
+
+	id := pad.AddProbe(PadProbeTypeBuffer, func(*Pad, *PadProbeInfo) PadProbeReturn { return PadProbeOK })
+	if id == 0 {
+		t.Fatal("Expected an ID for the added probe")
+	}
+	pad.RemoveProbe(id)
+	if err := CheckCallbackLeaks(before, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,12 +1,25 @@
 package gst
 
-// #include "gst.go.h"
+/*
+#include "gst.go.h"
+
+GstState elementTargetState (GstElement * element)
+{
+	GstState target;
+	GST_OBJECT_LOCK(element);
+	target = GST_STATE_TARGET(element);
+	GST_OBJECT_UNLOCK(element);
+	return target;
+}
+*/
 import "C"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
@@ -30,7 +43,10 @@ func NewPipeline(name string) (*Pipeline, error) {
 	if pipeline == nil {
 		return nil, errors.New("Could not create new pipeline")
 	}
-	return wrapPipeline(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pipeline))}), nil
+	obj := wrapPipeline(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pipeline))})
+	adoptObject(obj.BaseObject())
+	trackPipelineTermination(obj)
+	return obj, nil
 }
 
 // NewPipelineFromString creates a new gstreamer pipeline from the given launch string.
@@ -47,7 +63,10 @@ func NewPipelineFromString(launchv string) (*Pipeline, error) {
 		errMsg := C.GoString(gerr.message)
 		return nil, errors.New(errMsg)
 	}
-	return wrapPipeline(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pipeline))}), nil
+	obj := wrapPipeline(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(pipeline))})
+	adoptObject(obj.BaseObject())
+	trackPipelineTermination(obj)
+	return obj, nil
 }
 
 // Instance returns the native GstPipeline instance.
@@ -58,6 +77,7 @@ func (p *Pipeline) GetPipelineBus() *Bus {
 	if p.bus == nil {
 		cBus := C.gst_pipeline_get_bus((*C.GstPipeline)(p.Instance()))
 		p.bus = wrapBus(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(cBus))})
+		adoptObject(p.bus.BaseObject())
 	}
 	return p.bus
 }
@@ -65,7 +85,9 @@ func (p *Pipeline) GetPipelineBus() *Bus {
 // GetPipelineClock returns the global clock for this pipeline.
 func (p *Pipeline) GetPipelineClock() *Clock {
 	cClock := C.gst_pipeline_get_pipeline_clock((*C.GstPipeline)(p.Instance()))
-	return wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(cClock))})
+	obj := wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(cClock))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 /*
@@ -93,34 +115,100 @@ func (p *Pipeline) Start() error {
 	return p.SetState(StatePlaying)
 }
 
-// Destroy will attempt to stop the pipeline and then unref once the stream has
-// fully completed.
+// StartContext is like Start except it waits for the pipeline to actually reach StatePlaying.
+// It returns an error if an element fails to change state, or if ctx is done before the
+// pipeline reaches the playing state. See SetStateContext for more information.
+func (p *Pipeline) StartContext(ctx context.Context) error {
+	return p.SetStateContext(ctx, StatePlaying)
+}
+
+// DefaultDestroyTimeout is the time Destroy waits for a pipeline to reach StateNull.
+const DefaultDestroyTimeout = 10 * time.Second
+
+// Destroy is like DestroyContext, giving up after DefaultDestroyTimeout.
 func (p *Pipeline) Destroy() error {
-	if err := p.BlockSetState(StateNull); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultDestroyTimeout)
+	defer cancel()
+	return p.DestroyContext(ctx)
+}
+
+// DestroyContext will attempt to stop the pipeline and then unref once the stream has
+// fully completed. Any Subscriptions on the pipeline's bus are ended. The state change is
+// made with SetStateContext, so if ctx is done before the pipeline reaches StateNull, the
+// state change is aborted and a *StateChangeError is returned. The pipeline is not unreffed
+// in that case, and may still complete the change to StateNull on its own.
+func (p *Pipeline) DestroyContext(ctx context.Context) error {
+	bus := C.gst_element_get_bus((*C.GstElement)(unsafe.Pointer(p.Instance())))
+	if bus != nil {
+		defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(bus)))
+	}
+	if err := p.SetStateContext(ctx, StateNull); err != nil {
 		return err
 	}
+	if bus != nil {
+		closeBusSubscriptions(bus)
+	}
 	p.Unref()
 	return nil
 }
 
-// Wait waits for the given pipeline to reach end of stream or be stopped.
+// Wait waits for the given pipeline to reach end of stream, post an error, or be stopped.
+// The pipeline is considered stopped when it is in StateNull, starts shutting down to StateNull,
+// is destroyed, or its bus is flushed. Wait returns right away if the pipeline is already stopped,
+// or if it already reached end of stream or posted an error. The end of stream is only known for
+// pipelines created with NewPipeline or NewPipelineFromString, or while another Subscription was
+// active on their bus. Messages are observed with a Subscription, so they are left on the bus for
+// any watch or other consumer.
 func Wait(p *Pipeline) {
 	if p.Instance() == nil {
 		return
 	}
-	msgCh := p.GetPipelineBus().MessageChan()
-	for {
-		select {
-		default:
-			if p.Instance() == nil || p.GetState() == StateNull {
-				return
-			}
-		case msg := <-msgCh:
-			defer msg.Unref()
-			switch msg.Type() {
-			case MessageEOS:
-				return
-			}
+	bus := C.gst_element_get_bus((*C.GstElement)(unsafe.Pointer(p.Instance())))
+	if bus == nil {
+		return
+	}
+	defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(bus)))
+
+	// The subscription is made before looking at the state of the pipeline, so a transition
+	// happening in between cannot be missed.
+	sub := subscribeBus(bus, context.Background(), MessageEOS|MessageError|MessageStateChanged, &SubscribeOptions{DropPolicy: DropNone}, false)
+	defer sub.Unsubscribe()
+	if current, pending, _ := p.GetStateTimeout(0); current == StateNull && (pending == VoidPending || pending == StateNull) {
+		return
+	}
+	if busTerminated(bus) {
+		return
+	}
+	for msg := range sub.Messages() {
+		done := msg.Type() != MessageStateChanged || pipelineStopping(p, msg)
+		msg.Unref()
+		if done {
+			return
 		}
 	}
 }
+
+// trackPipelineTermination tracks the end of stream on the bus of the pipeline, so Wait can tell
+// whether the pipeline ended before it was called.
+func trackPipelineTermination(p *Pipeline) {
+	bus := C.gst_element_get_bus((*C.GstElement)(unsafe.Pointer(p.Instance())))
+	if bus == nil {
+		return
+	}
+	defer C.gst_object_unref((C.gpointer)(unsafe.Pointer(bus)))
+	trackBusTermination(bus)
+}
+
+// pipelineStopping returns true if the given state-changed message signals the pipeline is
+// going to StateNull. The final transition of the pipeline to StateNull is never seen on the bus,
+// since the pipeline flushes its bus before posting it, so the transitions of its children while
+// the pipeline is shutting down are considered as well.
+func pipelineStopping(p *Pipeline, msg *Message) bool {
+	elem := (*C.GstElement)(unsafe.Pointer(p.Instance()))
+	if unsafe.Pointer(msg.Instance().src) != p.Unsafe() {
+		return State(C.elementTargetState(elem)) == StateNull
+	}
+	var newState, pending C.GstState
+	C.gst_message_parse_state_changed(msg.Instance(), nil, &newState, &pending)
+	return State(newState) == StateNull || State(pending) == StateNull
+}
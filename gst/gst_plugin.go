@@ -3,12 +3,68 @@ package gst
 // #include "gst.go.h"
 import "C"
 
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
 // Plugin is a go representation of a GstPlugin.
 type Plugin struct{ *Object }
 
+// LoadPluginFile loads the plugin in the given file and adds it to the registry. Unref after usage.
+func LoadPluginFile(filename string) (*Plugin, error) {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+	var gerr *C.GError
+	plugin := C.gst_plugin_load_file((*C.gchar)(cFilename), &gerr)
+	if gerr != nil {
+		defer C.g_error_free(gerr)
+		return nil, errors.New(C.GoString(gerr.message))
+	}
+	obj := wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(plugin))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
+}
+
+// LoadPluginByName loads the plugin with the given name from the registry. Unref after usage.
+func LoadPluginByName(name string) (*Plugin, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	plugin := C.gst_plugin_load_by_name((*C.gchar)(cName))
+	if plugin == nil {
+		return nil, fmt.Errorf("Failed to load plugin %s", name)
+	}
+	obj := wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(plugin))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
+}
+
 // Instance returns the underlying GstPlugin instance.
 func (p *Plugin) Instance() *C.GstPlugin { return C.toGstPlugin(p.Unsafe()) }
 
+// Load loads the plugin if it is not already loaded, and returns the loaded plugin. Unref after usage.
+func (p *Plugin) Load() (*Plugin, error) {
+	plugin := C.gst_plugin_load(p.Instance())
+	if plugin == nil {
+		return nil, fmt.Errorf("Failed to load plugin %s", p.Name())
+	}
+	obj := wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(plugin))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
+}
+
+// IsLoaded returns true if the plugin is loaded into memory.
+func (p *Plugin) IsLoaded() bool { return gobool(C.gst_plugin_is_loaded(p.Instance())) }
+
+// IsBlacklisted returns true if the plugin failed to load when the registry was scanned, and is
+// therefore not used.
+func (p *Plugin) IsBlacklisted() bool {
+	return gobool(C.gstObjectFlagIsSet(C.toGstObject(p.Unsafe()), C.GstElementFlags(C.GST_PLUGIN_FLAG_BLACKLISTED)))
+}
+
 // Description returns the description for this plugin.
 func (p *Plugin) Description() string {
 	ret := C.gst_plugin_get_description((*C.GstPlugin)(p.Instance()))
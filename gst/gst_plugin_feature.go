@@ -4,6 +4,7 @@ package gst
 import "C"
 
 import (
+	"fmt"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
@@ -21,7 +22,9 @@ func (p *PluginFeature) GetPlugin() *Plugin {
 	if plugin == nil {
 		return nil
 	}
-	return wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(plugin))})
+	obj := wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(plugin))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // GetPluginName returns the name of the plugin that provides this feature.
@@ -32,3 +35,40 @@ func (p *PluginFeature) GetPluginName() string {
 	}
 	return C.GoString(pluginName)
 }
+
+// GetRank returns the rank of the feature.
+func (p *PluginFeature) GetRank() Rank {
+	return Rank(C.gst_plugin_feature_get_rank(p.Instance()))
+}
+
+// SetRank sets the rank of the feature. Autoplugging elements such as decodebin prefer features with a
+// higher rank, so raising the rank of a decoder above RankPrimary forces it to be used, while setting it to
+// RankNone prevents it from being autoplugged.
+//
+//   registry := gst.GetRegistry()
+//   feature, err := registry.LookupFeature("avdec_h264")
+//   if err != nil {
+//       panic(err)
+//   }
+//   defer feature.Unref()
+//   feature.SetRank(gst.RankPrimary + 1)
+func (p *PluginFeature) SetRank(rank Rank) {
+	C.gst_plugin_feature_set_rank(p.Instance(), C.guint(rank))
+}
+
+// Load loads the plugin containing the feature, if it is not already loaded, and returns the loaded
+// feature. Unref after usage.
+func (p *PluginFeature) Load() (*PluginFeature, error) {
+	feat := C.gst_plugin_feature_load(p.Instance())
+	if feat == nil {
+		return nil, fmt.Errorf("Failed to load plugin feature %s", p.Name())
+	}
+	obj := wrapPluginFeature(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(feat))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
+}
+
+// CheckVersion checks if the plugin providing the feature has at least the given version.
+func (p *PluginFeature) CheckVersion(major, minor, micro uint) bool {
+	return gobool(C.gst_plugin_feature_check_version(p.Instance(), C.guint(major), C.guint(minor), C.guint(micro)))
+}
@@ -0,0 +1,200 @@
+package gst
+
+// #include "gst.go.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// InterfacePreset represents the GstPreset interface GType. Use this when querying bins
+// for elements that implement a Preset.
+var InterfacePreset = glib.Type(C.GST_TYPE_PRESET)
+
+// Common meta tags that can be used with Preset SetMeta and GetMeta.
+const (
+	PresetMetaAuthor  = "author"
+	PresetMetaComment = "comment"
+)
+
+// Preset is an interface that elements can implement to provide storage and retrieval of
+// named property sets (presets). Presets are stored in a keyfile in the user's home directory
+// and/or the system wide preset directories. Elements with presets installed by their package
+// (e.g. x264enc) will have those presets merged with any saved by the application.
+type Preset interface {
+	// GetPresetNames returns a list of all the preset names available for this element.
+	GetPresetNames() []string
+	// GetPropertyNames returns the names of the properties that are stored in a preset.
+	GetPropertyNames() []string
+	// LoadPreset loads the properties of the given preset into the element.
+	LoadPreset(name string) error
+	// SavePreset saves the current object settings as a preset under the given name. If there is
+	// already a preset by this name it will be overwritten.
+	SavePreset(name string) error
+	// RenamePreset renames a preset. If there is already a preset by the new name it will be overwritten.
+	RenamePreset(oldName, newName string) error
+	// DeletePreset deletes the given preset.
+	DeletePreset(name string) error
+	// SetMeta sets a new value for an existing meta data item or adds a new item. Meta data
+	// tags starting with '_' are private to the preset implementation. Setting an empty value
+	// removes the tag.
+	SetMeta(name, tag, value string) error
+	// GetMeta gets the value for an existing meta data tag. The bool is false if the tag
+	// was not present on the preset.
+	GetMeta(name, tag string) (string, bool)
+	// IsEditable returns true if presets for this element can be saved, renamed or deleted.
+	IsEditable() bool
+}
+
+// SetPresetAppDir sets an additional, application specific directory where presets are searched
+// and stored. Presets saved by the application will be written here instead of the user's home
+// directory. This can only be done once, and must be done before any presets are loaded. False is
+// returned if the directory could not be set.
+func SetPresetAppDir(dir string) bool {
+	cDir := C.CString(dir)
+	defer C.free(unsafe.Pointer(cDir))
+	return gobool(C.gst_preset_set_app_dir((*C.gchar)(unsafe.Pointer(cDir))))
+}
+
+// GetPresetAppDir returns the application specific preset directory, or an empty string if
+// none has been set.
+func GetPresetAppDir() string {
+	dir := C.gst_preset_get_app_dir()
+	if dir == nil {
+		return ""
+	}
+	return C.GoString(dir)
+}
+
+// AddPresetInterface adds the GstPreset interface to the given type. This is intended for element
+// types registered from the Go runtime. The default GstPreset implementation will be used, which
+// stores and loads presets from the readable and writable properties of the element, so no further
+// work is needed to give the element preset support. This should be called once, directly after the
+// type has been registered. Elements registered with the Register functions of this module, e.g.
+// audio.RegisterEncoder, already implement the interface and need no call to this.
+func AddPresetInterface(gtype glib.Type) {
+	var ifaceInfo C.GInterfaceInfo
+	C.g_type_add_interface_static(C.GType(gtype), C.GST_TYPE_PRESET, &ifaceInfo)
+}
+
+// gstPreset implements a Preset that is backed by an Element from the C runtime.
+type gstPreset struct {
+	ptr *C.GstElement
+}
+
+// Instance returns the underlying GstPreset instance.
+func (p *gstPreset) Instance() *C.GstPreset { return C.toGstPreset(unsafe.Pointer(p.ptr)) }
+
+func (p *gstPreset) GetPresetNames() []string {
+	names := C.gst_preset_get_preset_names(p.Instance())
+	if names == nil {
+		return nil
+	}
+	defer C.g_strfreev(names)
+	return goStrings(C.sizeOfGCharArray(names), names)
+}
+
+func (p *gstPreset) GetPropertyNames() []string {
+	names := C.gst_preset_get_property_names(p.Instance())
+	if names == nil {
+		return nil
+	}
+	defer C.g_strfreev(names)
+	return goStrings(C.sizeOfGCharArray(names), names)
+}
+
+func (p *gstPreset) LoadPreset(name string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	if !gobool(C.gst_preset_load_preset(p.Instance(), (*C.gchar)(unsafe.Pointer(cName)))) {
+		return fmt.Errorf("Failed to load preset %s", name)
+	}
+	return nil
+}
+
+func (p *gstPreset) SavePreset(name string) error {
+	if !p.IsEditable() {
+		return errors.New("Presets for this element are not editable")
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	if !gobool(C.gst_preset_save_preset(p.Instance(), (*C.gchar)(unsafe.Pointer(cName)))) {
+		return fmt.Errorf("Failed to save preset %s", name)
+	}
+	return nil
+}
+
+func (p *gstPreset) RenamePreset(oldName, newName string) error {
+	if !p.IsEditable() {
+		return errors.New("Presets for this element are not editable")
+	}
+	cOld := C.CString(oldName)
+	cNew := C.CString(newName)
+	defer C.free(unsafe.Pointer(cOld))
+	defer C.free(unsafe.Pointer(cNew))
+	if !gobool(C.gst_preset_rename_preset(p.Instance(), (*C.gchar)(unsafe.Pointer(cOld)), (*C.gchar)(unsafe.Pointer(cNew)))) {
+		return fmt.Errorf("Failed to rename preset %s to %s", oldName, newName)
+	}
+	return nil
+}
+
+func (p *gstPreset) DeletePreset(name string) error {
+	if !p.IsEditable() {
+		return errors.New("Presets for this element are not editable")
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	if !gobool(C.gst_preset_delete_preset(p.Instance(), (*C.gchar)(unsafe.Pointer(cName)))) {
+		return fmt.Errorf("Failed to delete preset %s", name)
+	}
+	return nil
+}
+
+func (p *gstPreset) SetMeta(name, tag, value string) error {
+	cName := C.CString(name)
+	cTag := C.CString(tag)
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cTag))
+	var cValue *C.gchar
+	if value != "" {
+		cValue = (*C.gchar)(unsafe.Pointer(C.CString(value)))
+		defer C.free(unsafe.Pointer(cValue))
+	}
+	ok := C.gst_preset_set_meta(
+		p.Instance(),
+		(*C.gchar)(unsafe.Pointer(cName)),
+		(*C.gchar)(unsafe.Pointer(cTag)),
+		cValue,
+	)
+	if !gobool(ok) {
+		return fmt.Errorf("Failed to set meta %s on preset %s", tag, name)
+	}
+	return nil
+}
+
+func (p *gstPreset) GetMeta(name, tag string) (string, bool) {
+	cName := C.CString(name)
+	cTag := C.CString(tag)
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cTag))
+	var value *C.gchar
+	ok := C.gst_preset_get_meta(
+		p.Instance(),
+		(*C.gchar)(unsafe.Pointer(cName)),
+		(*C.gchar)(unsafe.Pointer(cTag)),
+		&value,
+	)
+	if !gobool(ok) || value == nil {
+		return "", false
+	}
+	defer C.g_free((C.gpointer)(unsafe.Pointer(value)))
+	return C.GoString(value), true
+}
+
+func (p *gstPreset) IsEditable() bool {
+	return gobool(C.gst_preset_is_editable(p.Instance()))
+}
@@ -0,0 +1,159 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+extern void goPromiseChangeFunc       (GstPromise * promise, gpointer user_data);
+extern void goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+void cgoPromiseChangeFunc (GstPromise * promise, gpointer user_data)
+{
+	goPromiseChangeFunc(promise, user_data);
+}
+
+void cgoPromiseDestroyNotify (gpointer user_data)
+{
+	goGDestroyNotifyFuncNoRun(user_data);
+}
+
+GstPromise * newGoPromiseWithChangeFunc (gpointer user_data)
+{
+	return gst_promise_new_with_change_func(cgoPromiseChangeFunc, user_data, cgoPromiseDestroyNotify);
+}
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"unsafe"
+)
+
+// Errors returned from Promise.Await.
+var (
+	ErrPromiseInterrupted = errors.New("Promise was interrupted")
+	ErrPromiseExpired     = errors.New("Promise expired before it was replied to")
+)
+
+// Promise is a go wrapper around a GstPromise. A Promise provides a way for one piece of code to
+// wait for a value (a Structure) that is produced by another, usually asynchronously on another
+// thread. They are used by action signals such as webrtcbin's create-offer and get-stats.
+//
+//   promise := gst.NewPromise()
+//   defer promise.Unref()
+//
+//   if _, err := webrtcbin.Emit("get-stats", nil, promise); err != nil {
+//       panic(err)
+//   }
+//
+//   reply, err := promise.Await(context.Background())
+//   if err != nil {
+//       panic(err)
+//   }
+//   fmt.Println(reply)
+type Promise struct {
+	ptr  *C.GstPromise
+	refs refTracker
+}
+
+// PromiseChangeFunc is called when the result of a Promise changes from PromiseResultPending. It is
+// called from the thread that replied to, interrupted or expired the promise.
+type PromiseChangeFunc func(promise *Promise)
+
+// FromGstPromiseUnsafe wraps the given C GstPromise in the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstPromiseUnsafe(promise unsafe.Pointer) *Promise {
+	return wrapPromise(C.toGstPromise(promise))
+}
+
+// NewPromise returns a new Promise. Unref after usage.
+func NewPromise() *Promise { return takePromise(C.gst_promise_new()) }
+
+// NewPromiseWithChangeFunc returns a new Promise that calls f once its result changes from
+// PromiseResultPending. The function is released along with the promise. Unref after usage.
+func NewPromiseWithChangeFunc(f PromiseChangeFunc) *Promise {
+	return takePromise(C.newGoPromiseWithChangeFunc((C.gpointer)(savePointer(f))))
+}
+
+// Instance returns the underlying GstPromise instance.
+func (p *Promise) Instance() *C.GstPromise { return p.ptr }
+
+// Ref increases the ref count on the promise by one.
+func (p *Promise) Ref() { refMiniObject(p) }
+
+// Unref decreases the ref count on the promise by one.
+func (p *Promise) Unref() { unrefMiniObject(p) }
+
+// Wait waits for the promise to move out of PromiseResultPending and returns the result. If the
+// promise is already not pending, the current result is returned immediately.
+func (p *Promise) Wait() PromiseResult {
+	return PromiseResult(C.gst_promise_wait(p.Instance()))
+}
+
+// Reply sets a reply on the promise and wakes up any waiters. The promise takes ownership of the
+// structure, which must not be used or freed afterwards. s may be nil. If the promise has already
+// been interrupted, the reply is discarded.
+//
+// This is usually only called by the producer of a value.
+func (p *Promise) Reply(s *Structure) {
+	var st *C.GstStructure
+	if s != nil {
+		st = s.Instance()
+	}
+	C.gst_promise_reply(p.Instance(), st)
+}
+
+// Interrupt interrupts the promise, for when the consumer no longer needs its value, and wakes up any
+// waiters. Any reply made after this is discarded.
+func (p *Promise) Interrupt() { C.gst_promise_interrupt(p.Instance()) }
+
+// Expire expires the promise, for when the producer will never reply to it, and wakes up any waiters.
+func (p *Promise) Expire() { C.gst_promise_expire(p.Instance()) }
+
+// GetReply returns the reply set on the promise, or nil if there is none. The promise must be in the
+// PromiseResultReplied state. The structure is owned by the promise and is only valid as long as the
+// promise is.
+func (p *Promise) GetReply() *Structure {
+	st := C.gst_promise_get_reply(p.Instance())
+	if st == nil {
+		return nil
+	}
+	return wrapStructure(st)
+}
+
+// Await waits for the promise in a separate goroutine until it is replied to, or until the context
+// is done, in which case the promise is interrupted. The reply is returned on success, otherwise the
+// error is ErrPromiseInterrupted, ErrPromiseExpired or the error of the context.
+func (p *Promise) Await(ctx context.Context) (*Structure, error) {
+	select {
+	case res := <-p.Done():
+		return p.result(res)
+	case <-ctx.Done():
+		p.Interrupt()
+		return nil, ctx.Err()
+	}
+}
+
+// Done returns a channel that receives the result of the promise once it is no longer pending. The
+// promise is waited on in a separate goroutine, which holds a reference to it until then.
+func (p *Promise) Done() <-chan PromiseResult {
+	ch := make(chan PromiseResult, 1)
+	C.gst_mini_object_ref(p.miniObject())
+	go func() {
+		defer C.gst_mini_object_unref(p.miniObject())
+		ch <- p.Wait()
+	}()
+	return ch
+}
+
+func (p *Promise) result(res PromiseResult) (*Structure, error) {
+	switch res {
+	case PromiseResultReplied:
+		return p.GetReply(), nil
+	case PromiseResultInterrupted:
+		return nil, ErrPromiseInterrupted
+	case PromiseResultExpired:
+		return nil, ErrPromiseExpired
+	}
+	return nil, errors.New("Promise is still pending")
+}
@@ -0,0 +1,86 @@
+package gst
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPromiseReplyAwait(t *testing.T) {
+	promise := NewPromise()
+	defer promise.Unref()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		reply := NewStructure("reply")
+		reply.SetValue("answer", "forty-two")
+		promise.Reply(reply)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	reply, err := promise.Await(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Name() != "reply" {
+		t.Errorf("Unexpected reply: %s", reply.Name())
+	}
+	if answer, err := reply.GetValue("answer"); err != nil || answer != "forty-two" {
+		t.Errorf("Unexpected answer: %v (%v)", answer, err)
+	}
+	if res := promise.Wait(); res != PromiseResultReplied {
+		t.Errorf("Expected the promise to be replied, got %v", res)
+	}
+}
+
+func TestPromiseAwaitCancellationInterrupts(t *testing.T) {
+	promise := NewPromise()
+	defer promise.Unref()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := promise.Await(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the context error, got %v", err)
+	}
+	if res := promise.Wait(); res != PromiseResultInterrupted {
+		t.Fatalf("Expected the promise to be interrupted, got %v", res)
+	}
+
+	// A producer replying late is discarded.
+	promise.Reply(NewStructure("late"))
+	if res := promise.Wait(); res != PromiseResultInterrupted {
+		t.Errorf("Expected the late reply to be discarded, got %v", res)
+	}
+	if _, err := promise.Await(context.Background()); !errors.Is(err, ErrPromiseInterrupted) {
+		t.Errorf("Expected ErrPromiseInterrupted, got %v", err)
+	}
+}
+
+func TestPromiseChangeFunc(t *testing.T) {
+	before := LiveCallbacks()
+	changed := make(chan PromiseResult, 1)
+	promise := NewPromiseWithChangeFunc(func(p *Promise) { changed <- p.Wait() })
+
+	promise.Expire()
+	select {
+	case res := <-changed:
+		if res != PromiseResultExpired {
+			t.Errorf("Expected the promise to be expired, got %v", res)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("The change function was not called")
+	}
+	if _, err := promise.Await(context.Background()); !errors.Is(err, ErrPromiseExpired) {
+		t.Errorf("Expected ErrPromiseExpired, got %v", err)
+	}
+
+	promise.Unref()
+	if err := CheckCallbackLeaks(before, testTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
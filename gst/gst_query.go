@@ -11,17 +11,18 @@ import (
 
 // Query is a go wrapper around a GstQuery.
 type Query struct {
-	ptr *C.GstQuery
+	ptr  *C.GstQuery
+	refs refTracker
 }
 
 // NewAcceptCapsQuery constructs a new query object for querying if caps are accepted.
 func NewAcceptCapsQuery(caps *Caps) *Query {
-	return wrapQuery(C.gst_query_new_accept_caps(caps.Instance()))
+	return takeQuery(C.gst_query_new_accept_caps(caps.Instance()))
 }
 
 // NewAllocationQuery constructs a new query object for querying the allocation properties.
 func NewAllocationQuery(caps *Caps, needPool bool) *Query {
-	return wrapQuery(C.gst_query_new_allocation(
+	return takeQuery(C.gst_query_new_allocation(
 		caps.Instance(), gboolean(needPool),
 	))
 }
@@ -33,7 +34,7 @@ func NewAllocationQuery(caps *Caps, needPool bool) *Query {
 
 // NewBufferingQuery constructs a new query object for querying the buffering status of a stream.
 func NewBufferingQuery(format Format) *Query {
-	return wrapQuery(C.gst_query_new_buffering(
+	return takeQuery(C.gst_query_new_buffering(
 		C.GstFormat(format),
 	))
 }
@@ -52,14 +53,14 @@ func NewBufferingQuery(format Format) *Query {
 // The filter is used to restrict the result caps, only the caps matching filter should be returned from the CAPS
 // query. Specifying a filter might greatly reduce the amount of processing an element needs to do.
 func NewCapsQuery(caps *Caps) *Query {
-	return wrapQuery(C.gst_query_new_caps(caps.Instance()))
+	return takeQuery(C.gst_query_new_caps(caps.Instance()))
 }
 
 // NewContextQuery constructs a new query object for querying the pipeline-local context.
 func NewContextQuery(ctxType string) *Query {
 	cName := C.CString(ctxType)
 	defer C.free(unsafe.Pointer(cName))
-	return wrapQuery(C.gst_query_new_context(
+	return takeQuery(C.gst_query_new_context(
 		(*C.gchar)(unsafe.Pointer(cName)),
 	))
 }
@@ -67,14 +68,14 @@ func NewContextQuery(ctxType string) *Query {
 // NewConvertQuery constructs a new convert query object. A convert query is used to ask for a conversion between one
 // format and another.
 func NewConvertQuery(srcFormat, destFormat Format, value int64) *Query {
-	return wrapQuery(C.gst_query_new_convert(
+	return takeQuery(C.gst_query_new_convert(
 		C.GstFormat(srcFormat), C.gint64(value), C.GstFormat(destFormat),
 	))
 }
 
 // NewCustomQuery constructs a new custom query object.
 func NewCustomQuery(queryType QueryType, structure *Structure) *Query {
-	return wrapQuery(C.gst_query_new_custom(
+	return takeQuery(C.gst_query_new_custom(
 		C.GstQueryType(queryType),
 		structure.Instance(),
 	))
@@ -82,53 +83,62 @@ func NewCustomQuery(queryType QueryType, structure *Structure) *Query {
 
 // NewDrainQuery constructs a new query object for querying the drain state.
 func NewDrainQuery() *Query {
-	return wrapQuery(C.gst_query_new_drain())
+	return takeQuery(C.gst_query_new_drain())
 }
 
 // NewDurationQuery constructs a new stream duration query object to query in the given format. A duration query will give the
 // total length of the stream.
 func NewDurationQuery(format Format) *Query {
-	return wrapQuery(C.gst_query_new_duration(C.GstFormat(format)))
+	return takeQuery(C.gst_query_new_duration(C.GstFormat(format)))
 }
 
 // NewFormatsQuery constructs a new query object for querying formats of the stream.
 func NewFormatsQuery() *Query {
-	return wrapQuery(C.gst_query_new_formats())
+	return takeQuery(C.gst_query_new_formats())
 }
 
 // NewLatencyQuery constructs a new latency query object. A latency query is usually performed by sinks to compensate for additional
 // latency introduced by elements in the pipeline.
 func NewLatencyQuery() *Query {
-	return wrapQuery(C.gst_query_new_latency())
+	return takeQuery(C.gst_query_new_latency())
 }
 
 // NewPositionQuery constructs a new query stream position query object. A position query is used to query the current position of playback
 // in the streams, in some format.
 func NewPositionQuery(format Format) *Query {
-	return wrapQuery(C.gst_query_new_position(C.GstFormat(format)))
+	return takeQuery(C.gst_query_new_position(C.GstFormat(format)))
 }
 
 // NewSchedulingQuery constructs a new query object for querying the scheduling properties.
 func NewSchedulingQuery() *Query {
-	return wrapQuery(C.gst_query_new_scheduling())
+	return takeQuery(C.gst_query_new_scheduling())
 }
 
 // NewSeekingQuery constructs a new query object for querying seeking properties of the stream.
 func NewSeekingQuery(format Format) *Query {
-	return wrapQuery(C.gst_query_new_seeking(C.GstFormat(format)))
+	return takeQuery(C.gst_query_new_seeking(C.GstFormat(format)))
 }
 
 // NewSegmentQuery constructs a new segment query object. A segment query is used to discover information about the currently configured segment
 // for playback.
 func NewSegmentQuery(format Format) *Query {
-	return wrapQuery(C.gst_query_new_segment(C.GstFormat(format)))
+	return takeQuery(C.gst_query_new_segment(C.GstFormat(format)))
 }
 
 // NewURIQuery constructs a new query URI query object. An URI query is used to query the current URI that is used by the source or sink.
 func NewURIQuery() *Query {
-	return wrapQuery(C.gst_query_new_uri())
+	return takeQuery(C.gst_query_new_uri())
 }
 
+// FromGstQueryUnsafe wraps the pointer to the given C GstQuery with the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstQueryUnsafe(ptr unsafe.Pointer) *Query { return wrapQuery(C.toGstQuery(ptr)) }
+
+// FromGstQueryUnsafeFull wraps the pointer to the given C GstQuery with the go type, taking ownership
+// of the reference the caller holds on it (transfer full).
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstQueryUnsafeFull(ptr unsafe.Pointer) *Query { return takeQuery(C.toGstQuery(ptr)) }
+
 // Instance returns the underlying GstQuery instance.
 func (q *Query) Instance() *C.GstQuery { return C.toGstQuery(unsafe.Pointer(q.ptr)) }
 
@@ -165,7 +175,14 @@ func (q *Query) AddSchedulingMode(mode PadMode) {
 
 // Copy copies the given query using the copy function of the parent GstStructure.
 func (q *Query) Copy() *Query {
-	return wrapQuery(C.gst_query_copy(q.Instance()))
+	return takeQuery(C.gst_query_copy(q.Instance()))
+}
+
+// MakeMut makes this query writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and q refers to the copy from then on.
+// Unlike MakeWritable, q remains valid after the call and must be released as usual.
+func (q *Query) MakeMut() {
+	q.ptr = C.toGstQuery(unsafe.Pointer(makeMutMiniObject(q)))
 }
 
 // FindAllocationMeta checks if query has metadata api set. When this function returns TRUE, index will contain the index where the requested
@@ -346,10 +363,15 @@ func (q *Query) ParseAllocationMetaAt(idx uint) (api glib.Type, st *Structure) {
 
 // ParseAllocationParamAt parses an available query and get the allocator and its params at index of the allocator array.
 func (q *Query) ParseAllocationParamAt(idx uint) (*Allocator, *AllocationParams) {
-	alloc := &C.GstAllocator{}
-	params := C.GstAllocationParams{}
-	C.gst_query_parse_nth_allocation_param(q.Instance(), C.guint(idx), &alloc, &params)
-	return wrapAllocator(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(alloc))}), wrapAllocationParams(&params)
+	var alloc *C.GstAllocator
+	params := &C.GstAllocationParams{}
+	C.gst_query_parse_nth_allocation_param(q.Instance(), C.guint(idx), &alloc, params)
+	if alloc == nil {
+		return nil, wrapAllocationParams(params)
+	}
+	obj := wrapAllocator(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(alloc))})
+	adoptObject(obj.BaseObject())
+	return obj, wrapAllocationParams(params)
 }
 
 // ParseAllocationPoolAt gets the pool parameters in query.
@@ -357,7 +379,9 @@ func (q *Query) ParseAllocationPoolAt(idx uint) (pool *BufferPool, size, minBuff
 	gpool := &C.GstBufferPool{}
 	var gs, gmin, gmax C.guint
 	C.gst_query_parse_nth_allocation_pool(q.Instance(), C.guint(idx), &gpool, &gs, &gmin, &gmax)
-	return wrapBufferPool(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(gpool))}), uint(gs), uint(gmin), uint(gmax)
+	obj := wrapBufferPool(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(gpool))})
+	adoptObject(obj.BaseObject())
+	return obj, uint(gs), uint(gmin), uint(gmax)
 }
 
 // ParseBufferingRangeAt parses an available query and get the start and stop values stored at the index of the buffered ranges array.
@@ -571,13 +595,13 @@ func (q *Query) SetURIRedirectionPermanent(permanent bool) {
 
 // Ref increases the query ref count by one.
 func (q *Query) Ref() *Query {
-	C.gst_query_ref(q.Instance())
+	refMiniObject(q)
 	return q
 }
 
 // Unref decreases the refcount of the query. If the refcount reaches 0, the query will be freed.
 func (q *Query) Unref() {
-	C.gst_query_unref(q.Instance())
+	unrefMiniObject(q)
 }
 
 // WritableStructure gets the structure of a query. This method should be called with a writable query so that the returned structure is guaranteed to be writable.
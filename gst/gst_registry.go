@@ -30,7 +30,9 @@ func (r *Registry) FindPlugin(name string) (*Plugin, error) {
 	if plugin == nil {
 		return nil, fmt.Errorf("No plugin named %s found", name)
 	}
-	return wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(plugin))}), nil
+	obj := wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(plugin))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
 }
 
 // LookupFeature looks up the given plugin feature by name. Unref after usage.
@@ -41,5 +43,97 @@ func (r *Registry) LookupFeature(name string) (*PluginFeature, error) {
 	if feat == nil {
 		return nil, fmt.Errorf("No feature named %s found", name)
 	}
-	return wrapPluginFeature(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(feat))}), nil
+	obj := wrapPluginFeature(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(feat))})
+	adoptObject(obj.BaseObject())
+	return obj, nil
+}
+
+// GetPluginList returns all the plugins in the registry. Unref each plugin after usage.
+func (r *Registry) GetPluginList() []*Plugin {
+	glist := C.gst_registry_get_plugin_list(r.Instance())
+	return takePluginList(glist)
+}
+
+// GetFeatureList returns all the features in the registry of the given type, e.g. the type of
+// ElementFactory or TypeFindFactory. Unref each feature after usage.
+func (r *Registry) GetFeatureList(gtype glib.Type) []*PluginFeature {
+	glist := C.gst_registry_get_feature_list(r.Instance(), C.GType(gtype))
+	return takePluginFeatureList(glist)
+}
+
+// GetFeatureListByPlugin returns all the features provided by the plugin with the given name. Unref
+// each feature after usage.
+func (r *Registry) GetFeatureListByPlugin(name string) []*PluginFeature {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	glist := C.gst_registry_get_feature_list_by_plugin(r.Instance(), (*C.gchar)(cName))
+	return takePluginFeatureList(glist)
+}
+
+// ScanPath scans the given path for plugins to add to the registry. It returns true if the registry
+// changed.
+func (r *Registry) ScanPath(path string) bool {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	return gobool(C.gst_registry_scan_path(r.Instance(), (*C.gchar)(cPath)))
+}
+
+// RemoveFeature removes the feature from the registry, after which it can no longer be found or
+// autoplugged.
+func (r *Registry) RemoveFeature(feature *PluginFeature) {
+	C.gst_registry_remove_feature(r.Instance(), feature.Instance())
+}
+
+// RemovePlugin removes the plugin and all of its features from the registry.
+func (r *Registry) RemovePlugin(plugin *Plugin) {
+	C.gst_registry_remove_plugin(r.Instance(), plugin.Instance())
+}
+
+// Blacklist removes the features with the given names from the registry, so that they are never used by
+// autoplugging elements such as decodebin, and cannot be created by name. It returns an error for the
+// first name that is not found, after having removed the others.
+func (r *Registry) Blacklist(names ...string) error {
+	var firstErr error
+	for _, name := range names {
+		feature, err := r.LookupFeature(name)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		r.RemoveFeature(feature)
+		feature.Unref()
+	}
+	return firstErr
+}
+
+// takePluginList wraps the plugins in the given list, taking their references, and frees the list.
+func takePluginList(glist *C.GList) []*Plugin {
+	if glist == nil {
+		return nil
+	}
+	defer C.g_list_free(glist)
+	out := make([]*Plugin, 0)
+	for l := glist; l != nil; l = l.next {
+		obj := wrapPlugin(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(l.data))})
+		adoptObject(obj.BaseObject())
+		out = append(out, obj)
+	}
+	return out
+}
+
+// takePluginFeatureList wraps the features in the given list, taking their references, and frees the list.
+func takePluginFeatureList(glist *C.GList) []*PluginFeature {
+	if glist == nil {
+		return nil
+	}
+	defer C.g_list_free(glist)
+	out := make([]*PluginFeature, 0)
+	for l := glist; l != nil; l = l.next {
+		obj := wrapPluginFeature(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(l.data))})
+		adoptObject(obj.BaseObject())
+		out = append(out, obj)
+	}
+	return out
 }
@@ -17,7 +17,9 @@ func NewStream(id string, caps *Caps, sType StreamType, flags StreamFlags) *Stre
 	cID := C.CString(id)
 	defer C.free(unsafe.Pointer(cID))
 	stream := C.gst_stream_new(cID, caps.Instance(), C.GstStreamType(sType), C.GstStreamFlags(flags))
-	return wrapStream(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	obj := wrapStream(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(stream))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the underlying GstStream.
@@ -27,7 +29,7 @@ func (s *Stream) Instance() *C.GstStream {
 
 // Caps returns the caps for this stream.
 func (s *Stream) Caps() *Caps {
-	return wrapCaps(C.gst_stream_get_caps(s.Instance()))
+	return takeCaps(C.gst_stream_get_caps(s.Instance()))
 }
 
 // StreamFlags returns the flags for this stream.
@@ -51,7 +53,7 @@ func (s *Stream) Tags() *TagList {
 	if tags == nil {
 		return nil
 	}
-	return wrapTagList(tags)
+	return takeTagList(tags)
 }
 
 // SetCaps sets the caps for this stream.
@@ -19,7 +19,9 @@ func NewStreamCollection(upstreamID string) *StreamCollection {
 	cID := C.CString(upstreamID)
 	defer C.free(unsafe.Pointer(cID))
 	collection := C.gst_stream_collection_new(cID)
-	return wrapStreamCollection(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(collection))})
+	obj := wrapStreamCollection(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(collection))})
+	adoptObject(obj.BaseObject())
+	return obj
 }
 
 // Instance returns the underlying GstStreamCollection.
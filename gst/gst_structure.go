@@ -21,7 +21,6 @@ import (
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // Structure is a go implementation of a C GstStructure.
@@ -59,6 +58,16 @@ func StructureFromGValue(gval *glib.Value) *Structure {
 	return wrapStructure(st)
 }
 
+// FromGstStructureUnsafe wraps the given C GstStructure in the go type. No copy is made, the structure
+// remains owned by its parent.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstStructureUnsafe(st unsafe.Pointer) *Structure {
+	if st == nil {
+		return nil
+	}
+	return wrapStructure(C.toGstStructure(st))
+}
+
 // MarshalStructure will convert the given go struct into a GstStructure. Currently nested
 // structs are not supported.
 func MarshalStructure(data interface{}) *Structure {
@@ -156,8 +165,8 @@ func (s *Structure) RemoveValue(key string) {
 func (s *Structure) Values() map[string]interface{} {
 	out := make(map[string]interface{})
 	resCh := make(chan interface{})
-	chPtr := gopointer.Save(resCh)
-	defer gopointer.Unref(chPtr)
+	chPtr := savePointer(resCh)
+	defer unrefPointer(chPtr)
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -15,5 +15,7 @@ type SystemClock struct{ *Clock }
 // increased so you need to unref the clock after usage.
 func ObtainSystemClock() *SystemClock {
 	clock := C.gst_system_clock_obtain()
-	return &SystemClock{wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(clock))})}
+	obj := wrapClock(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(clock))})
+	adoptObject(obj.BaseObject())
+	return &SystemClock{obj}
 }
@@ -18,13 +18,13 @@ import (
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
-	gopointer "github.com/mattn/go-pointer"
 )
 
 // TagList is a go wrapper around a GstTagList. For now, until the rest of the methods are
 // implemnented, this struct is primarily used for retrieving serialized copies of the tags.
 type TagList struct {
-	ptr *C.GstTagList
+	ptr  *C.GstTagList
+	refs refTracker
 }
 
 // FromGstTagListUnsafe wraps the pointer to the given C GstTagList with the go type.
@@ -38,7 +38,7 @@ func FromGstTagListUnsafe(tags unsafe.Pointer) *TagList { return wrapTagList(C.t
 //   // true
 //
 func NewEmptyTagList() *TagList {
-	return wrapTagList(C.gst_tag_list_new_empty())
+	return takeTagList(C.gst_tag_list_new_empty())
 }
 
 // NewTagListFromString creates a new tag list from the given string. This is the same format produced
@@ -50,7 +50,7 @@ func NewTagListFromString(tags string) *TagList {
 	if tagList == nil {
 		return nil
 	}
-	return wrapTagList(tagList)
+	return takeTagList(tagList)
 }
 
 // Instance returns the underlying GstTagList instance.
@@ -60,11 +60,11 @@ func (t *TagList) Instance() *C.GstTagList { return C.toGstTagList(unsafe.Pointe
 func (t *TagList) String() string { return C.GoString(C.gst_tag_list_to_string(t.Instance())) }
 
 // Ref increases the ref count on this TagList by one.
-func (t *TagList) Ref() *TagList { return wrapTagList(C.gst_tag_list_ref(t.Instance())) }
+func (t *TagList) Ref() *TagList { return takeTagList(C.gst_tag_list_ref(t.Instance())) }
 
 // Unref decreses the ref count on this TagList by one. When the ref count reaches zero, the object
 // is destroyed.
-func (t *TagList) Unref() { C.gst_tag_list_unref(t.Instance()) }
+func (t *TagList) Unref() { unrefMiniObject(t) }
 
 // AddValue adds a value to a given tag using the given merge mode. If the value provided
 // cannot be coerced to a GValue, nothing will happen.
@@ -105,7 +105,7 @@ func (t *TagList) AddValues(mergeMode TagMergeMode, tag Tag, vals ...interface{}
 // to hold on to a reference to the data, you should use Ref.
 //
 // When you are finished with the taglist, call Unref on it.
-func (t *TagList) Copy() *TagList { return wrapTagList(C.gst_tag_list_copy(t.Instance())) }
+func (t *TagList) Copy() *TagList { return takeTagList(C.gst_tag_list_copy(t.Instance())) }
 
 // TagListForEachFunc is a function that will be called in ForEach. The function may not modify the tag list.
 type TagListForEachFunc func(tagList *TagList, tag Tag)
@@ -127,8 +127,8 @@ type TagListForEachFunc func(tagList *TagList, tag Tag)
 //   // album : GstreamerInGo
 //
 func (t *TagList) ForEach(f TagListForEachFunc) {
-	ptr := gopointer.Save(f)
-	defer gopointer.Unref(ptr)
+	ptr := savePointer(f)
+	defer unrefPointer(ptr)
 	C.gst_tag_list_foreach(
 		t.Instance(),
 		C.GstTagForeachFunc(C.cgoTagForEachFunc),
@@ -387,7 +387,7 @@ func (t *TagList) GetSample(tag Tag) (value *Sample, ok bool) {
 		&gout,
 	)
 	if gobool(gok) {
-		return wrapSample(gout), true
+		return takeSample(gout), true
 	}
 	return nil, false
 }
@@ -405,7 +405,7 @@ func (t *TagList) GetSampleIndex(tag Tag, idx uint) (value *Sample, ok bool) {
 		&gout,
 	)
 	if gobool(gok) {
-		return wrapSample(gout), true
+		return takeSample(gout), true
 	}
 	return nil, false
 }
@@ -539,12 +539,19 @@ func (t *TagList) IsWritable() bool { return gobool(C.tagListIsWritable(t.Instan
 
 // MakeWritable will return a writable copy of the tag list if it is not already so.
 func (t *TagList) MakeWritable() *TagList {
-	return wrapTagList(C.makeTagListWritable(t.Instance()))
+	return takeTagList(C.makeTagListWritable(t.give()))
+}
+
+// MakeMut makes this tag list writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and t refers to the copy from then on.
+// Unlike MakeWritable, t remains valid after the call and must be released as usual.
+func (t *TagList) MakeMut() {
+	t.ptr = C.toGstTagList(unsafe.Pointer(makeMutMiniObject(t)))
 }
 
 // Merge merges the two tag lists with the given mode.
 func (t *TagList) Merge(tagList *TagList, mergeMode TagMergeMode) *TagList {
-	return wrapTagList(C.gst_tag_list_merge(
+	return takeTagList(C.gst_tag_list_merge(
 		t.Instance(),
 		tagList.Instance(),
 		C.GstTagMergeMode(mergeMode),
@@ -0,0 +1,11 @@
+package gst
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	Init(nil)
+	os.Exit(m.Run())
+}
@@ -6,7 +6,8 @@ import "unsafe"
 
 // TOC is a go representation of a GstToc.
 type TOC struct {
-	ptr *C.GstToc
+	ptr  *C.GstToc
+	refs refTracker
 }
 
 // FromGstTOCUnsafe wraps the pointer to the given C GstToc with the go type.
@@ -19,7 +20,7 @@ func NewTOC(scope TOCScope) *TOC {
 	if toc == nil {
 		return nil
 	}
-	return wrapTOC(toc)
+	return takeTOC(toc)
 }
 
 // Instance returns the underlying GstToc instance.
@@ -27,23 +28,30 @@ func (t *TOC) Instance() *C.GstToc { return t.ptr }
 
 // Ref increases the ref count on the TOC by one.
 func (t *TOC) Ref() *TOC {
-	C.tocRef(t.Instance())
+	refMiniObject(t)
 	return t
 }
 
 // Unref decreases the ref count on the TOC by one.
 func (t *TOC) Unref() {
-	C.tocUnref(t.Instance())
+	unrefMiniObject(t)
 }
 
 // MakeWritable returns a writable copy of the TOC if it isn't already,
 func (t *TOC) MakeWritable() *TOC {
-	return wrapTOC(C.makeTocWritable(t.Instance()))
+	return takeTOC(C.makeTocWritable(t.give()))
+}
+
+// MakeMut makes this TOC writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and t refers to the copy from then on.
+// Unlike MakeWritable, t remains valid after the call and must be released as usual.
+func (t *TOC) MakeMut() {
+	t.ptr = (*C.GstToc)(unsafe.Pointer(makeMutMiniObject(t)))
 }
 
 // Copy creates a copy of the TOC.
 func (t *TOC) Copy() *TOC {
-	return wrapTOC(C.copyToc(t.Instance()))
+	return takeTOC(C.copyToc(t.Instance()))
 }
 
 // AppendEntry appends the given TOCEntry to this TOC.
@@ -110,7 +118,8 @@ func (t *TOC) SetTags(tagList *TagList) {
 
 // TOCEntry is a go representation of a GstTocEntry,
 type TOCEntry struct {
-	ptr *C.GstTocEntry
+	ptr  *C.GstTocEntry
+	refs refTracker
 }
 
 // NewTOCEntry creates a new TOCEntry with the given UID and type.
@@ -124,7 +133,7 @@ func NewTOCEntry(entryType TOCEntryType, uid string) *TOCEntry {
 	if entry == nil {
 		return nil
 	}
-	return wrapTOCEntry(entry)
+	return takeTOCEntry(entry)
 }
 
 // Instance returns the underlying GstTocEntry instance.
@@ -132,23 +141,30 @@ func (t *TOCEntry) Instance() *C.GstTocEntry { return t.ptr }
 
 // Ref increases the ref count on the TOCEntry by one.
 func (t *TOCEntry) Ref() *TOCEntry {
-	C.tocEntryRef(t.Instance())
+	refMiniObject(t)
 	return t
 }
 
 // Unref decreases the ref count on the TOCEntry by one.
 func (t *TOCEntry) Unref() {
-	C.tocEntryUnref(t.Instance())
+	unrefMiniObject(t)
 }
 
 // MakeWritable returns a writable copy of the TOCEntry if it is not already so.
 func (t *TOCEntry) MakeWritable() *TOCEntry {
-	return wrapTOCEntry(C.makeTocEntryWritable(t.Instance()))
+	return takeTOCEntry(C.makeTocEntryWritable(t.give()))
+}
+
+// MakeMut makes this TOC entry writable in place, copying it if it is shared with anyone else. When
+// a copy is made, the reference held on the original is released and t refers to the copy from then on.
+// Unlike MakeWritable, t remains valid after the call and must be released as usual.
+func (t *TOCEntry) MakeMut() {
+	t.ptr = (*C.GstTocEntry)(unsafe.Pointer(makeMutMiniObject(t)))
 }
 
 // Copy creates a copy of the TOCEntry
 func (t *TOCEntry) Copy() *TOCEntry {
-	return wrapTOCEntry(C.copyTocEntry(t.Instance()))
+	return takeTOCEntry(C.copyTocEntry(t.Instance()))
 }
 
 // AppendSubEntry appends the given entry as a subentry to this one.
@@ -32,7 +32,7 @@ func (g *gstTOCSetter) GetTOC() *TOC {
 	if toc == nil {
 		return nil
 	}
-	return wrapTOC(toc)
+	return takeTOC(toc)
 }
 
 func (g *gstTOCSetter) SetTOC(toc *TOC) { C.gst_toc_setter_set_toc(g.Instance(), toc.Instance()) }
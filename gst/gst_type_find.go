@@ -0,0 +1,114 @@
+package gst
+
+/*
+#include "gst.go.h"
+
+extern void goTypeFindFunc            (GstTypeFind * find, gpointer user_data);
+extern void goGDestroyNotifyFuncNoRun (gpointer user_data);
+
+void cgoTypeFindFunc (GstTypeFind * find, gpointer user_data)
+{
+	goTypeFindFunc(find, user_data);
+}
+
+void cgoTypeFindDestroyNotify (gpointer user_data)
+{
+	goGDestroyNotifyFuncNoRun(user_data);
+}
+
+gboolean registerGoTypeFind (GstPlugin * plugin, const gchar * name, guint rank, const gchar * extensions, GstCaps * possibleCaps, gpointer user_data)
+{
+	return gst_type_find_register(plugin, name, rank, cgoTypeFindFunc, extensions, possibleCaps, user_data, cgoTypeFindDestroyNotify);
+}
+*/
+import "C"
+
+import (
+	"math"
+	"strings"
+	"unsafe"
+)
+
+// TypeFind is a go representation of a GstTypeFind. It is passed to a TypeFindFunc to inspect the data
+// of a stream and suggest its caps. It is only valid for the duration of the call.
+type TypeFind struct {
+	ptr *C.GstTypeFind
+}
+
+// TypeFindFunc is a function that inspects the data of a stream with TypeFind.Peek, and calls
+// TypeFind.Suggest when it recognizes it.
+type TypeFindFunc func(find *TypeFind)
+
+// TypeFindRegister registers a new typefinder with the given name and rank, which decodebin, playbin and the
+// typefind element will call to identify streams. extensions are the file extensions typically used by the
+// format, and possibleCaps the caps that may be suggested, both of which may be nil. plugin may be nil to
+// register the typefinder statically, as is usual for applications.
+//
+//   gst.TypeFindRegister(nil, "application/x-my-archive", gst.RankPrimary, func(find *gst.TypeFind) {
+//       if data := find.Peek(0, 4); bytes.Equal(data, []byte("MYAR")) {
+//           find.Suggest(gst.TypeFindMaximum, gst.NewCapsFromString("application/x-my-archive"))
+//       }
+//   }, []string{"myar"}, gst.NewCapsFromString("application/x-my-archive"))
+func TypeFindRegister(plugin *Plugin, name string, rank Rank, f TypeFindFunc, extensions []string, possibleCaps *Caps) bool {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cExtensions *C.gchar
+	if len(extensions) > 0 {
+		cExtensions = (*C.gchar)(unsafe.Pointer(C.CString(strings.Join(extensions, ","))))
+		defer C.free(unsafe.Pointer(cExtensions))
+	}
+	var cPlugin *C.GstPlugin
+	if plugin != nil {
+		cPlugin = plugin.Instance()
+	}
+	var cCaps *C.GstCaps
+	if possibleCaps != nil {
+		cCaps = possibleCaps.Instance()
+	}
+	return gobool(C.registerGoTypeFind(
+		cPlugin,
+		(*C.gchar)(unsafe.Pointer(cName)),
+		C.guint(rank),
+		cExtensions,
+		cCaps,
+		(C.gpointer)(savePointer(f)),
+	))
+}
+
+// Instance returns the underlying GstTypeFind instance.
+func (t *TypeFind) Instance() *C.GstTypeFind { return t.ptr }
+
+// Peek returns a copy of size bytes of the stream starting at offset. A negative offset is relative to the
+// end of the stream. nil is returned if the data is not available, for example because the stream is
+// shorter, or if size is negative.
+func (t *TypeFind) Peek(offset int64, size int) []byte {
+	if size < 0 || size > math.MaxInt32 {
+		return nil
+	}
+	data := C.gst_type_find_peek(t.Instance(), C.gint64(offset), C.guint(size))
+	if data == nil {
+		return nil
+	}
+	return C.GoBytes(unsafe.Pointer(data), C.int(size))
+}
+
+// Suggest suggests the given caps with the given probability. It may be called multiple times, and the
+// caps with the highest probability are used. The caps are not taken and can be unreffed afterwards.
+func (t *TypeFind) Suggest(probability TypeFindProbability, caps *Caps) {
+	C.gst_type_find_suggest(t.Instance(), C.guint(probability), caps.Instance())
+}
+
+// SuggestMediaType is a convenience function around Suggest for caps of the given media type without
+// any fields.
+func (t *TypeFind) SuggestMediaType(probability TypeFindProbability, mediaType string) {
+	cMediaType := C.CString(mediaType)
+	defer C.free(unsafe.Pointer(cMediaType))
+	caps := C.gst_caps_new_empty_simple(cMediaType)
+	defer C.gst_caps_unref(caps)
+	C.gst_type_find_suggest(t.Instance(), C.guint(probability), caps)
+}
+
+// GetLength returns the length of the stream in bytes, or 0 if it is unknown.
+func (t *TypeFind) GetLength() int64 {
+	return int64(C.gst_type_find_get_length(t.Instance()))
+}
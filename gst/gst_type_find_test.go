@@ -0,0 +1,79 @@
+package gst
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestTypeFindPeekAndSuggest(t *testing.T) {
+	data := append([]byte("GOTF"), bytes.Repeat([]byte{0xab}, 4092)...)
+	copy(data[len(data)-4:], "TAIL")
+	path := filepath.Join(t.TempDir(), "stream.gotf")
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var length int64
+	var tail, negative, pastEnd []byte
+	called := false
+	caps := NewCapsFromString("application/x-go-test, version=(int)1")
+	ok := TypeFindRegister(nil, "application/x-go-test", RankPrimary, func(find *TypeFind) {
+		if !bytes.Equal(find.Peek(0, 4), []byte("GOTF")) {
+			return
+		}
+		mu.Lock()
+		called = true
+		length = find.GetLength()
+		tail = find.Peek(-4, 4)
+		negative = find.Peek(0, -1)
+		pastEnd = find.Peek(int64(len(data)), 1)
+		mu.Unlock()
+		find.Suggest(TypeFindMaximum, caps)
+	}, []string{"gotf"}, caps)
+	if !ok {
+		t.Fatal("Could not register the typefinder")
+	}
+
+	pipeline, err := NewPipelineFromString(fmt.Sprintf("filesrc location=%s ! typefind name=find ! fakesink", path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeline.Destroy()
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pipeline.SetStateContext(ctx, StatePaused); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	if !called {
+		t.Fatal("Expected the typefinder to be called with the data")
+	}
+	if length != int64(len(data)) {
+		t.Errorf("Expected the length of the stream to be %d, got %d", len(data), length)
+	}
+	if !bytes.Equal(tail, []byte("TAIL")) {
+		t.Errorf("Expected a negative offset to peek from the end, got %q", tail)
+	}
+	if negative != nil || pastEnd != nil {
+		t.Errorf("Expected nil for a negative size and for data past the end, got %v and %v", negative, pastEnd)
+	}
+	mu.Unlock()
+
+	find, err := pipeline.GetElementByName("find")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer find.Unref()
+	pad := find.GetStaticPad("src")
+	defer pad.Unref()
+	if found := pad.GetCurrentCaps(); found == nil || !found.IsEqual(caps) {
+		t.Errorf("Expected the suggested caps to be used, got %v", found)
+	}
+}
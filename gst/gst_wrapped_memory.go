@@ -0,0 +1,211 @@
+package gst
+
+/*
+#include <string.h>
+#include "gst.go.h"
+
+extern void goGDestroyNotifyFunc (gpointer data);
+
+void cgoWrappedMemoryRelease (gpointer data) {
+	goGDestroyNotifyFunc(data);
+}
+
+gpointer copyWrappedBytes (gconstpointer data, gsize size, gsize maxsize)
+{
+	gpointer out = g_malloc0(maxsize);
+	memcpy(out, data, size);
+	return out;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// cAllocation is a region allocated with AllocCBytes.
+type cAllocation struct {
+	base, size uintptr
+}
+
+// cAllocations holds the regions allocated with AllocCBytes, sorted by their start address.
+var (
+	cAllocations   []cAllocation
+	cAllocationsMu sync.RWMutex
+)
+
+// findCAllocation returns the index of the allocation with the highest start address at or below
+// addr, or -1 if there is none. It must be called with cAllocationsMu held.
+func findCAllocation(addr uintptr) int {
+	return sort.Search(len(cAllocations), func(i int) bool { return cAllocations[i].base > addr }) - 1
+}
+
+// AllocCBytes allocates a byte slice of the given size outside of the go heap. Since the garbage collector
+// does not manage this memory, slices of it can be handed to GStreamer with NewBufferWrapped or
+// NewMemoryWrappedFull without being copied. This is useful for ring buffers and other large, long-lived
+// allocations that frames are produced into.
+//
+// The memory is not zeroed. It must be freed with FreeCBytes once GStreamer has released every buffer
+// wrapping it, which is signaled by the release functions passed to the wrapping functions.
+func AllocCBytes(size int) []byte {
+	if size <= 0 {
+		return nil
+	}
+	ptr := C.malloc(C.size_t(size))
+	if ptr == nil {
+		panic(fmt.Sprintf("Failed to allocate %d bytes", size))
+	}
+	base := uintptr(ptr)
+	cAllocationsMu.Lock()
+	idx := findCAllocation(base) + 1
+	cAllocations = append(cAllocations, cAllocation{})
+	copy(cAllocations[idx+1:], cAllocations[idx:])
+	cAllocations[idx] = cAllocation{base: base, size: uintptr(size)}
+	cAllocationsMu.Unlock()
+	return cBytes(ptr, size)
+}
+
+// cBytes returns a byte slice of size bytes backed by the C memory at ptr, without copying it.
+func cBytes(ptr unsafe.Pointer, size int) []byte {
+	if ptr == nil || size <= 0 {
+		return nil
+	}
+	var out []byte
+	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&out))
+	hdr.Data = uintptr(ptr)
+	hdr.Len = size
+	hdr.Cap = size
+	return out
+}
+
+// FreeCBytes frees a byte slice previously returned from AllocCBytes. An error is returned if b does not
+// start at a region allocated with AllocCBytes, e.g. because it was already freed or is a sub-slice of one.
+func FreeCBytes(b []byte) error {
+	if cap(b) == 0 {
+		return errors.New("cannot free an empty slice")
+	}
+	base := uintptr(unsafe.Pointer(&b[:1][0]))
+	cAllocationsMu.Lock()
+	idx := findCAllocation(base)
+	if idx < 0 || cAllocations[idx].base != base {
+		cAllocationsMu.Unlock()
+		return fmt.Errorf("the slice at %#x was not allocated with AllocCBytes", base)
+	}
+	cAllocations = append(cAllocations[:idx], cAllocations[idx+1:]...)
+	cAllocationsMu.Unlock()
+	C.free(unsafe.Pointer(&b[:1][0]))
+	return nil
+}
+
+// isCBytes returns true if b lies entirely within a region allocated with AllocCBytes.
+func isCBytes(b []byte) bool {
+	return cap(b) > 0 && cBytesAvailable(b) >= int64(cap(b))
+}
+
+// cBytesAvailable returns the number of bytes from the start of b to the end of the region allocated
+// with AllocCBytes that holds it, or -1 if b does not start in such a region.
+func cBytesAvailable(b []byte) int64 {
+	if cap(b) == 0 {
+		return -1
+	}
+	start := uintptr(unsafe.Pointer(&b[:1][0]))
+	cAllocationsMu.RLock()
+	defer cAllocationsMu.RUnlock()
+	idx := findCAllocation(start)
+	if idx < 0 {
+		return -1
+	}
+	region := cAllocations[idx]
+	if start >= region.base+region.size {
+		return -1
+	}
+	return int64(region.base + region.size - start)
+}
+
+// wrapBytes returns a pointer to data that can be retained by C, along with the user data and destroy
+// notify that release it. Memory allocated with AllocCBytes is wrapped as is. Memory on the go heap cannot
+// be retained by C, so it is copied into a new allocation of maxSize bytes which is freed by the destroy
+// notify. In both cases release is called from the destroy notify, once C no longer uses the data.
+//
+// ok is false, and nothing is allocated, if maxSize is shorter than data, extends past the end of the
+// AllocCBytes region, or does not hold the region from offset and size.
+func wrapBytes(data []byte, maxSize, offset, size int64, release func()) (ptr, userData C.gpointer, notify C.GDestroyNotify, ok bool) {
+	if maxSize < int64(len(data)) || offset < 0 || size < 0 || offset+size > maxSize {
+		return nil, nil, nil, false
+	}
+	if isCBytes(data) {
+		if maxSize > cBytesAvailable(data) {
+			return nil, nil, nil, false
+		}
+		if release == nil {
+			return C.gpointer(unsafe.Pointer(&data[:1][0])), nil, nil, true
+		}
+		return C.gpointer(unsafe.Pointer(&data[:1][0])),
+			C.gpointer(savePointer(release)),
+			C.GDestroyNotify(C.cgoWrappedMemoryRelease), true
+	}
+	var src unsafe.Pointer
+	if len(data) > 0 {
+		src = unsafe.Pointer(&data[0])
+	}
+	ptr = C.copyWrappedBytes(C.gconstpointer(src), C.gsize(len(data)), C.gsize(maxSize))
+	if release == nil {
+		return ptr, ptr, C.GDestroyNotify(C.g_free), true
+	}
+	return ptr, C.gpointer(savePointer(func() {
+		C.g_free(ptr)
+		release()
+	})), C.GDestroyNotify(C.cgoWrappedMemoryRelease), true
+}
+
+// NewBufferWrapped returns a new buffer wrapping the given data. release is called once GStreamer no longer
+// uses the data, at which point it can be reused or freed. release may be called from any thread, and may
+// be nil.
+//
+// The data is only shared without copying if it was allocated with AllocCBytes, since memory on the go heap
+// must not be retained by C. Other slices are copied, and release is still only called once the copy is
+// released.
+//
+//   ring := gst.AllocCBytes(frameSize * 8)
+//
+//   frame := ring[i*frameSize : (i+1)*frameSize]
+//   buf := gst.NewBufferWrapped(frame, func() {
+//       // the frame slot can be written to again
+//   })
+//   src.PushBuffer(buf)
+func NewBufferWrapped(data []byte, release func()) *Buffer {
+	return NewBufferFull(0, data, int64(len(data)), 0, int64(len(data)), release)
+}
+
+// NewMemoryWrappedFull allocates a new memory block that wraps the given data. The memory will have the region
+// from offset and size visible, and maxSize must be at least the length of data. release is called once
+// GStreamer no longer uses the data, and may be nil. The same rules as NewBufferWrapped apply to when the
+// data is copied. For data allocated with AllocCBytes, maxSize cannot extend past the end of the
+// allocation. nil is returned, without calling release, if the sizes are invalid.
+//
+// The prefix/padding must be filled with 0 if flags contains MemoryFlagZeroPrefixed
+// and MemoryFlagZeroPadded respectively.
+func NewMemoryWrappedFull(flags MemoryFlags, data []byte, maxSize, offset, size int64, release func()) *Memory {
+	ptr, userData, notify, ok := wrapBytes(data, maxSize, offset, size, release)
+	if !ok {
+		return nil
+	}
+	mem := C.gst_memory_new_wrapped(
+		C.GstMemoryFlags(flags),
+		ptr,
+		C.gsize(maxSize),
+		C.gsize(offset),
+		C.gsize(size),
+		userData,
+		notify,
+	)
+	if mem == nil {
+		return nil
+	}
+	return takeMemory(mem)
+}
@@ -0,0 +1,83 @@
+package gst
+
+import "testing"
+
+func TestCBytesOwnership(t *testing.T) {
+	data := AllocCBytes(64)
+	if !isCBytes(data) || !isCBytes(data[16:32]) {
+		t.Fatal("Expected slices of an allocation to be recognized as C bytes")
+	}
+	if isCBytes(make([]byte, 64)) {
+		t.Fatal("Expected a go slice not to be recognized as C bytes")
+	}
+	if err := FreeCBytes(data[16:]); err == nil {
+		t.Fatal("Expected freeing a sub-slice of an allocation to fail")
+	}
+	if err := FreeCBytes(make([]byte, 64)); err == nil {
+		t.Fatal("Expected freeing a go slice to fail")
+	}
+	if err := FreeCBytes(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := FreeCBytes(data); err == nil {
+		t.Fatal("Expected freeing an allocation twice to fail")
+	}
+}
+
+func TestNewBufferFullNotifiesOnDestroy(t *testing.T) {
+	released := make(chan struct{})
+	buf := NewBufferFull(0, []byte("hello-world"), 1024, 0, 11, func() { close(released) })
+	if buf == nil {
+		t.Fatal("Failed to create the buffer")
+	}
+	select {
+	case <-released:
+		t.Fatal("The notify function was called before the buffer was destroyed")
+	default:
+	}
+	if got := string(buf.Bytes()); got != "hello-world" {
+		t.Fatalf("Unexpected buffer contents: %q", got)
+	}
+	buf.Unref()
+	select {
+	case <-released:
+	default:
+		t.Fatal("The notify function was not called when the buffer was destroyed")
+	}
+}
+
+func TestWrappedMaxSizeBounds(t *testing.T) {
+	data := AllocCBytes(64)
+	defer FreeCBytes(data)
+
+	if buf := NewBufferFull(0, data[32:48], 32, 0, 16, nil); buf == nil {
+		t.Error("Expected a maxSize up to the end of the allocation to be accepted")
+	} else {
+		buf.Unref()
+	}
+	if buf := NewBufferFull(0, data[32:48], 33, 0, 16, nil); buf != nil {
+		buf.Unref()
+		t.Error("Expected a maxSize past the end of the allocation to be rejected")
+	}
+	if mem := NewMemoryWrappedFull(0, data[32:48], 33, 0, 16, nil); mem != nil {
+		mem.Unref()
+		t.Error("Expected a maxSize past the end of the allocation to be rejected")
+	}
+
+	before := LiveCallbacks()
+	released := false
+	if buf := NewBufferFull(0, []byte("hello-world"), 4, 0, 4, func() { released = true }); buf != nil {
+		buf.Unref()
+		t.Error("Expected a maxSize shorter than the data to be rejected")
+	}
+	if mem := NewMemoryWrappedFull(0, []byte("hello-world"), 16, 8, 16, func() { released = true }); mem != nil {
+		mem.Unref()
+		t.Error("Expected a region past maxSize to be rejected")
+	}
+	if released {
+		t.Error("Expected release not to be called for rejected data")
+	}
+	if after := LiveCallbacks(); after != before {
+		t.Errorf("Expected no callbacks to be kept for rejected data, got %d more", after-before)
+	}
+}
@@ -142,6 +142,10 @@ func init() {
 			T: glib.Type(C.gst_message_get_type()),
 			F: marshalMessage,
 		},
+		{
+			T: glib.Type(C.GST_TYPE_PROMISE),
+			F: marshalPromise,
+		},
 	}
 
 	glib.RegisterGValueMarshalers(tm)
@@ -154,46 +158,36 @@ func uintptrToGVal(p uintptr) *C.GValue {
 
 // Object wrappers
 
-func wrapAllocator(obj *glib.Object) *Allocator           { return &Allocator{wrapObject(obj)} }
-func wrapBin(obj *glib.Object) *Bin                       { return &Bin{wrapElement(obj)} }
-func wrapBuffer(buf *C.GstBuffer) *Buffer                 { return &Buffer{ptr: buf} }
-func wrapBufferList(bufList *C.GstBufferList) *BufferList { return &BufferList{ptr: bufList} }
-func wrapBufferPool(obj *glib.Object) *BufferPool         { return &BufferPool{wrapObject(obj)} }
-func wrapBus(obj *glib.Object) *Bus                       { return &Bus{Object: wrapObject(obj)} }
-func wrapCaps(caps *C.GstCaps) *Caps                      { return &Caps{native: caps} }
-func wrapClock(obj *glib.Object) *Clock                   { return &Clock{wrapObject(obj)} }
-func wrapContext(ctx *C.GstContext) *Context              { return &Context{ptr: ctx} }
-func wrapDevice(obj *glib.Object) *Device                 { return &Device{wrapObject(obj)} }
-func wrapElement(obj *glib.Object) *Element               { return &Element{wrapObject(obj)} }
-func wrapEvent(ev *C.GstEvent) *Event                     { return &Event{ptr: ev} }
-func wrapGhostPad(obj *glib.Object) *GhostPad             { return &GhostPad{wrapProxyPad(obj)} }
-func wrapMainContext(ctx *C.GMainContext) *MainContext    { return &MainContext{ptr: ctx} }
-func wrapMainLoop(loop *C.GMainLoop) *MainLoop            { return &MainLoop{ptr: loop} }
-func wrapMemory(mem *C.GstMemory) *Memory                 { return &Memory{ptr: mem} }
-func wrapMessage(msg *C.GstMessage) *Message              { return &Message{msg: msg} }
-func wrapMeta(meta *C.GstMeta) *Meta                      { return &Meta{ptr: meta} }
-func wrapMetaInfo(info *C.GstMetaInfo) *MetaInfo          { return &MetaInfo{ptr: info} }
-func wrapPad(obj *glib.Object) *Pad                       { return &Pad{wrapObject(obj)} }
-func wrapPadTemplate(obj *glib.Object) *PadTemplate       { return &PadTemplate{wrapObject(obj)} }
-func wrapPipeline(obj *glib.Object) *Pipeline             { return &Pipeline{Bin: wrapBin(obj)} }
-func wrapPluginFeature(obj *glib.Object) *PluginFeature   { return &PluginFeature{wrapObject(obj)} }
-func wrapPlugin(obj *glib.Object) *Plugin                 { return &Plugin{wrapObject(obj)} }
-func wrapProxyPad(obj *glib.Object) *ProxyPad             { return &ProxyPad{wrapPad(obj)} }
-func wrapQuery(query *C.GstQuery) *Query                  { return &Query{ptr: query} }
-func wrapRegistry(obj *glib.Object) *Registry             { return &Registry{wrapObject(obj)} }
-func wrapSample(sample *C.GstSample) *Sample              { return &Sample{sample: sample} }
-func wrapSegment(segment *C.GstSegment) *Segment          { return &Segment{ptr: segment} }
-func wrapStream(obj *glib.Object) *Stream                 { return &Stream{wrapObject(obj)} }
-func wrapTagList(tagList *C.GstTagList) *TagList          { return &TagList{ptr: tagList} }
-func wrapTOC(toc *C.GstToc) *TOC                          { return &TOC{ptr: toc} }
-func wrapTOCEntry(toc *C.GstTocEntry) *TOCEntry           { return &TOCEntry{ptr: toc} }
+func wrapAllocator(obj *glib.Object) *Allocator         { return &Allocator{wrapObject(obj)} }
+func wrapBin(obj *glib.Object) *Bin                     { return &Bin{wrapElement(obj)} }
+func wrapBufferPool(obj *glib.Object) *BufferPool       { return &BufferPool{wrapObject(obj)} }
+func wrapBus(obj *glib.Object) *Bus                     { return &Bus{Object: wrapObject(obj)} }
+func wrapClock(obj *glib.Object) *Clock                 { return &Clock{wrapObject(obj)} }
+func wrapDevice(obj *glib.Object) *Device               { return &Device{wrapObject(obj)} }
+func wrapElement(obj *glib.Object) *Element             { return &Element{wrapObject(obj)} }
+func wrapGhostPad(obj *glib.Object) *GhostPad           { return &GhostPad{wrapProxyPad(obj)} }
+func wrapMainContext(ctx *C.GMainContext) *MainContext  { return &MainContext{ptr: ctx} }
+func wrapMainLoop(loop *C.GMainLoop) *MainLoop          { return &MainLoop{ptr: loop} }
+func wrapMeta(meta *C.GstMeta) *Meta                    { return &Meta{ptr: meta} }
+func wrapMetaInfo(info *C.GstMetaInfo) *MetaInfo        { return &MetaInfo{ptr: info} }
+func wrapPad(obj *glib.Object) *Pad                     { return &Pad{wrapObject(obj)} }
+func wrapPadTemplate(obj *glib.Object) *PadTemplate     { return &PadTemplate{wrapObject(obj)} }
+func wrapPipeline(obj *glib.Object) *Pipeline           { return &Pipeline{Bin: wrapBin(obj)} }
+func wrapPluginFeature(obj *glib.Object) *PluginFeature { return &PluginFeature{wrapObject(obj)} }
+func wrapPlugin(obj *glib.Object) *Plugin               { return &Plugin{wrapObject(obj)} }
+func wrapProxyPad(obj *glib.Object) *ProxyPad           { return &ProxyPad{wrapPad(obj)} }
+func wrapRegistry(obj *glib.Object) *Registry           { return &Registry{wrapObject(obj)} }
+func wrapSegment(segment *C.GstSegment) *Segment        { return &Segment{ptr: segment} }
+func wrapStream(obj *glib.Object) *Stream               { return &Stream{wrapObject(obj)} }
 
 func wrapCapsFeatures(features *C.GstCapsFeatures) *CapsFeatures {
 	return &CapsFeatures{native: features}
 }
 
 func wrapObject(obj *glib.Object) *Object {
-	return &Object{InitiallyUnowned: &glib.InitiallyUnowned{Object: obj}}
+	o := &Object{InitiallyUnowned: &glib.InitiallyUnowned{Object: obj}}
+	trackObject(o)
+	return o
 }
 
 func wrapElementFactory(obj *glib.Object) *ElementFactory {
@@ -208,6 +202,210 @@ func wrapAllocationParams(obj *C.GstAllocationParams) *AllocationParams {
 	return &AllocationParams{ptr: obj}
 }
 
+// MiniObject wrappers
+//
+// wrapX functions are used for objects borrowed from C (transfer none), and takeX functions
+// for objects the caller owns a reference to (transfer full).
+
+func wrapBuffer(buf *C.GstBuffer) *Buffer {
+	w := &Buffer{ptr: buf}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeBuffer(buf *C.GstBuffer) *Buffer {
+	w := &Buffer{ptr: buf}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapBufferList(bufList *C.GstBufferList) *BufferList {
+	w := &BufferList{ptr: bufList}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeBufferList(bufList *C.GstBufferList) *BufferList {
+	w := &BufferList{ptr: bufList}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapCaps(caps *C.GstCaps) *Caps {
+	w := &Caps{native: caps}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeCaps(caps *C.GstCaps) *Caps {
+	w := &Caps{native: caps}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapContext(ctx *C.GstContext) *Context {
+	w := &Context{ptr: ctx}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeContext(ctx *C.GstContext) *Context {
+	w := &Context{ptr: ctx}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapEvent(ev *C.GstEvent) *Event {
+	w := &Event{ptr: ev}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeEvent(ev *C.GstEvent) *Event {
+	w := &Event{ptr: ev}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapMemory(mem *C.GstMemory) *Memory {
+	w := &Memory{ptr: mem}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeMemory(mem *C.GstMemory) *Memory {
+	w := &Memory{ptr: mem}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapMessage(msg *C.GstMessage) *Message {
+	w := &Message{msg: msg}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeMessage(msg *C.GstMessage) *Message {
+	w := &Message{msg: msg}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapPromise(promise *C.GstPromise) *Promise {
+	w := &Promise{ptr: promise}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takePromise(promise *C.GstPromise) *Promise {
+	w := &Promise{ptr: promise}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapQuery(query *C.GstQuery) *Query {
+	w := &Query{ptr: query}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeQuery(query *C.GstQuery) *Query {
+	w := &Query{ptr: query}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapSample(sample *C.GstSample) *Sample {
+	w := &Sample{sample: sample}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeSample(sample *C.GstSample) *Sample {
+	w := &Sample{sample: sample}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapTagList(tagList *C.GstTagList) *TagList {
+	w := &TagList{ptr: tagList}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeTagList(tagList *C.GstTagList) *TagList {
+	w := &TagList{ptr: tagList}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapTOC(toc *C.GstToc) *TOC {
+	w := &TOC{ptr: toc}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeTOC(toc *C.GstToc) *TOC {
+	w := &TOC{ptr: toc}
+	trackMiniObject(w, true)
+	return w
+}
+
+func wrapTOCEntry(entry *C.GstTocEntry) *TOCEntry {
+	w := &TOCEntry{ptr: entry}
+	trackMiniObject(w, false)
+	return w
+}
+
+func takeTOCEntry(entry *C.GstTocEntry) *TOCEntry {
+	w := &TOCEntry{ptr: entry}
+	trackMiniObject(w, true)
+	return w
+}
+
+func (b *Buffer) miniObject() *C.GstMiniObject     { return C.toGstMiniObject(unsafe.Pointer(b.ptr)) }
+func (b *Buffer) tracker() *refTracker             { return &b.refs }
+func (b *BufferList) miniObject() *C.GstMiniObject { return C.toGstMiniObject(unsafe.Pointer(b.ptr)) }
+func (b *BufferList) tracker() *refTracker         { return &b.refs }
+func (c *Caps) miniObject() *C.GstMiniObject       { return C.toGstMiniObject(unsafe.Pointer(c.native)) }
+func (c *Caps) tracker() *refTracker               { return &c.refs }
+func (c *Context) miniObject() *C.GstMiniObject    { return C.toGstMiniObject(unsafe.Pointer(c.ptr)) }
+func (c *Context) tracker() *refTracker            { return &c.refs }
+func (e *Event) miniObject() *C.GstMiniObject      { return C.toGstMiniObject(unsafe.Pointer(e.ptr)) }
+func (e *Event) tracker() *refTracker              { return &e.refs }
+func (m *Memory) miniObject() *C.GstMiniObject     { return C.toGstMiniObject(unsafe.Pointer(m.ptr)) }
+func (m *Memory) tracker() *refTracker             { return &m.refs }
+func (m *Message) miniObject() *C.GstMiniObject    { return C.toGstMiniObject(unsafe.Pointer(m.msg)) }
+func (m *Message) tracker() *refTracker            { return &m.refs }
+func (p *Promise) miniObject() *C.GstMiniObject    { return C.toGstMiniObject(unsafe.Pointer(p.ptr)) }
+func (p *Promise) tracker() *refTracker            { return &p.refs }
+func (q *Query) miniObject() *C.GstMiniObject      { return C.toGstMiniObject(unsafe.Pointer(q.ptr)) }
+func (q *Query) tracker() *refTracker              { return &q.refs }
+func (s *Sample) miniObject() *C.GstMiniObject     { return C.toGstMiniObject(unsafe.Pointer(s.sample)) }
+func (s *Sample) tracker() *refTracker             { return &s.refs }
+func (t *TagList) miniObject() *C.GstMiniObject    { return C.toGstMiniObject(unsafe.Pointer(t.ptr)) }
+func (t *TagList) tracker() *refTracker            { return &t.refs }
+func (t *TOC) miniObject() *C.GstMiniObject        { return C.toGstMiniObject(unsafe.Pointer(t.ptr)) }
+func (t *TOC) tracker() *refTracker                { return &t.refs }
+func (t *TOCEntry) miniObject() *C.GstMiniObject   { return C.toGstMiniObject(unsafe.Pointer(t.ptr)) }
+func (t *TOCEntry) tracker() *refTracker           { return &t.refs }
+
+// give returns the underlying object for passing to a function that takes ownership of it.
+func (b *Buffer) give() *C.GstBuffer { return C.toGstBuffer(unsafe.Pointer(giveMiniObject(b))) }
+func (b *BufferList) give() *C.GstBufferList {
+	return C.toGstBufferList(unsafe.Pointer(giveMiniObject(b)))
+}
+func (c *Caps) give() *C.GstCaps         { return C.toGstCaps(unsafe.Pointer(giveMiniObject(c))) }
+func (c *Context) give() *C.GstContext   { return C.toGstContext(unsafe.Pointer(giveMiniObject(c))) }
+func (e *Event) give() *C.GstEvent       { return C.toGstEvent(unsafe.Pointer(giveMiniObject(e))) }
+func (m *Memory) give() *C.GstMemory     { return C.toGstMemory(unsafe.Pointer(giveMiniObject(m))) }
+func (m *Message) give() *C.GstMessage   { return C.toGstMessage(unsafe.Pointer(giveMiniObject(m))) }
+func (q *Query) give() *C.GstQuery       { return C.toGstQuery(unsafe.Pointer(giveMiniObject(q))) }
+func (s *Sample) give() *C.GstSample     { return C.toGstSample(unsafe.Pointer(giveMiniObject(s))) }
+func (t *TagList) give() *C.GstTagList   { return C.toGstTagList(unsafe.Pointer(giveMiniObject(t))) }
+func (t *TOC) give() *C.GstToc           { return (*C.GstToc)(unsafe.Pointer(giveMiniObject(t))) }
+func (t *TOCEntry) give() *C.GstTocEntry { return (*C.GstTocEntry)(unsafe.Pointer(giveMiniObject(t))) }
+
 // Clock wrappers
 
 func clockTimeToDuration(n ClockTime) time.Duration {
@@ -288,7 +486,12 @@ func marshalPad(p uintptr) (interface{}, error) {
 
 func marshalMessage(p uintptr) (interface{}, error) {
 	c := C.g_value_get_boxed(uintptrToGVal(p))
-	return &Message{(*C.GstMessage)(unsafe.Pointer(c))}, nil
+	return wrapMessage((*C.GstMessage)(unsafe.Pointer(c))), nil
+}
+
+func marshalPromise(p uintptr) (interface{}, error) {
+	c := C.g_value_get_boxed(uintptrToGVal(p))
+	return wrapPromise((*C.GstPromise)(unsafe.Pointer(c))), nil
 }
 
 func marshalObject(p uintptr) (interface{}, error) {
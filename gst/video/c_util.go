@@ -1,7 +1,7 @@
 package video
 
 /*
-#include <gst/gst.h>
+#include "gst.go.h"
 */
 import "C"
 
@@ -25,6 +25,13 @@ func durationToClockTime(d time.Duration) C.GstClockTime {
 	return C.GstClockTime(d.Nanoseconds())
 }
 
+func clockTimeToDuration(t C.GstClockTime) time.Duration {
+	if gst.ClockTime(t) == gst.ClockTimeNone {
+		return time.Duration(-1)
+	}
+	return time.Duration(t)
+}
+
 func fromCoreCaps(caps *gst.Caps) *C.GstCaps {
 	return (*C.GstCaps)(unsafe.Pointer(caps.Instance()))
 }
@@ -45,6 +52,10 @@ func fromCoreMessage(msg *gst.Message) *C.GstMessage {
 	return (*C.GstMessage)(unsafe.Pointer(msg.Instance()))
 }
 
+func fromCoreObject(obj *gst.Object) *C.GstObject {
+	return (*C.GstObject)(unsafe.Pointer(obj.Instance()))
+}
+
 func fromCoreQuery(query *gst.Query) *C.GstQuery {
 	return (*C.GstQuery)(unsafe.Pointer(query.Instance()))
 }
@@ -58,3 +69,10 @@ func fromCoreStructure(structure *gst.Structure) *C.GstStructure {
 }
 
 func gobool(b C.gboolean) bool { return int(b) > 0 }
+
+func gboolean(b bool) C.gboolean {
+	if b {
+		return C.gboolean(1)
+	}
+	return C.gboolean(0)
+}
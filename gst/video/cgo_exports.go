@@ -1,7 +1,7 @@
 package video
 
 /*
-#include <gst/gst.h>
+#include "gst.go.h"
 */
 import "C"
 
@@ -14,11 +14,12 @@ import (
 
 //export goVideoGDestroyNotifyFunc
 func goVideoGDestroyNotifyFunc(ptr C.gpointer) {
-	gopointer.Unref(unsafe.Pointer(ptr))
+	gst.UnrefPointerUnsafe(unsafe.Pointer(ptr))
 }
 
 //export goVideoConvertSampleCb
 func goVideoConvertSampleCb(gsample *C.GstSample, gerr *C.GError, userData C.gpointer) {
+	defer gst.RecoverCallbackUnsafe(nil, nil)
 	var sample *gst.Sample
 	var err error
 	if gerr != nil {
@@ -38,3 +39,87 @@ func goVideoConvertSampleCb(gsample *C.GstSample, gerr *C.GError, userData C.gpo
 	}
 	cb(sample, err)
 }
+
+//export goVideoEncoderStart
+func goVideoEncoderStart(enc *C.GstVideoEncoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).StartFunc(wrapCEncoder(enc)))
+}
+
+//export goVideoEncoderStop
+func goVideoEncoderStop(enc *C.GstVideoEncoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).StopFunc(wrapCEncoder(enc)))
+}
+
+//export goVideoEncoderSetFormat
+func goVideoEncoderSetFormat(enc *C.GstVideoEncoder, state *C.GstVideoCodecState) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).SetFormatFunc(wrapCEncoder(enc), wrapCodecState(state)))
+}
+
+//export goVideoEncoderHandleFrame
+func goVideoEncoderHandleFrame(enc *C.GstVideoEncoder, frame *C.GstVideoCodecFrame) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = C.GstFlowReturn(gst.FlowError) })
+	return C.GstFlowReturn(getEncoderCallbacks(enc).HandleFrameFunc(wrapCEncoder(enc), wrapCodecFrame(frame)))
+}
+
+//export goVideoEncoderFinish
+func goVideoEncoderFinish(enc *C.GstVideoEncoder) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = C.GstFlowReturn(gst.FlowError) })
+	return C.GstFlowReturn(getEncoderCallbacks(enc).DrainFunc(wrapCEncoder(enc)))
+}
+
+//export goVideoEncoderFlush
+func goVideoEncoderFlush(enc *C.GstVideoEncoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).FlushFunc(wrapCEncoder(enc)))
+}
+
+//export goVideoEncoderNegotiate
+func goVideoEncoderNegotiate(enc *C.GstVideoEncoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(enc), func() { ret = gboolean(false) })
+	return gboolean(getEncoderCallbacks(enc).NegotiateFunc(wrapCEncoder(enc)))
+}
+
+//export goVideoDecoderStart
+func goVideoDecoderStart(dec *C.GstVideoDecoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).StartFunc(wrapCDecoder(dec)))
+}
+
+//export goVideoDecoderStop
+func goVideoDecoderStop(dec *C.GstVideoDecoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).StopFunc(wrapCDecoder(dec)))
+}
+
+//export goVideoDecoderSetFormat
+func goVideoDecoderSetFormat(dec *C.GstVideoDecoder, state *C.GstVideoCodecState) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).SetFormatFunc(wrapCDecoder(dec), wrapCodecState(state)))
+}
+
+//export goVideoDecoderHandleFrame
+func goVideoDecoderHandleFrame(dec *C.GstVideoDecoder, frame *C.GstVideoCodecFrame) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = C.GstFlowReturn(gst.FlowError) })
+	return C.GstFlowReturn(getDecoderCallbacks(dec).HandleFrameFunc(wrapCDecoder(dec), wrapCodecFrame(frame)))
+}
+
+//export goVideoDecoderDrain
+func goVideoDecoderDrain(dec *C.GstVideoDecoder) (ret C.GstFlowReturn) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = C.GstFlowReturn(gst.FlowError) })
+	return C.GstFlowReturn(getDecoderCallbacks(dec).DrainFunc(wrapCDecoder(dec)))
+}
+
+//export goVideoDecoderFlush
+func goVideoDecoderFlush(dec *C.GstVideoDecoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).FlushFunc(wrapCDecoder(dec)))
+}
+
+//export goVideoDecoderNegotiate
+func goVideoDecoderNegotiate(dec *C.GstVideoDecoder) (ret C.gboolean) {
+	defer gst.RecoverCallbackUnsafe(unsafe.Pointer(dec), func() { ret = gboolean(false) })
+	return gboolean(getDecoderCallbacks(dec).NegotiateFunc(wrapCDecoder(dec)))
+}
@@ -22,7 +22,6 @@ import (
 	"time"
 	"unsafe"
 
-	gopointer "github.com/mattn/go-pointer"
 	"github.com/tinyzimmer/go-gst/gst"
 )
 
@@ -49,7 +48,7 @@ func ConvertSample(sample *gst.Sample, toCaps *gst.Caps, timeout time.Duration)
 	if ret == nil {
 		return nil, nil
 	}
-	return gst.FromGstSampleUnsafe(unsafe.Pointer(ret)), nil
+	return gst.FromGstSampleUnsafeFull(unsafe.Pointer(ret)), nil
 }
 
 // ConvertSampleAsync converts a raw video buffer into the specified output caps.
@@ -61,7 +60,7 @@ func ConvertSample(sample *gst.Sample, toCaps *gst.Caps, timeout time.Duration)
 // The callback will be called after conversion, when an error occurred or if conversion
 // didn't finish after timeout.
 func ConvertSampleAsync(sample *gst.Sample, toCaps *gst.Caps, timeout time.Duration, cb ConvertSampleCallback) {
-	ptr := gopointer.Save(cb)
+	ptr := gst.SavePointerUnsafe(cb)
 	C.gst_video_convert_sample_async(
 		fromCoreSample(sample),
 		fromCoreCaps(toCaps),
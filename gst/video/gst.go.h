@@ -0,0 +1,2 @@
+#include <stdlib.h>
+#include <gst/video/video.h>
@@ -1,12 +1,81 @@
 package video
 
 /*
+#include <stdlib.h>
 #include <gst/gst.h>
 #include <gst/video/video.h>
+
+GstVideoRegionOfInterestMeta * getRegionOfInterestMeta (GstBuffer * buffer) { return gst_buffer_get_video_region_of_interest_meta(buffer); }
+GstVideoTimeCodeMeta *         getTimeCodeMeta         (GstBuffer * buffer) { return gst_buffer_get_video_time_code_meta(buffer); }
+GstVideoCaptionMeta *          getCaptionMeta          (GstBuffer * buffer) { return gst_buffer_get_video_caption_meta(buffer); }
+
+#if GST_CHECK_VERSION(1, 18, 0)
+
+GstVideoAFDMeta * addAFDMeta (GstBuffer * buffer, guint8 field, GstVideoAFDSpec spec, GstVideoAFDValue afd)
+{
+	return gst_buffer_add_video_afd_meta(buffer, field, spec, afd);
+}
+
+GstVideoBarMeta * addBarMeta (GstBuffer * buffer, guint8 field, gboolean is_letterbox, guint bar_data1, guint bar_data2)
+{
+	return gst_buffer_add_video_bar_meta(buffer, field, is_letterbox, bar_data1, bar_data2);
+}
+
+GstVideoAFDMeta * getAFDMeta (GstBuffer * buffer) { return gst_buffer_get_video_afd_meta(buffer); }
+GstVideoBarMeta * getBarMeta (GstBuffer * buffer) { return gst_buffer_get_video_bar_meta(buffer); }
+
+#else
+
+// The AFD and bar metas were added in GStreamer 1.18, these mirror their definitions so the wrappers build
+// against older versions, where no meta can be added or found.
+
+typedef enum {
+	GST_VIDEO_AFD_SPEC_DVB_ETSI,
+	GST_VIDEO_AFD_SPEC_ATSC_A53,
+	GST_VIDEO_AFD_SPEC_SMPTE_ST2016_1
+} GstVideoAFDSpec;
+
+typedef enum {
+	GST_VIDEO_AFD_UNAVAILABLE = 0,
+	GST_VIDEO_AFD_16_9_TOP_ALIGNED = 2,
+	GST_VIDEO_AFD_14_9_TOP_ALIGNED = 3,
+	GST_VIDEO_AFD_GREATER_THAN_16_9 = 4,
+	GST_VIDEO_AFD_4_3_FULL_16_9_FULL = 8,
+	GST_VIDEO_AFD_4_3_FULL_4_3_PILLAR = 9,
+	GST_VIDEO_AFD_16_9_LETTER_16_9_FULL = 10,
+	GST_VIDEO_AFD_14_9_LETTER_14_9_PILLAR = 11,
+	GST_VIDEO_AFD_4_3_FULL_14_9_CENTER = 13,
+	GST_VIDEO_AFD_16_9_LETTER_14_9_CENTER = 14,
+	GST_VIDEO_AFD_16_9_LETTER_4_3_CENTER = 15
+} GstVideoAFDValue;
+
+typedef struct {
+	GstMeta          meta;
+	guint8           field;
+	GstVideoAFDSpec  spec;
+	GstVideoAFDValue afd;
+} GstVideoAFDMeta;
+
+typedef struct {
+	GstMeta  meta;
+	guint8   field;
+	gboolean is_letterbox;
+	guint    bar_data1;
+	guint    bar_data2;
+} GstVideoBarMeta;
+
+GstVideoAFDMeta * addAFDMeta (GstBuffer * buffer, guint8 field, GstVideoAFDSpec spec, GstVideoAFDValue afd) { return NULL; }
+GstVideoBarMeta * addBarMeta (GstBuffer * buffer, guint8 field, gboolean is_letterbox, guint bar_data1, guint bar_data2) { return NULL; }
+GstVideoAFDMeta * getAFDMeta (GstBuffer * buffer) { return NULL; }
+GstVideoBarMeta * getBarMeta (GstBuffer * buffer) { return NULL; }
+
+#endif
 */
 import "C"
 
 import (
+	"errors"
+	"fmt"
 	"unsafe"
 
 	"github.com/tinyzimmer/go-gst/gst"
@@ -45,3 +114,513 @@ func (c *CropMetaInfo) Width() uint { return uint(c.Instance().width) }
 
 // Height returns the cropped height.
 func (c *CropMetaInfo) Height() uint { return uint(c.Instance().height) }
+
+// iterateMetas calls f for every meta of the given API type on the buffer, until it returns false.
+func iterateMetas(buffer *gst.Buffer, api C.GType, f func(meta *C.GstMeta) bool) {
+	var state C.gpointer
+	for {
+		meta := C.gst_buffer_iterate_meta_filtered((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())), &state, api)
+		if meta == nil || !f(meta) {
+			return
+		}
+	}
+}
+
+// Meta is a go representation of a GstVideoMeta. It describes the layout of the pixels in a buffer,
+// and is required on buffers whose planes do not use the default offsets and strides of their Info,
+// e.g. with padding added by hardware. MapFrame takes it into account.
+type Meta struct {
+	ptr *C.GstVideoMeta
+}
+
+// AddMeta adds a Meta to the buffer describing a frame of the given format and size, with the default
+// offsets and strides.
+func AddMeta(buffer *gst.Buffer, flags FrameFlags, format Format, width, height uint) (*Meta, error) {
+	meta := C.gst_buffer_add_video_meta(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.GstVideoFrameFlags(flags),
+		C.GstVideoFormat(format),
+		C.guint(width), C.guint(height),
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add video meta")
+	}
+	return &Meta{ptr: meta}, nil
+}
+
+// AddMetaFull adds a Meta to the buffer describing a frame of the given format and size, whose planes
+// are at the given offsets in bytes, with the given strides. There must be an offset and a stride for
+// every plane of the format.
+func AddMetaFull(buffer *gst.Buffer, flags FrameFlags, format Format, width, height uint, offsets []int64, strides []int) (*Meta, error) {
+	nPlanes := len(offsets)
+	if nPlanes == 0 || nPlanes > C.GST_VIDEO_MAX_PLANES || len(strides) != nPlanes {
+		return nil, errors.New("There must be an offset and a stride for every plane")
+	}
+	var cOffsets [C.GST_VIDEO_MAX_PLANES]C.gsize
+	var cStrides [C.GST_VIDEO_MAX_PLANES]C.gint
+	for i := 0; i < nPlanes; i++ {
+		cOffsets[i] = C.gsize(offsets[i])
+		cStrides[i] = C.gint(strides[i])
+	}
+	meta := C.gst_buffer_add_video_meta_full(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.GstVideoFrameFlags(flags),
+		C.GstVideoFormat(format),
+		C.guint(width), C.guint(height),
+		C.guint(nPlanes),
+		&cOffsets[0], &cStrides[0],
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add video meta")
+	}
+	return &Meta{ptr: meta}, nil
+}
+
+// GetMeta returns the Meta on the buffer with the lowest id, or nil if it has none.
+func GetMeta(buffer *gst.Buffer) *Meta {
+	meta := C.gst_buffer_get_video_meta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &Meta{ptr: meta}
+}
+
+// GetMetaID returns the Meta on the buffer with the given id, or nil if there is none. Buffers with
+// multiple views hold a Meta for each.
+func GetMetaID(buffer *gst.Buffer, id int) *Meta {
+	meta := C.gst_buffer_get_video_meta_id((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())), C.gint(id))
+	if meta == nil {
+		return nil
+	}
+	return &Meta{ptr: meta}
+}
+
+// GetMetas returns all the Metas on the buffer.
+func GetMetas(buffer *gst.Buffer) []*Meta {
+	out := make([]*Meta, 0)
+	iterateMetas(buffer, C.gst_video_meta_api_get_type(), func(meta *C.GstMeta) bool {
+		out = append(out, &Meta{ptr: (*C.GstVideoMeta)(unsafe.Pointer(meta))})
+		return true
+	})
+	return out
+}
+
+// Instance returns the underlying GstVideoMeta instance.
+func (m *Meta) Instance() *C.GstVideoMeta { return m.ptr }
+
+// Meta returns the parent Meta instance.
+func (m *Meta) Meta() *gst.Meta { return gst.FromGstMetaUnsafe(unsafe.Pointer(&m.ptr.meta)) }
+
+// Flags returns the flags of the frame.
+func (m *Meta) Flags() FrameFlags { return FrameFlags(m.ptr.flags) }
+
+// Format returns the format of the frame.
+func (m *Meta) Format() Format { return Format(m.ptr.format) }
+
+// ID returns the id of the meta, which identifies the view of the frame for buffers with multiple views.
+func (m *Meta) ID() int { return int(m.ptr.id) }
+
+// Width returns the width of the frame.
+func (m *Meta) Width() uint { return uint(m.ptr.width) }
+
+// Height returns the height of the frame.
+func (m *Meta) Height() uint { return uint(m.ptr.height) }
+
+// NumPlanes returns the number of planes of the frame.
+func (m *Meta) NumPlanes() int { return int(m.ptr.n_planes) }
+
+// Offsets returns the offset in bytes of each plane in the buffer.
+func (m *Meta) Offsets() []int64 {
+	out := make([]int64, m.NumPlanes())
+	for i := range out {
+		out[i] = int64(m.ptr.offset[i])
+	}
+	return out
+}
+
+// Strides returns the number of bytes per row of each plane.
+func (m *Meta) Strides() []int {
+	out := make([]int, m.NumPlanes())
+	for i := range out {
+		out[i] = int(m.ptr.stride[i])
+	}
+	return out
+}
+
+// SetPlane sets the offset and stride of the given plane.
+func (m *Meta) SetPlane(plane int, offset int64, stride int) error {
+	if plane < 0 || plane >= m.NumPlanes() {
+		return fmt.Errorf("Plane %d out of range for meta with %d planes", plane, m.NumPlanes())
+	}
+	m.ptr.offset[plane] = C.gsize(offset)
+	m.ptr.stride[plane] = C.gint(stride)
+	return nil
+}
+
+// RegionOfInterestMeta is a go representation of a GstVideoRegionOfInterestMeta. It describes a
+// rectangle of the frame with a special meaning, e.g. an object found by a detector. A buffer can hold
+// any number of them.
+type RegionOfInterestMeta struct {
+	ptr *C.GstVideoRegionOfInterestMeta
+}
+
+// AddRegionOfInterestMeta adds a RegionOfInterestMeta of the given type, e.g. "face", to the buffer,
+// covering the given rectangle of the frame.
+func AddRegionOfInterestMeta(buffer *gst.Buffer, roiType string, x, y, width, height uint) (*RegionOfInterestMeta, error) {
+	cType := C.CString(roiType)
+	defer C.free(unsafe.Pointer(cType))
+	meta := C.gst_buffer_add_video_region_of_interest_meta(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		(*C.gchar)(unsafe.Pointer(cType)),
+		C.guint(x), C.guint(y), C.guint(width), C.guint(height),
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add region of interest meta")
+	}
+	return &RegionOfInterestMeta{ptr: meta}, nil
+}
+
+// GetRegionOfInterestMeta returns the first RegionOfInterestMeta on the buffer, or nil if it has none.
+func GetRegionOfInterestMeta(buffer *gst.Buffer) *RegionOfInterestMeta {
+	meta := C.getRegionOfInterestMeta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &RegionOfInterestMeta{ptr: meta}
+}
+
+// GetRegionOfInterestMetaID returns the RegionOfInterestMeta on the buffer with the given id, or nil if
+// there is none.
+func GetRegionOfInterestMetaID(buffer *gst.Buffer, id int) *RegionOfInterestMeta {
+	meta := C.gst_buffer_get_video_region_of_interest_meta_id((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())), C.gint(id))
+	if meta == nil {
+		return nil
+	}
+	return &RegionOfInterestMeta{ptr: meta}
+}
+
+// GetRegionOfInterestMetas returns all the RegionOfInterestMetas on the buffer.
+func GetRegionOfInterestMetas(buffer *gst.Buffer) []*RegionOfInterestMeta {
+	out := make([]*RegionOfInterestMeta, 0)
+	iterateMetas(buffer, C.gst_video_region_of_interest_meta_api_get_type(), func(meta *C.GstMeta) bool {
+		out = append(out, &RegionOfInterestMeta{ptr: (*C.GstVideoRegionOfInterestMeta)(unsafe.Pointer(meta))})
+		return true
+	})
+	return out
+}
+
+// Instance returns the underlying GstVideoRegionOfInterestMeta instance.
+func (r *RegionOfInterestMeta) Instance() *C.GstVideoRegionOfInterestMeta { return r.ptr }
+
+// Meta returns the parent Meta instance.
+func (r *RegionOfInterestMeta) Meta() *gst.Meta {
+	return gst.FromGstMetaUnsafe(unsafe.Pointer(&r.ptr.meta))
+}
+
+// Type returns the type of the region, e.g. "face".
+func (r *RegionOfInterestMeta) Type() string { return C.GoString(C.g_quark_to_string(r.ptr.roi_type)) }
+
+// ID returns the id of the region, which is unique among the regions of the buffer.
+func (r *RegionOfInterestMeta) ID() int { return int(r.ptr.id) }
+
+// ParentID returns the id of the region containing this one, or -1 if there is none.
+func (r *RegionOfInterestMeta) ParentID() int { return int(r.ptr.parent_id) }
+
+// SetParentID sets the id of the region containing this one. -1 means there is none.
+func (r *RegionOfInterestMeta) SetParentID(id int) { r.ptr.parent_id = C.gint(id) }
+
+// X returns the horizontal offset of the region.
+func (r *RegionOfInterestMeta) X() uint { return uint(r.ptr.x) }
+
+// Y returns the vertical offset of the region.
+func (r *RegionOfInterestMeta) Y() uint { return uint(r.ptr.y) }
+
+// Width returns the width of the region.
+func (r *RegionOfInterestMeta) Width() uint { return uint(r.ptr.w) }
+
+// Height returns the height of the region.
+func (r *RegionOfInterestMeta) Height() uint { return uint(r.ptr.h) }
+
+// SetRect moves the region to the given rectangle of the frame.
+func (r *RegionOfInterestMeta) SetRect(x, y, width, height uint) {
+	r.ptr.x, r.ptr.y, r.ptr.w, r.ptr.h = C.guint(x), C.guint(y), C.guint(width), C.guint(height)
+}
+
+// AddParam adds a copy of the given structure to the params of the region, e.g. the confidence of a
+// detection. The name of the structure identifies the param.
+func (r *RegionOfInterestMeta) AddParam(param *gst.Structure) {
+	C.gst_video_region_of_interest_meta_add_param(r.ptr, C.gst_structure_copy(fromCoreStructure(param)))
+}
+
+// GetParam returns the param of the region with the given name, or nil if there is none. The structure
+// is owned by the meta.
+func (r *RegionOfInterestMeta) GetParam(name string) *gst.Structure {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	param := C.gst_video_region_of_interest_meta_get_param(r.ptr, (*C.gchar)(unsafe.Pointer(cName)))
+	if param == nil {
+		return nil
+	}
+	return gst.FromGstStructureUnsafe(unsafe.Pointer(param))
+}
+
+// Params returns all the params of the region. The structures are owned by the meta.
+func (r *RegionOfInterestMeta) Params() []*gst.Structure {
+	out := make([]*gst.Structure, 0)
+	for l := r.ptr.params; l != nil; l = l.next {
+		out = append(out, gst.FromGstStructureUnsafe(unsafe.Pointer(l.data)))
+	}
+	return out
+}
+
+// TimeCodeMeta is a go representation of a GstVideoTimeCodeMeta. It attaches a TimeCode to a buffer.
+type TimeCodeMeta struct {
+	ptr *C.GstVideoTimeCodeMeta
+}
+
+// AddTimeCodeMeta adds a TimeCodeMeta holding a copy of the given time code to the buffer. The time code
+// must be valid.
+func AddTimeCodeMeta(buffer *gst.Buffer, tc *TimeCode) (*TimeCodeMeta, error) {
+	meta := C.gst_buffer_add_video_time_code_meta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())), tc.ptr)
+	if meta == nil {
+		return nil, errors.New("Failed to add time code meta")
+	}
+	return &TimeCodeMeta{ptr: meta}, nil
+}
+
+// GetTimeCodeMeta returns the TimeCodeMeta on the buffer, or nil if it has none.
+func GetTimeCodeMeta(buffer *gst.Buffer) *TimeCodeMeta {
+	meta := C.getTimeCodeMeta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &TimeCodeMeta{ptr: meta}
+}
+
+// Instance returns the underlying GstVideoTimeCodeMeta instance.
+func (t *TimeCodeMeta) Instance() *C.GstVideoTimeCodeMeta { return t.ptr }
+
+// Meta returns the parent Meta instance.
+func (t *TimeCodeMeta) Meta() *gst.Meta { return gst.FromGstMetaUnsafe(unsafe.Pointer(&t.ptr.meta)) }
+
+// TimeCode returns a copy of the time code of the buffer.
+func (t *TimeCodeMeta) TimeCode() *TimeCode {
+	return FromGstVideoTimeCodeUnsafe(unsafe.Pointer(&t.ptr.tc))
+}
+
+// AFDSpec is the specification an AFDMeta follows.
+type AFDSpec int
+
+// Type castings
+const (
+	AFDSpecDVBETSI      AFDSpec = C.GST_VIDEO_AFD_SPEC_DVB_ETSI       // (0) – AFD value is from DVB/ETSI standard
+	AFDSpecATSCA53      AFDSpec = C.GST_VIDEO_AFD_SPEC_ATSC_A53       // (1) – AFD value is from ATSC A/53 standard
+	AFDSpecSMPTEST20161 AFDSpec = C.GST_VIDEO_AFD_SPEC_SMPTE_ST2016_1 // (2) – AFD value is from SMPTE ST2016-1 standard
+)
+
+// AFDValue is an Active Format Description, describing the area of interest of the frame.
+type AFDValue int
+
+// Type castings
+const (
+	AFDUnavailable        AFDValue = C.GST_VIDEO_AFD_UNAVAILABLE             // (0) – Unavailable or reserved
+	AFD169TopAligned      AFDValue = C.GST_VIDEO_AFD_16_9_TOP_ALIGNED        // (2) – For 4:3 coded frame, letterbox 16:9 image, at top of the coded frame. For 16:9 coded frame, full frame 16:9 image, the same as the coded frame.
+	AFD149TopAligned      AFDValue = C.GST_VIDEO_AFD_14_9_TOP_ALIGNED        // (3) – For 4:3 coded frame, letterbox 14:9 image, at top of the coded frame. For 16:9 coded frame, pillarbox 14:9 image, horizontally centered in the coded frame.
+	AFDGreaterThan169     AFDValue = C.GST_VIDEO_AFD_GREATER_THAN_16_9       // (4) – For 4:3 coded frame, letterbox image with an aspect ratio greater than 16:9, vertically centered in the coded frame. For 16:9 coded frame, letterbox image with an aspect ratio greater than 16:9.
+	AFD43Full169Full      AFDValue = C.GST_VIDEO_AFD_4_3_FULL_16_9_FULL      // (8) – For 4:3 coded frame, full frame 4:3 image, the same as the coded frame. For 16:9 coded frame, full frame 16:9 image, the same as the coded frame.
+	AFD43Full43Pillar     AFDValue = C.GST_VIDEO_AFD_4_3_FULL_4_3_PILLAR     // (9) – For 4:3 coded frame, full frame 4:3 image, the same as the coded frame. For 16:9 coded frame, pillarbox 4:3 image, horizontally centered in the coded frame.
+	AFD169Letter169Full   AFDValue = C.GST_VIDEO_AFD_16_9_LETTER_16_9_FULL   // (10) – For 4:3 coded frame, letterbox 16:9 image, vertically centered in the coded frame with all image areas protected. For 16:9 coded frame, full frame 16:9 image, with all image areas protected.
+	AFD149Letter149Pillar AFDValue = C.GST_VIDEO_AFD_14_9_LETTER_14_9_PILLAR // (11) – For 4:3 coded frame, letterbox 14:9 image, vertically centered in the coded frame. For 16:9 coded frame, pillarbox 14:9 image, horizontally centered in the coded frame.
+	AFD43Full149Center    AFDValue = C.GST_VIDEO_AFD_4_3_FULL_14_9_CENTER    // (13) – For 4:3 coded frame, full frame 4:3 image, with alternative 14:9 center. For 16:9 coded frame, pillarbox 4:3 image, with alternative 14:9 center.
+	AFD169Letter149Center AFDValue = C.GST_VIDEO_AFD_16_9_LETTER_14_9_CENTER // (14) – For 4:3 coded frame, letterbox 16:9 image, with alternative 14:9 center. For 16:9 coded frame, full frame 16:9 image, with alternative 14:9 center.
+	AFD169Letter43Center  AFDValue = C.GST_VIDEO_AFD_16_9_LETTER_4_3_CENTER  // (15) – For 4:3 coded frame, letterbox 16:9 image, with alternative 4:3 center. For 16:9 coded frame, full frame 16:9 image, with alternative 4:3 center.
+)
+
+// AFDMeta is a go representation of a GstVideoAFDMeta. It holds the Active Format Description of a
+// frame, as carried e.g. in the user data of MPEG-2 and H.264 streams.
+type AFDMeta struct {
+	ptr *C.GstVideoAFDMeta
+}
+
+// AddAFDMeta adds an AFDMeta to the buffer. field is 0 for progressive frames and the first field of
+// interlaced frames, and 1 for the second field.
+//
+// AFD metas are only available since GStreamer 1.18. When built against older versions, an error is returned.
+func AddAFDMeta(buffer *gst.Buffer, field uint8, spec AFDSpec, afd AFDValue) (*AFDMeta, error) {
+	meta := C.addAFDMeta(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.guint8(field),
+		C.GstVideoAFDSpec(spec),
+		C.GstVideoAFDValue(afd),
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add AFD meta")
+	}
+	return &AFDMeta{ptr: meta}, nil
+}
+
+// GetAFDMeta returns the AFDMeta on the buffer, or nil if it has none. It always returns nil when built
+// against GStreamer older than 1.18.
+func GetAFDMeta(buffer *gst.Buffer) *AFDMeta {
+	meta := C.getAFDMeta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &AFDMeta{ptr: meta}
+}
+
+// Instance returns the underlying GstVideoAFDMeta instance.
+func (a *AFDMeta) Instance() *C.GstVideoAFDMeta { return a.ptr }
+
+// Meta returns the parent Meta instance.
+func (a *AFDMeta) Meta() *gst.Meta { return gst.FromGstMetaUnsafe(unsafe.Pointer(&a.ptr.meta)) }
+
+// Field returns the field the description applies to, 0 for progressive frames and the first field of
+// interlaced frames, and 1 for the second field.
+func (a *AFDMeta) Field() uint8 { return uint8(a.ptr.field) }
+
+// Spec returns the specification the description follows.
+func (a *AFDMeta) Spec() AFDSpec { return AFDSpec(a.ptr.spec) }
+
+// AFD returns the Active Format Description.
+func (a *AFDMeta) AFD() AFDValue { return AFDValue(a.ptr.afd) }
+
+// BarMeta is a go representation of a GstVideoBarMeta. It describes the black bars of a letterboxed or
+// pillarboxed frame.
+type BarMeta struct {
+	ptr *C.GstVideoBarMeta
+}
+
+// AddBarMeta adds a BarMeta to the buffer. field is 0 for progressive frames and the first field of
+// interlaced frames, and 1 for the second field. For letterboxes, barData1 and barData2 are the last line
+// of the top bar and the first line of the bottom bar. For pillarboxes, they are the last pixel of the
+// left bar and the first pixel of the right bar.
+//
+// Bar metas are only available since GStreamer 1.18. When built against older versions, an error is returned.
+func AddBarMeta(buffer *gst.Buffer, field uint8, isLetterbox bool, barData1, barData2 uint) (*BarMeta, error) {
+	meta := C.addBarMeta(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.guint8(field),
+		gboolean(isLetterbox),
+		C.guint(barData1), C.guint(barData2),
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add bar meta")
+	}
+	return &BarMeta{ptr: meta}, nil
+}
+
+// GetBarMeta returns the BarMeta on the buffer, or nil if it has none. It always returns nil when built
+// against GStreamer older than 1.18.
+func GetBarMeta(buffer *gst.Buffer) *BarMeta {
+	meta := C.getBarMeta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &BarMeta{ptr: meta}
+}
+
+// Instance returns the underlying GstVideoBarMeta instance.
+func (b *BarMeta) Instance() *C.GstVideoBarMeta { return b.ptr }
+
+// Meta returns the parent Meta instance.
+func (b *BarMeta) Meta() *gst.Meta { return gst.FromGstMetaUnsafe(unsafe.Pointer(&b.ptr.meta)) }
+
+// Field returns the field the bars apply to, 0 for progressive frames and the first field of interlaced
+// frames, and 1 for the second field.
+func (b *BarMeta) Field() uint8 { return uint8(b.ptr.field) }
+
+// IsLetterbox returns true if the bars are at the top and bottom of the frame, and false if they are at
+// the left and right.
+func (b *BarMeta) IsLetterbox() bool { return gobool(b.ptr.is_letterbox) }
+
+// BarData1 returns the last line of the top bar for letterboxes, or the last pixel of the left bar for
+// pillarboxes.
+func (b *BarMeta) BarData1() uint { return uint(b.ptr.bar_data1) }
+
+// BarData2 returns the first line of the bottom bar for letterboxes, or the first pixel of the right bar
+// for pillarboxes.
+func (b *BarMeta) BarData2() uint { return uint(b.ptr.bar_data2) }
+
+// CaptionType is the format of the data of a CaptionMeta.
+type CaptionType int
+
+// Type castings
+const (
+	CaptionTypeUnknown      CaptionType = C.GST_VIDEO_CAPTION_TYPE_UNKNOWN        // (0) – Unknown type of Closed Caption
+	CaptionTypeCEA608Raw    CaptionType = C.GST_VIDEO_CAPTION_TYPE_CEA608_RAW     // (1) – CEA-608 as byte pairs. Note that this format is not recommended since is does not specify to which field the caption comes from and therefore assumes it comes from the first field (and that there is no information on the second field). Use CaptionTypeCEA708Raw if you wish to store CEA-608 from two fields and prefix each byte pair with 0xFC for the first field and 0xFD for the second field.
+	CaptionTypeCEA608S3341A CaptionType = C.GST_VIDEO_CAPTION_TYPE_CEA608_S334_1A // (2) – CEA-608 as byte triplets as defined in SMPTE S334-1 Annex A. The second and third byte of the byte triplet is the raw CEA608 data, the first byte is a bitfield: The top/7th bit is 0 for the second field, 1 for the first field, bit 6 and 5 are 0 and bits 4 to 0 are a 5 bit unsigned integer that represents the line offset relative to the base-line of the original image format (line 9 for 525-line field 1, line 272 for 525-line field 2, line 5 for 625-line field 1 and line 318 for 625-line field 2).
+	CaptionTypeCEA708Raw    CaptionType = C.GST_VIDEO_CAPTION_TYPE_CEA708_RAW     // (3) – CEA-708 as cc_data byte triplets. They can also contain 608-in-708 and the first byte of each triplet has to be inspected for detecting the type.
+	CaptionTypeCEA708CDP    CaptionType = C.GST_VIDEO_CAPTION_TYPE_CEA708_CDP     // (4) – CEA-708 (and optionally CEA-608) in a CDP (Caption Distribution Packet) defined by SMPTE S-334-2. Contains the whole CDP (starting with 0x9669).
+)
+
+// String implements a stringer on CaptionType.
+func (c CaptionType) String() string {
+	switch c {
+	case CaptionTypeCEA608Raw:
+		return "CEA-608 raw"
+	case CaptionTypeCEA608S3341A:
+		return "CEA-608 S334-1A"
+	case CaptionTypeCEA708Raw:
+		return "CEA-708 raw"
+	case CaptionTypeCEA708CDP:
+		return "CEA-708 CDP"
+	}
+	return "unknown"
+}
+
+// CaptionMeta is a go representation of a GstVideoCaptionMeta. It holds closed caption data belonging
+// to a frame. A buffer can hold any number of them.
+type CaptionMeta struct {
+	ptr *C.GstVideoCaptionMeta
+}
+
+// AddCaptionMeta adds a CaptionMeta holding a copy of the given data to the buffer.
+func AddCaptionMeta(buffer *gst.Buffer, captionType CaptionType, data []byte) (*CaptionMeta, error) {
+	if len(data) == 0 {
+		return nil, errors.New("Caption data cannot be empty")
+	}
+	meta := C.gst_buffer_add_video_caption_meta(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.GstVideoCaptionType(captionType),
+		(*C.guint8)(unsafe.Pointer(&data[0])),
+		C.gsize(len(data)),
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add caption meta")
+	}
+	return &CaptionMeta{ptr: meta}, nil
+}
+
+// GetCaptionMeta returns the first CaptionMeta on the buffer, or nil if it has none.
+func GetCaptionMeta(buffer *gst.Buffer) *CaptionMeta {
+	meta := C.getCaptionMeta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &CaptionMeta{ptr: meta}
+}
+
+// GetCaptionMetas returns all the CaptionMetas on the buffer.
+func GetCaptionMetas(buffer *gst.Buffer) []*CaptionMeta {
+	out := make([]*CaptionMeta, 0)
+	iterateMetas(buffer, C.gst_video_caption_meta_api_get_type(), func(meta *C.GstMeta) bool {
+		out = append(out, &CaptionMeta{ptr: (*C.GstVideoCaptionMeta)(unsafe.Pointer(meta))})
+		return true
+	})
+	return out
+}
+
+// Instance returns the underlying GstVideoCaptionMeta instance.
+func (c *CaptionMeta) Instance() *C.GstVideoCaptionMeta { return c.ptr }
+
+// Meta returns the parent Meta instance.
+func (c *CaptionMeta) Meta() *gst.Meta { return gst.FromGstMetaUnsafe(unsafe.Pointer(&c.ptr.meta)) }
+
+// CaptionType returns the format of the caption data.
+func (c *CaptionMeta) CaptionType() CaptionType { return CaptionType(c.ptr.caption_type) }
+
+// Data returns a copy of the caption data.
+func (c *CaptionMeta) Data() []byte {
+	return C.GoBytes(unsafe.Pointer(c.ptr.data), C.int(c.ptr.size))
+}
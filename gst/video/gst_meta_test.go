@@ -0,0 +1,34 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+func TestAFDAndBarMetas(t *testing.T) {
+	buf := gst.NewEmptyBuffer()
+	defer buf.Unref()
+
+	if _, err := AddAFDMeta(buf, 0, AFDSpecATSCA53, AFD169TopAligned); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddBarMeta(buf, 1, true, 60, 420); err != nil {
+		t.Fatal(err)
+	}
+
+	afd := GetAFDMeta(buf)
+	if afd == nil {
+		t.Fatal("Expected the buffer to have an AFD meta")
+	}
+	if afd.Field() != 0 || afd.Spec() != AFDSpecATSCA53 || afd.AFD() != AFD169TopAligned {
+		t.Errorf("Unexpected AFD meta: %d %d %d", afd.Field(), afd.Spec(), afd.AFD())
+	}
+	bar := GetBarMeta(buf)
+	if bar == nil {
+		t.Fatal("Expected the buffer to have a bar meta")
+	}
+	if bar.Field() != 1 || !bar.IsLetterbox() || bar.BarData1() != 60 || bar.BarData2() != 420 {
+		t.Errorf("Unexpected bar meta: %d %v %d %d", bar.Field(), bar.IsLetterbox(), bar.BarData1(), bar.BarData2())
+	}
+}
@@ -230,6 +230,82 @@ func (e *NavigationEvent) GetType() NavigationEventType {
 // instance returns the underlying GstEvent instance.
 func (e *NavigationEvent) instance() *C.GstEvent { return fromCoreEvent(e.Event) }
 
+// ParseKeyEvent returns the key of a key press or release event. ok is false if the event is not one.
+func (e *NavigationEvent) ParseKeyEvent() (key string, ok bool) {
+	var cKey *C.gchar
+	if !gobool(C.gst_navigation_event_parse_key_event(e.instance(), &cKey)) {
+		return "", false
+	}
+	return C.GoString(cKey), true
+}
+
+// ParseMouseButtonEvent returns the button and coordinates of a mouse button press or release event. ok
+// is false if the event is not one.
+func (e *NavigationEvent) ParseMouseButtonEvent() (button int, x, y float64, ok bool) {
+	var cButton C.gint
+	var cX, cY C.gdouble
+	if !gobool(C.gst_navigation_event_parse_mouse_button_event(e.instance(), &cButton, &cX, &cY)) {
+		return
+	}
+	return int(cButton), float64(cX), float64(cY), true
+}
+
+// ParseMouseMoveEvent returns the coordinates of a mouse move event. ok is false if the event is not one.
+func (e *NavigationEvent) ParseMouseMoveEvent() (x, y float64, ok bool) {
+	var cX, cY C.gdouble
+	if !gobool(C.gst_navigation_event_parse_mouse_move_event(e.instance(), &cX, &cY)) {
+		return
+	}
+	return float64(cX), float64(cY), true
+}
+
+// ParseMouseScrollEvent returns the coordinates and scroll deltas of a mouse scroll event. ok is false if
+// the event is not one.
+func (e *NavigationEvent) ParseMouseScrollEvent() (x, y, dX, dY float64, ok bool) {
+	var cX, cY, cDX, cDY C.gdouble
+	if !gobool(C.gst_navigation_event_parse_mouse_scroll_event(e.instance(), &cX, &cY, &cDX, &cDY)) {
+		return
+	}
+	return float64(cX), float64(cY), float64(cDX), float64(cDY), true
+}
+
+// ParseCommand returns the command of a command event. ok is false if the event is not one.
+func (e *NavigationEvent) ParseCommand() (cmd NavigationCommand, ok bool) {
+	var cCmd C.GstNavigationCommand
+	if !gobool(C.gst_navigation_event_parse_command(e.instance(), &cCmd)) {
+		return NavigationCommandInvalid, false
+	}
+	return NavigationCommand(cCmd), true
+}
+
+// NewNavigationMessageMouseOver creates a new message notifying that the mouse moved over (active is
+// true) or left (active is false) a clickable region of the output of src, such as a DVD menu button.
+func NewNavigationMessageMouseOver(src *gst.Object, active bool) *NavigationMessage {
+	return wrapNavigationMessage(C.gst_navigation_message_new_mouse_over(fromCoreObject(src), gboolean(active)))
+}
+
+// NewNavigationMessageCommandsChanged creates a new message notifying that the set of commands available
+// from src changed, and should be queried again with a commands query.
+func NewNavigationMessageCommandsChanged(src *gst.Object) *NavigationMessage {
+	return wrapNavigationMessage(C.gst_navigation_message_new_commands_changed(fromCoreObject(src)))
+}
+
+// NewNavigationMessageAnglesChanged creates a new message notifying that the current angle or the number
+// of angles available from src changed.
+func NewNavigationMessageAnglesChanged(src *gst.Object, curAngle, nAngles uint) *NavigationMessage {
+	return wrapNavigationMessage(C.gst_navigation_message_new_angles_changed(fromCoreObject(src), C.guint(curAngle), C.guint(nAngles)))
+}
+
+// NewNavigationMessageEvent creates a new message holding a navigation event that was not handled by any
+// element of the pipeline, for the application to handle.
+func NewNavigationMessageEvent(src *gst.Object, event *gst.Event) *NavigationMessage {
+	return wrapNavigationMessage(C.gst_navigation_message_new_event(fromCoreObject(src), fromCoreEvent(event)))
+}
+
+func wrapNavigationMessage(msg *C.GstMessage) *NavigationMessage {
+	return &NavigationMessage{gst.FromGstMessageUnsafeFull(unsafe.Pointer(msg))}
+}
+
 // NavigationMessage extends the Event from the core library and is used by elements
 // implementing the Navigation interface. You can wrap a message in this struct yourself,
 // but it is safer to use the ToNavigationMessage method first to check validity.
@@ -249,11 +325,53 @@ func ToNavigationMessage(msg *gst.Message) *NavigationMessage {
 // instance returns the underlying GstMessage instance.
 func (m *NavigationMessage) instance() *C.GstMessage { return fromCoreMessage(m.Message) }
 
+// ParseMouseOver returns whether the mouse moved over (true) or left (false) a clickable region. ok is
+// false if the message is not a mouse over message.
+func (m *NavigationMessage) ParseMouseOver() (active, ok bool) {
+	var cActive C.gboolean
+	if !gobool(C.gst_navigation_message_parse_mouse_over(m.instance(), &cActive)) {
+		return false, false
+	}
+	return gobool(cActive), true
+}
+
+// ParseAnglesChanged returns the current angle and the number of angles. ok is false if the message is
+// not an angles changed message.
+func (m *NavigationMessage) ParseAnglesChanged() (curAngle, nAngles uint, ok bool) {
+	var cCur, cN C.guint
+	if !gobool(C.gst_navigation_message_parse_angles_changed(m.instance(), &cCur, &cN)) {
+		return
+	}
+	return uint(cCur), uint(cN), true
+}
+
+// ParseEvent returns the navigation event held by the message. ok is false if the message is not an
+// event message.
+func (m *NavigationMessage) ParseEvent() (event *NavigationEvent, ok bool) {
+	var cEvent *C.GstEvent
+	if !gobool(C.gst_navigation_message_parse_event(m.instance(), &cEvent)) {
+		return nil, false
+	}
+	return &NavigationEvent{gst.FromGstEventUnsafeFull(unsafe.Pointer(cEvent))}, true
+}
+
 // GetType returns the type of this message.
 func (m *NavigationMessage) GetType() NavigationMessageType {
 	return NavigationMessageType(C.gst_navigation_message_get_type(m.instance()))
 }
 
+// NewNavigationCommandsQuery creates a new query for the commands available from an element implementing
+// the Navigation interface.
+func NewNavigationCommandsQuery() *NavigationQuery {
+	return &NavigationQuery{gst.FromGstQueryUnsafeFull(unsafe.Pointer(C.gst_navigation_query_new_commands()))}
+}
+
+// NewNavigationAnglesQuery creates a new query for the current angle and the number of angles available
+// from an element implementing the Navigation interface.
+func NewNavigationAnglesQuery() *NavigationQuery {
+	return &NavigationQuery{gst.FromGstQueryUnsafeFull(unsafe.Pointer(C.gst_navigation_query_new_angles()))}
+}
+
 // NavigationQuery extends the Query from the core library and is used by elements
 // implementing the Navigation interface. You can wrap a query in this struct yourself,
 // but it is safer to use the ToNavigationQuery method first to check validity.
@@ -273,6 +391,49 @@ func ToNavigationQuery(query *gst.Query) *NavigationQuery {
 // instance returns the underlying GstQuery instance.
 func (q *NavigationQuery) instance() *C.GstQuery { return fromCoreQuery(q.Query) }
 
+// SetCommands sets the commands available from the element answering a commands query.
+func (q *NavigationQuery) SetCommands(cmds ...NavigationCommand) {
+	if len(cmds) == 0 {
+		C.gst_navigation_query_set_commandsv(q.instance(), 0, nil)
+		return
+	}
+	cCmds := make([]C.GstNavigationCommand, len(cmds))
+	for i, cmd := range cmds {
+		cCmds[i] = C.GstNavigationCommand(cmd)
+	}
+	C.gst_navigation_query_set_commandsv(q.instance(), C.gint(len(cmds)), &cCmds[0])
+}
+
+// ParseCommands returns the commands set on a commands query. ok is false if the query is not one.
+func (q *NavigationQuery) ParseCommands() (cmds []NavigationCommand, ok bool) {
+	var n C.guint
+	if !gobool(C.gst_navigation_query_parse_commands_length(q.instance(), &n)) {
+		return nil, false
+	}
+	cmds = make([]NavigationCommand, int(n))
+	for i := range cmds {
+		var cmd C.GstNavigationCommand
+		C.gst_navigation_query_parse_commands_nth(q.instance(), C.guint(i), &cmd)
+		cmds[i] = NavigationCommand(cmd)
+	}
+	return cmds, true
+}
+
+// SetAngles sets the current angle and the number of angles of the element answering an angles query.
+func (q *NavigationQuery) SetAngles(curAngle, nAngles uint) {
+	C.gst_navigation_query_set_angles(q.instance(), C.guint(curAngle), C.guint(nAngles))
+}
+
+// ParseAngles returns the current angle and the number of angles set on an angles query. ok is false if
+// the query is not one.
+func (q *NavigationQuery) ParseAngles() (curAngle, nAngles uint, ok bool) {
+	var cCur, cN C.guint
+	if !gobool(C.gst_navigation_query_parse_angles(q.instance(), &cCur, &cN)) {
+		return
+	}
+	return uint(cCur), uint(cN), true
+}
+
 // GetType returns the type of this query.
 func (q *NavigationQuery) GetType() NavigationQueryType {
 	return NavigationQueryType(C.gst_navigation_query_get_type(q.instance()))
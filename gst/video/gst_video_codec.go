@@ -0,0 +1,248 @@
+package video
+
+/*
+#include "gst.go.h"
+
+gboolean codecFrameFlagIsSet (GstVideoCodecFrame * frame, GstVideoCodecFrameFlags flag) { return GST_VIDEO_CODEC_FRAME_FLAG_IS_SET(frame, flag); }
+void     codecFrameFlagSet   (GstVideoCodecFrame * frame, GstVideoCodecFrameFlags flag) { GST_VIDEO_CODEC_FRAME_FLAG_SET(frame, flag); }
+void     codecFrameFlagUnset (GstVideoCodecFrame * frame, GstVideoCodecFrameFlags flag) { GST_VIDEO_CODEC_FRAME_FLAG_UNSET(frame, flag); }
+
+void setCodecFrameOutputBuffer (GstVideoCodecFrame * frame, GstBuffer * buffer)
+{
+	if (frame->output_buffer != NULL)
+		gst_buffer_unref(frame->output_buffer);
+	frame->output_buffer = buffer;
+}
+*/
+import "C"
+
+import (
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// CodecFrameFlags are flags for a CodecFrame.
+type CodecFrameFlags int
+
+// Type castings
+const (
+	CodecFrameFlagDecodeOnly           CodecFrameFlags = C.GST_VIDEO_CODEC_FRAME_FLAG_DECODE_ONLY            // (1) – is the frame only meant to be decoded
+	CodecFrameFlagSyncPoint            CodecFrameFlags = C.GST_VIDEO_CODEC_FRAME_FLAG_SYNC_POINT             // (2) – is the frame a synchronization point (keyframe)
+	CodecFrameFlagForceKeyframe        CodecFrameFlags = C.GST_VIDEO_CODEC_FRAME_FLAG_FORCE_KEYFRAME         // (4) – should the output frame be made a keyframe
+	CodecFrameFlagForceKeyframeHeaders CodecFrameFlags = C.GST_VIDEO_CODEC_FRAME_FLAG_FORCE_KEYFRAME_HEADERS // (8) – should the encoder output stream headers
+)
+
+// CodecFrame is a go representation of a GstVideoCodecFrame. It holds the input and output buffers of
+// a frame passing through an Encoder or Decoder, along with its timestamps and flags.
+//
+// Frames passed to a HandleFrameFunc are owned by the callback, which must pass them on to FinishFrame
+// (or DropFrame for decoders), or Unref them. Frames are not tied to the go garbage collector, since
+// holding on to their buffers until a collection would starve buffer pools.
+type CodecFrame struct {
+	ptr *C.GstVideoCodecFrame
+}
+
+// FromGstVideoCodecFrameUnsafe wraps the given C GstVideoCodecFrame in the go type. No reference is taken.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstVideoCodecFrameUnsafe(frame unsafe.Pointer) *CodecFrame {
+	return wrapCodecFrame((*C.GstVideoCodecFrame)(frame))
+}
+
+func wrapCodecFrame(frame *C.GstVideoCodecFrame) *CodecFrame {
+	if frame == nil {
+		return nil
+	}
+	return &CodecFrame{ptr: frame}
+}
+
+// takeCodecFrameList returns the frames in the given list and frees it. The references on the frames are
+// handed to the caller.
+func takeCodecFrameList(list *C.GList) []*CodecFrame {
+	defer C.g_list_free(list)
+	out := make([]*CodecFrame, 0)
+	for l := list; l != nil; l = l.next {
+		out = append(out, wrapCodecFrame((*C.GstVideoCodecFrame)(unsafe.Pointer(l.data))))
+	}
+	return out
+}
+
+// Instance returns the underlying GstVideoCodecFrame instance.
+func (f *CodecFrame) Instance() *C.GstVideoCodecFrame { return f.ptr }
+
+// Ref increases the ref count on the frame by one.
+func (f *CodecFrame) Ref() *CodecFrame {
+	C.gst_video_codec_frame_ref(f.ptr)
+	return f
+}
+
+// Unref decreases the ref count on the frame by one. When it reaches zero the frame and its buffers are
+// freed.
+func (f *CodecFrame) Unref() { C.gst_video_codec_frame_unref(f.ptr) }
+
+// SystemFrameNumber returns the unique identifier of the frame. Use it to look up frames with GetFrame.
+func (f *CodecFrame) SystemFrameNumber() uint32 { return uint32(f.ptr.system_frame_number) }
+
+// DecodeFrameNumber returns the decode order number of the frame.
+func (f *CodecFrame) DecodeFrameNumber() uint32 { return uint32(f.ptr.decode_frame_number) }
+
+// PresentationFrameNumber returns the presentation order number of the frame.
+func (f *CodecFrame) PresentationFrameNumber() uint32 {
+	return uint32(f.ptr.presentation_frame_number)
+}
+
+// PTS returns the presentation timestamp of the frame, or a negative duration if it is not known.
+func (f *CodecFrame) PTS() time.Duration { return clockTimeToDuration(f.ptr.pts) }
+
+// SetPTS sets the presentation timestamp of the frame. A negative duration unsets it.
+func (f *CodecFrame) SetPTS(pts time.Duration) { f.ptr.pts = durationToClockTime(pts) }
+
+// DTS returns the decoding timestamp of the frame, or a negative duration if it is not known.
+func (f *CodecFrame) DTS() time.Duration { return clockTimeToDuration(f.ptr.dts) }
+
+// SetDTS sets the decoding timestamp of the frame. A negative duration unsets it.
+func (f *CodecFrame) SetDTS(dts time.Duration) { f.ptr.dts = durationToClockTime(dts) }
+
+// Duration returns the duration of the frame, or a negative duration if it is not known.
+func (f *CodecFrame) Duration() time.Duration { return clockTimeToDuration(f.ptr.duration) }
+
+// SetDuration sets the duration of the frame. A negative duration unsets it.
+func (f *CodecFrame) SetDuration(dur time.Duration) { f.ptr.duration = durationToClockTime(dur) }
+
+// Deadline returns the running time by which the frame should be decoded, or a negative duration if
+// there is none.
+func (f *CodecFrame) Deadline() time.Duration { return clockTimeToDuration(f.ptr.deadline) }
+
+// DistanceFromSync returns the distance in frames from the last synchronization point.
+func (f *CodecFrame) DistanceFromSync() int { return int(f.ptr.distance_from_sync) }
+
+// InputBuffer returns the input buffer of the frame, or nil if it has none. The buffer is owned by the
+// frame.
+func (f *CodecFrame) InputBuffer() *gst.Buffer {
+	if f.ptr.input_buffer == nil {
+		return nil
+	}
+	return gst.FromGstBufferUnsafe(unsafe.Pointer(f.ptr.input_buffer))
+}
+
+// OutputBuffer returns the output buffer of the frame, or nil if it has none. The buffer is owned by the
+// frame.
+func (f *CodecFrame) OutputBuffer() *gst.Buffer {
+	if f.ptr.output_buffer == nil {
+		return nil
+	}
+	return gst.FromGstBufferUnsafe(unsafe.Pointer(f.ptr.output_buffer))
+}
+
+// SetOutputBuffer sets the output buffer of the frame, replacing any previous one. The buffer is taken.
+// Encoders set the encoded data here before calling FinishFrame. Decoders usually call AllocateOutputFrame
+// instead, which takes a buffer from the negotiated pool.
+func (f *CodecFrame) SetOutputBuffer(buffer *gst.Buffer) {
+	C.setCodecFrameOutputBuffer(f.ptr, (*C.GstBuffer)(buffer.TransferUnsafe()))
+}
+
+// HasFlag returns true if the given flag is set on the frame.
+func (f *CodecFrame) HasFlag(flag CodecFrameFlags) bool {
+	return gobool(C.codecFrameFlagIsSet(f.ptr, C.GstVideoCodecFrameFlags(flag)))
+}
+
+// SetFlag sets the given flag on the frame.
+func (f *CodecFrame) SetFlag(flag CodecFrameFlags) {
+	C.codecFrameFlagSet(f.ptr, C.GstVideoCodecFrameFlags(flag))
+}
+
+// UnsetFlag unsets the given flag on the frame.
+func (f *CodecFrame) UnsetFlag(flag CodecFrameFlags) {
+	C.codecFrameFlagUnset(f.ptr, C.GstVideoCodecFrameFlags(flag))
+}
+
+// IsSyncPoint returns true if the frame is a synchronization point, i.e. a keyframe.
+func (f *CodecFrame) IsSyncPoint() bool { return f.HasFlag(CodecFrameFlagSyncPoint) }
+
+// IsDecodeOnly returns true if the frame should be decoded but not displayed.
+func (f *CodecFrame) IsDecodeOnly() bool { return f.HasFlag(CodecFrameFlagDecodeOnly) }
+
+// IsForceKeyframe returns true if an encoder should encode the frame as a keyframe, e.g. because a
+// force-key-unit event was received.
+func (f *CodecFrame) IsForceKeyframe() bool { return f.HasFlag(CodecFrameFlagForceKeyframe) }
+
+// IsForceKeyframeHeaders returns true if an encoder should output the stream headers along with the
+// forced keyframe.
+func (f *CodecFrame) IsForceKeyframeHeaders() bool {
+	return f.HasFlag(CodecFrameFlagForceKeyframeHeaders)
+}
+
+// CodecState is a go representation of a GstVideoCodecState. It describes the format of the input or
+// output of an Encoder or Decoder.
+type CodecState struct {
+	ptr *C.GstVideoCodecState
+}
+
+// FromGstVideoCodecStateUnsafe wraps the given C GstVideoCodecState in the go type, taking a reference on it.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstVideoCodecStateUnsafe(state unsafe.Pointer) *CodecState {
+	return wrapCodecState((*C.GstVideoCodecState)(state))
+}
+
+func wrapCodecState(state *C.GstVideoCodecState) *CodecState {
+	if state == nil {
+		return nil
+	}
+	return takeCodecState(C.gst_video_codec_state_ref(state))
+}
+
+func takeCodecState(state *C.GstVideoCodecState) *CodecState {
+	if state == nil {
+		return nil
+	}
+	s := &CodecState{ptr: state}
+	runtime.SetFinalizer(s, func(s *CodecState) { C.gst_video_codec_state_unref(s.ptr) })
+	return s
+}
+
+// Instance returns the underlying GstVideoCodecState instance.
+func (s *CodecState) Instance() *C.GstVideoCodecState { return s.ptr }
+
+// Info returns a copy of the video info of the state.
+func (s *CodecState) Info() *Info { return FromGstVideoInfoUnsafe(unsafe.Pointer(&s.ptr.info)) }
+
+// SetInfo replaces the video info of the state. This is only meant to be used on an output state before
+// negotiation, to adjust its defaults.
+func (s *CodecState) SetInfo(info *Info) { s.ptr.info = *info.instance() }
+
+// Caps returns the caps of the state, or nil if they are not set yet. Output states get their caps
+// on negotiation.
+func (s *CodecState) Caps() *gst.Caps {
+	if s.ptr.caps == nil {
+		return nil
+	}
+	return gst.FromGstCapsUnsafeFull(unsafe.Pointer(C.gst_caps_ref(s.ptr.caps)))
+}
+
+// SetCaps sets the caps of the state, replacing any previous ones. Encoders can use this on their
+// output state to set caps containing fields that cannot be expressed in the video info.
+func (s *CodecState) SetCaps(caps *gst.Caps) {
+	if s.ptr.caps != nil {
+		C.gst_caps_unref(s.ptr.caps)
+	}
+	s.ptr.caps = C.gst_caps_ref(fromCoreCaps(caps))
+}
+
+// CodecData returns the codec data of the state, e.g. from the codec_data field of the input caps, or
+// nil if there is none.
+func (s *CodecState) CodecData() *gst.Buffer {
+	if s.ptr.codec_data == nil {
+		return nil
+	}
+	return gst.FromGstBufferUnsafe(unsafe.Pointer(s.ptr.codec_data))
+}
+
+// AllocationCaps returns the caps used for the allocation query, or nil if they are the same as Caps.
+func (s *CodecState) AllocationCaps() *gst.Caps {
+	if s.ptr.allocation_caps == nil {
+		return nil
+	}
+	return gst.FromGstCapsUnsafeFull(unsafe.Pointer(C.gst_caps_ref(s.ptr.allocation_caps)))
+}
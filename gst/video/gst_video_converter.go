@@ -0,0 +1,416 @@
+package video
+
+/*
+#include <stdlib.h>
+#include "gst.go.h"
+
+GType videoResamplerMethodType (void) { return GST_TYPE_VIDEO_RESAMPLER_METHOD; }
+GType videoDitherMethodType    (void) { return GST_TYPE_VIDEO_DITHER_METHOD; }
+GType videoAlphaModeType       (void) { return GST_TYPE_VIDEO_ALPHA_MODE; }
+GType videoChromaModeType      (void) { return GST_TYPE_VIDEO_CHROMA_MODE; }
+GType videoMatrixModeType      (void) { return GST_TYPE_VIDEO_MATRIX_MODE; }
+GType videoGammaModeType       (void) { return GST_TYPE_VIDEO_GAMMA_MODE; }
+GType videoPrimariesModeType   (void) { return GST_TYPE_VIDEO_PRIMARIES_MODE; }
+
+void setVideoConfigEnum (GstStructure * config, const gchar * name, GType type, gint value)
+{
+	gst_structure_set(config, name, type, value, NULL);
+}
+
+void setVideoConfigUint (GstStructure * config, const gchar * name, guint value)
+{
+	gst_structure_set(config, name, G_TYPE_UINT, value, NULL);
+}
+
+void setVideoConfigBool (GstStructure * config, const gchar * name, gboolean value)
+{
+	gst_structure_set(config, name, G_TYPE_BOOLEAN, value, NULL);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"image"
+	"runtime"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// DitherMethod is the dither method to use when converting to a lower color depth.
+type DitherMethod int
+
+// Type castings
+const (
+	DitherNone           DitherMethod = C.GST_VIDEO_DITHER_NONE            // (0) – no dithering
+	DitherVerbatim       DitherMethod = C.GST_VIDEO_DITHER_VERBATIM        // (1) – propagate rounding errors downwards
+	DitherFloydSteinberg DitherMethod = C.GST_VIDEO_DITHER_FLOYD_STEINBERG // (2) – Dither with floyd-steinberg error diffusion
+	DitherSierraLite     DitherMethod = C.GST_VIDEO_DITHER_SIERRA_LITE     // (3) – Dither with Sierra Lite error diffusion
+	DitherBayer          DitherMethod = C.GST_VIDEO_DITHER_BAYER           // (4) – ordered dither using a bayer pattern
+)
+
+// AlphaMode is the way alpha is handled by a Converter.
+type AlphaMode int
+
+// Type castings
+const (
+	AlphaModeCopy AlphaMode = C.GST_VIDEO_ALPHA_MODE_COPY // (0) – When input and output have alpha, it will be copied. When the input has no alpha, alpha will be set to the alpha value
+	AlphaModeSet  AlphaMode = C.GST_VIDEO_ALPHA_MODE_SET  // (1) – set all alpha to the alpha value
+	AlphaModeMult AlphaMode = C.GST_VIDEO_ALPHA_MODE_MULT // (2) – multiply all alpha with the alpha value. When the input format has no alpha but the output format has, alpha will be set to the alpha value
+)
+
+// ChromaMode is the way chroma is resampled by a Converter.
+type ChromaMode int
+
+// Type castings
+const (
+	ChromaModeFull           ChromaMode = C.GST_VIDEO_CHROMA_MODE_FULL            // (0) – do full chroma up and down sampling
+	ChromaModeUpsampleOnly   ChromaMode = C.GST_VIDEO_CHROMA_MODE_UPSAMPLE_ONLY   // (1) – only perform chroma upsampling
+	ChromaModeDownsampleOnly ChromaMode = C.GST_VIDEO_CHROMA_MODE_DOWNSAMPLE_ONLY // (2) – only perform chroma downsampling
+	ChromaModeNone           ChromaMode = C.GST_VIDEO_CHROMA_MODE_NONE            // (3) – disable chroma resampling
+)
+
+// MatrixMode is the way color matrices are applied by a Converter.
+type MatrixMode int
+
+// Type castings
+const (
+	MatrixModeFull       MatrixMode = C.GST_VIDEO_MATRIX_MODE_FULL        // (0) – do conversion between color matrices
+	MatrixModeInputOnly  MatrixMode = C.GST_VIDEO_MATRIX_MODE_INPUT_ONLY  // (1) – use the input color matrix to convert to and from R'G'B
+	MatrixModeOutputOnly MatrixMode = C.GST_VIDEO_MATRIX_MODE_OUTPUT_ONLY // (2) – use the output color matrix to convert to and from R'G'B
+	MatrixModeNone       MatrixMode = C.GST_VIDEO_MATRIX_MODE_NONE        // (3) – disable color matrix conversion.
+)
+
+// GammaMode is the way gamma is handled by a Converter.
+type GammaMode int
+
+// Type castings
+const (
+	GammaModeNone  GammaMode = C.GST_VIDEO_GAMMA_MODE_NONE  // (0) – disable gamma handling
+	GammaModeRemap GammaMode = C.GST_VIDEO_GAMMA_MODE_REMAP // (1) – convert between input and output gamma
+)
+
+// PrimariesMode is the way color primaries are handled by a Converter.
+type PrimariesMode int
+
+// Type castings
+const (
+	PrimariesModeNone      PrimariesMode = C.GST_VIDEO_PRIMARIES_MODE_NONE       // (0) – disable conversion between primaries
+	PrimariesModeMergeOnly PrimariesMode = C.GST_VIDEO_PRIMARIES_MODE_MERGE_ONLY // (1) – do conversion between primaries only when it can be merged with color matrix conversion.
+	PrimariesModeFast      PrimariesMode = C.GST_VIDEO_PRIMARIES_MODE_FAST       // (2) – fast conversion between primaries
+)
+
+// ConverterConfig holds the options of a Converter. Options that are not set keep their defaults. It
+// can be built by chaining the setters to NewConverterConfig.
+//
+//   config := video.NewConverterConfig().
+//       WithResamplerMethod(video.ResamplerMethodLanczos).
+//       WithSrcRect(image.Rect(0, 0, 1280, 720)).
+//       WithThreads(4)
+type ConverterConfig struct {
+	resamplerMethod       *ResamplerMethod
+	chromaResamplerMethod *ResamplerMethod
+	resamplerTaps         *uint
+	ditherMethod          *DitherMethod
+	ditherQuantization    *uint
+	srcRect               *image.Rectangle
+	destRect              *image.Rectangle
+	fillBorder            *bool
+	borderARGB            *uint32
+	alphaValue            *float64
+	alphaMode             *AlphaMode
+	chromaMode            *ChromaMode
+	matrixMode            *MatrixMode
+	gammaMode             *GammaMode
+	primariesMode         *PrimariesMode
+	threads               *uint
+}
+
+// NewConverterConfig returns a new empty ConverterConfig.
+func NewConverterConfig() *ConverterConfig { return &ConverterConfig{} }
+
+// WithResamplerMethod sets the method used for scaling. The default is ResamplerMethodCubic.
+func (c *ConverterConfig) WithResamplerMethod(method ResamplerMethod) *ConverterConfig {
+	c.resamplerMethod = &method
+	return c
+}
+
+// WithChromaResamplerMethod sets the method used for chroma resampling. The default is
+// ResamplerMethodLinear.
+func (c *ConverterConfig) WithChromaResamplerMethod(method ResamplerMethod) *ConverterConfig {
+	c.chromaResamplerMethod = &method
+	return c
+}
+
+// WithResamplerTaps sets the number of taps used for scaling. The default of 0 uses the default of the
+// method.
+func (c *ConverterConfig) WithResamplerTaps(taps uint) *ConverterConfig {
+	c.resamplerTaps = &taps
+	return c
+}
+
+// WithDitherMethod sets the dither method used when converting to a lower color depth. The default is
+// DitherBayer.
+func (c *ConverterConfig) WithDitherMethod(method DitherMethod) *ConverterConfig {
+	c.ditherMethod = &method
+	return c
+}
+
+// WithDitherQuantization sets the quantization step of the output colors. The default of 1 means no
+// additional quantization.
+func (c *ConverterConfig) WithDitherQuantization(quantization uint) *ConverterConfig {
+	c.ditherQuantization = &quantization
+	return c
+}
+
+// WithSrcRect sets the rectangle of the input frames to convert, i.e. crops them. The default is the
+// whole frame.
+func (c *ConverterConfig) WithSrcRect(rect image.Rectangle) *ConverterConfig {
+	c.srcRect = &rect
+	return c
+}
+
+// WithDestRect sets the rectangle of the output frames to write the converted pixels to, scaling them
+// as needed. The default is the whole frame. Pixels outside of it are filled with the border color
+// unless WithFillBorder is false.
+func (c *ConverterConfig) WithDestRect(rect image.Rectangle) *ConverterConfig {
+	c.destRect = &rect
+	return c
+}
+
+// WithFillBorder sets whether the output pixels outside of the destination rectangle are filled with
+// the border color. The default is true.
+func (c *ConverterConfig) WithFillBorder(fill bool) *ConverterConfig {
+	c.fillBorder = &fill
+	return c
+}
+
+// WithBorderARGB sets the color of the border as ARGB. The default is opaque black, 0xff000000.
+func (c *ConverterConfig) WithBorderARGB(argb uint32) *ConverterConfig {
+	c.borderARGB = &argb
+	return c
+}
+
+// WithAlphaValue sets the alpha value, from 0.0 to 1.0, used by the alpha mode. The default is 1.0.
+func (c *ConverterConfig) WithAlphaValue(alpha float64) *ConverterConfig {
+	c.alphaValue = &alpha
+	return c
+}
+
+// WithAlphaMode sets how the alpha of the output is computed. The default is AlphaModeCopy.
+func (c *ConverterConfig) WithAlphaMode(mode AlphaMode) *ConverterConfig {
+	c.alphaMode = &mode
+	return c
+}
+
+// WithChromaMode sets how chroma is resampled. The default is ChromaModeFull.
+func (c *ConverterConfig) WithChromaMode(mode ChromaMode) *ConverterConfig {
+	c.chromaMode = &mode
+	return c
+}
+
+// WithMatrixMode sets how color matrices are converted. The default is MatrixModeFull.
+func (c *ConverterConfig) WithMatrixMode(mode MatrixMode) *ConverterConfig {
+	c.matrixMode = &mode
+	return c
+}
+
+// WithGammaMode sets how gamma is converted. The default is GammaModeNone.
+func (c *ConverterConfig) WithGammaMode(mode GammaMode) *ConverterConfig {
+	c.gammaMode = &mode
+	return c
+}
+
+// WithPrimariesMode sets how color primaries are converted. The default is PrimariesModeNone.
+func (c *ConverterConfig) WithPrimariesMode(mode PrimariesMode) *ConverterConfig {
+	c.primariesMode = &mode
+	return c
+}
+
+// WithThreads sets the maximum number of threads used for conversion. The default is 1, and 0 uses one
+// thread per CPU core.
+func (c *ConverterConfig) WithThreads(threads uint) *ConverterConfig {
+	c.threads = &threads
+	return c
+}
+
+// toStructure returns a new GstStructure holding the options, or nil if c is nil.
+func (c *ConverterConfig) toStructure() *C.GstStructure {
+	if c == nil {
+		return nil
+	}
+	name := C.CString("GstVideoConverter")
+	defer C.free(unsafe.Pointer(name))
+	config := C.gst_structure_new_empty(name)
+	if c.resamplerMethod != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_RESAMPLER_METHOD, C.videoResamplerMethodType(), int(*c.resamplerMethod))
+	}
+	if c.chromaResamplerMethod != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_CHROMA_RESAMPLER_METHOD, C.videoResamplerMethodType(), int(*c.chromaResamplerMethod))
+	}
+	if c.resamplerTaps != nil {
+		setVideoConfigUint(config, C.GST_VIDEO_CONVERTER_OPT_RESAMPLER_TAPS, *c.resamplerTaps)
+	}
+	if c.ditherMethod != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_DITHER_METHOD, C.videoDitherMethodType(), int(*c.ditherMethod))
+	}
+	if c.ditherQuantization != nil {
+		setVideoConfigUint(config, C.GST_VIDEO_CONVERTER_OPT_DITHER_QUANTIZATION, *c.ditherQuantization)
+	}
+	if c.srcRect != nil {
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_SRC_X, c.srcRect.Min.X)
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_SRC_Y, c.srcRect.Min.Y)
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_SRC_WIDTH, c.srcRect.Dx())
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_SRC_HEIGHT, c.srcRect.Dy())
+	}
+	if c.destRect != nil {
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_DEST_X, c.destRect.Min.X)
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_DEST_Y, c.destRect.Min.Y)
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_DEST_WIDTH, c.destRect.Dx())
+		setVideoConfigInt(config, C.GST_VIDEO_CONVERTER_OPT_DEST_HEIGHT, c.destRect.Dy())
+	}
+	if c.fillBorder != nil {
+		cName := C.CString(C.GST_VIDEO_CONVERTER_OPT_FILL_BORDER)
+		defer C.free(unsafe.Pointer(cName))
+		C.setVideoConfigBool(config, cName, gboolean(*c.fillBorder))
+	}
+	if c.borderARGB != nil {
+		setVideoConfigUint(config, C.GST_VIDEO_CONVERTER_OPT_BORDER_ARGB, uint(*c.borderARGB))
+	}
+	if c.alphaValue != nil {
+		setVideoConfigDouble(config, C.GST_VIDEO_CONVERTER_OPT_ALPHA_VALUE, *c.alphaValue)
+	}
+	if c.alphaMode != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_ALPHA_MODE, C.videoAlphaModeType(), int(*c.alphaMode))
+	}
+	if c.chromaMode != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_CHROMA_MODE, C.videoChromaModeType(), int(*c.chromaMode))
+	}
+	if c.matrixMode != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_MATRIX_MODE, C.videoMatrixModeType(), int(*c.matrixMode))
+	}
+	if c.gammaMode != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_GAMMA_MODE, C.videoGammaModeType(), int(*c.gammaMode))
+	}
+	if c.primariesMode != nil {
+		setVideoConfigEnum(config, C.GST_VIDEO_CONVERTER_OPT_PRIMARIES_MODE, C.videoPrimariesModeType(), int(*c.primariesMode))
+	}
+	if c.threads != nil {
+		setVideoConfigUint(config, C.GST_VIDEO_CONVERTER_OPT_THREADS, *c.threads)
+	}
+	return config
+}
+
+func setVideoConfigEnum(config *C.GstStructure, name string, gtype C.GType, value int) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.setVideoConfigEnum(config, cName, gtype, C.gint(value))
+}
+
+func setVideoConfigUint(config *C.GstStructure, name string, value uint) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.setVideoConfigUint(config, cName, C.guint(value))
+}
+
+// Converter is a go representation of a GstVideoConverter. It converts frames between formats, sizes
+// and colorimetries without the need for a pipeline, and can be reused for every frame of a stream.
+//
+//   in := video.NewInfo().WithFormat(video.FormatNV12, 1920, 1080)
+//   out := video.NewInfo().WithFormat(video.FormatRGBA, 640, 360)
+//   converter, err := video.NewConverter(in, out, nil)
+//   if err != nil {
+//       panic(err)
+//   }
+//   defer converter.Free()
+//
+//   for buffer := range buffers {
+//       rgba, err := converter.ConvertBuffer(buffer)
+//       ...
+//   }
+type Converter struct {
+	ptr     *C.GstVideoConverter
+	inInfo  *Info
+	outInfo *Info
+}
+
+// NewConverter creates a new Converter converting from inInfo to outInfo with the given config, which
+// may be nil to use the defaults.
+func NewConverter(inInfo, outInfo *Info, config *ConverterConfig) (*Converter, error) {
+	conv := C.gst_video_converter_new(inInfo.instance(), outInfo.instance(), config.toStructure())
+	if conv == nil {
+		return nil, errors.New("Could not create a converter for the given infos")
+	}
+	converter := &Converter{ptr: conv, inInfo: inInfo.Copy(), outInfo: outInfo.Copy()}
+	runtime.SetFinalizer(converter, (*Converter).Free)
+	return converter, nil
+}
+
+// Free frees the resources of the converter. It cannot be used afterwards.
+func (c *Converter) Free() {
+	if c.ptr == nil {
+		return
+	}
+	C.gst_video_converter_free(c.ptr)
+	c.ptr = nil
+}
+
+// InInfo returns the info of the input frames of the converter.
+func (c *Converter) InInfo() *Info { return c.inInfo.Copy() }
+
+// OutInfo returns the info of the output frames of the converter.
+func (c *Converter) OutInfo() *Info { return c.outInfo.Copy() }
+
+// SetConfig updates the options of the converter. Options that are not set in config keep their current
+// values.
+func (c *Converter) SetConfig(config *ConverterConfig) error {
+	cConfig := config.toStructure()
+	if cConfig == nil {
+		return nil
+	}
+	if !gobool(C.gst_video_converter_set_config(c.ptr, cConfig)) {
+		return errors.New("Failed to update the converter config")
+	}
+	return nil
+}
+
+// Convert converts the pixels of src into dest. src must be mapped for reading with the format and size
+// of InInfo, and dest for writing with the format and size of OutInfo.
+func (c *Converter) Convert(src, dest *Frame) error {
+	if src.Format() != c.inInfo.Format() || src.Width() != c.inInfo.Width() || src.Height() != c.inInfo.Height() {
+		return errors.New("The source frame does not match the input info of the converter")
+	}
+	if dest.Format() != c.outInfo.Format() || dest.Width() != c.outInfo.Width() || dest.Height() != c.outInfo.Height() {
+		return errors.New("The destination frame does not match the output info of the converter")
+	}
+	C.gst_video_converter_frame(c.ptr, src.ptr, dest.ptr)
+	return nil
+}
+
+// ConvertBuffer converts the frame in the given buffer and returns a new buffer holding the output
+// frame. The metadata of the buffer, e.g. its timestamps, is copied to the new buffer.
+func (c *Converter) ConvertBuffer(buffer *gst.Buffer) (*gst.Buffer, error) {
+	src, err := MapFrame(c.inInfo, buffer, gst.MapRead)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Unmap()
+	out := gst.NewBufferWithSize(c.outInfo.Size())
+	dest, err := MapFrame(c.outInfo, out, gst.MapWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Convert(src, dest); err != nil {
+		dest.Unmap()
+		return nil, err
+	}
+	dest.Unmap()
+	cOut := (*C.GstBuffer)(unsafe.Pointer(out.Instance()))
+	C.gst_buffer_copy_into(cOut, (*C.GstBuffer)(unsafe.Pointer(buffer.Instance())), C.GST_BUFFER_COPY_METADATA, 0, ^C.gsize(0))
+	// the video meta of the input buffer no longer describes the pixels
+	if meta := C.gst_buffer_get_video_meta(cOut); meta != nil {
+		C.gst_buffer_remove_meta(cOut, (*C.GstMeta)(unsafe.Pointer(meta)))
+	}
+	return out, nil
+}
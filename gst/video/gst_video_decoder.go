@@ -0,0 +1,284 @@
+package video
+
+/*
+#include "gst.go.h"
+
+extern gboolean      goVideoDecoderStart       (GstVideoDecoder * dec);
+extern gboolean      goVideoDecoderStop        (GstVideoDecoder * dec);
+extern gboolean      goVideoDecoderSetFormat   (GstVideoDecoder * dec, GstVideoCodecState * state);
+extern GstFlowReturn goVideoDecoderHandleFrame (GstVideoDecoder * dec, GstVideoCodecFrame * frame);
+extern GstFlowReturn goVideoDecoderDrain       (GstVideoDecoder * dec);
+extern gboolean      goVideoDecoderFlush       (GstVideoDecoder * dec);
+extern gboolean      goVideoDecoderNegotiate   (GstVideoDecoder * dec);
+
+#define GO_VDEC_PARENT_CLASS (GST_VIDEO_DECODER_CLASS(g_type_class_peek(GST_TYPE_VIDEO_DECODER)))
+
+gboolean parentVideoDecoderNegotiate (GstVideoDecoder * dec) { return GO_VDEC_PARENT_CLASS->negotiate(dec); }
+
+#if GST_CHECK_VERSION(1, 20, 0)
+
+gboolean requestSyncPoint (GstVideoDecoder * dec, GstVideoCodecFrame * frame, guint flags)
+{
+	gst_video_decoder_request_sync_point(dec, frame, (GstVideoDecoderRequestSyncPointFlags) flags);
+	return TRUE;
+}
+
+#else
+
+gboolean requestSyncPoint (GstVideoDecoder * dec, GstVideoCodecFrame * frame, guint flags) { return FALSE; }
+
+#endif
+*/
+import "C"
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// RequestSyncPointFlags are flags passed to Decoder.RequestSyncPoint.
+type RequestSyncPointFlags uint
+
+// Type castings
+const (
+	RequestSyncPointDiscardInput  RequestSyncPointFlags = 1 << 0 // (1) – discard all following input until the next sync point.
+	RequestSyncPointCorruptOutput RequestSyncPointFlags = 1 << 1 // (2) – discard all following output until the next sync point.
+)
+
+// DecoderCallbacks are the functions implementing a Decoder registered with RegisterDecoder. Any of them
+// can be nil, in which case the default GstVideoDecoder implementation is used, though every decoder
+// needs at least SetFormatFunc and HandleFrameFunc.
+type DecoderCallbacks struct {
+	// StartFunc is called when the decoder starts processing, before any data is passed to it. It is the
+	// place to allocate the resources of the decoder, e.g. with SetInstanceData.
+	StartFunc func(self *Decoder) bool
+	// StopFunc is called when the decoder stops processing. It is the place to free the resources of the
+	// decoder.
+	StopFunc func(self *Decoder) bool
+	// SetFormatFunc is called with the state of the encoded input whenever it changes. The decoder should
+	// configure itself accordingly, and call SetOutputState once the output format is known.
+	SetFormatFunc func(self *Decoder, state *CodecState) bool
+	// HandleFrameFunc decodes the given frame. The function owns the frame, and must pass it on to
+	// FinishFrame once decoded, with the decoded data in its output buffer, e.g. from AllocateOutputFrame,
+	// or to DropFrame. Decoders with a delay can keep frames and finish them later, retrieving them with
+	// GetOldestFrame or GetFrame.
+	HandleFrameFunc func(self *Decoder, frame *CodecFrame) gst.FlowReturn
+	// DrainFunc is called at the end of the stream, and whenever the decoder must output all the frames it
+	// still holds on to, e.g. before a format change.
+	DrainFunc func(self *Decoder) gst.FlowReturn
+	// FlushFunc is called when the decoder should drop all the frames it holds on to, e.g. on a seek.
+	FlushFunc func(self *Decoder) bool
+	// NegotiateFunc negotiates with downstream elements. The default implementation, which can be called
+	// with ParentNegotiate, uses the output state set with SetOutputState.
+	NegotiateFunc func(self *Decoder) bool
+}
+
+// classInit installs the implemented virtual methods on the class of a registered decoder.
+func (d *DecoderCallbacks) classInit(klass unsafe.Pointer) {
+	class := (*C.GstVideoDecoderClass)(klass)
+	if d.StartFunc != nil {
+		class.start = (*[0]byte)(C.goVideoDecoderStart)
+	}
+	if d.StopFunc != nil {
+		class.stop = (*[0]byte)(C.goVideoDecoderStop)
+	}
+	if d.SetFormatFunc != nil {
+		class.set_format = (*[0]byte)(C.goVideoDecoderSetFormat)
+	}
+	if d.HandleFrameFunc != nil {
+		class.handle_frame = (*[0]byte)(C.goVideoDecoderHandleFrame)
+	}
+	if d.DrainFunc != nil {
+		class.drain = (*[0]byte)(C.goVideoDecoderDrain)
+		class.finish = (*[0]byte)(C.goVideoDecoderDrain)
+	}
+	if d.FlushFunc != nil {
+		class.flush = (*[0]byte)(C.goVideoDecoderFlush)
+	}
+	if d.NegotiateFunc != nil {
+		class.negotiate = (*[0]byte)(C.goVideoDecoderNegotiate)
+	}
+}
+
+// Decoder is a go representation of a GstVideoDecoder. It is passed to the DecoderCallbacks of a
+// decoder registered with RegisterDecoder. The base class takes care of timestamps, latency, QoS,
+// allocation and reordering.
+type Decoder struct{ *gst.Element }
+
+// RegisterDecoder registers a new decoder element with the given name and rank, implemented by the given
+// callbacks. sinkCaps are the caps of the encoded video it accepts, and srcCaps the caps of the raw video
+// it produces. The decoder is expected to be fed whole frames, e.g. by a parser. plugin may be nil to
+// register the element statically, as is usual for applications. Once registered, the element can be
+// created by name like any other, e.g. with gst.NewElement, and is picked up by decodebin when its rank is
+// high enough.
+//
+//   video.RegisterDecoder(nil, "myvp8dec", gst.RankPrimary+1, &gst.ElementMetadata{
+//       LongName:    "VP8 decoder",
+//       Klass:       "Codec/Decoder/Video",
+//       Description: "Decodes VP8 in pure go",
+//       Author:      "Jane Doe <jane@example.com>",
+//   }, gst.NewCapsFromString("video/x-vp8"),
+//       gst.NewCapsFromString("video/x-raw, format=I420"),
+//       &video.DecoderCallbacks{
+//           SetFormatFunc: func(self *video.Decoder, state *video.CodecState) bool {
+//               info := state.Info()
+//               self.SetOutputState(video.FormatI420, uint(info.Width()), uint(info.Height()), state)
+//               return true
+//           },
+//           HandleFrameFunc: func(self *video.Decoder, frame *video.CodecFrame) gst.FlowReturn {
+//               if ret := self.AllocateOutputFrame(frame); ret != gst.FlowOK {
+//                   self.DropFrame(frame)
+//                   return ret
+//               }
+//               decodeInto(frame.OutputBuffer(), frame.InputBuffer())
+//               return self.FinishFrame(frame)
+//           },
+//       })
+func RegisterDecoder(plugin *gst.Plugin, name string, rank gst.Rank, metadata *gst.ElementMetadata, sinkCaps, srcCaps *gst.Caps, cbs *DecoderCallbacks) error {
+	return gst.RegisterGoElementUnsafe(plugin, name, rank, glib.Type(C.GST_TYPE_VIDEO_DECODER), metadata, sinkCaps, srcCaps, cbs, cbs.classInit)
+}
+
+// DecoderFromElement returns the Decoder of the given element, or nil if it is not a decoder registered
+// with RegisterDecoder.
+func DecoderFromElement(elem *gst.Element) *Decoder {
+	if _, ok := gst.GoElementCallbacksUnsafe(elem.Unsafe()).(*DecoderCallbacks); !ok {
+		return nil
+	}
+	return &Decoder{elem}
+}
+
+func wrapCDecoder(dec *C.GstVideoDecoder) *Decoder {
+	return &Decoder{gst.FromGstElementUnsafe(unsafe.Pointer(dec))}
+}
+
+func getDecoderCallbacks(dec *C.GstVideoDecoder) *DecoderCallbacks {
+	return gst.GoElementCallbacksUnsafe(unsafe.Pointer(dec)).(*DecoderCallbacks)
+}
+
+// Instance returns the underlying GstVideoDecoder instance.
+func (d *Decoder) Instance() *C.GstVideoDecoder { return (*C.GstVideoDecoder)(d.Unsafe()) }
+
+// SetInstanceData stores the given value with this decoder instance, e.g. the state of the codec. Any
+// previous value is released, and the last one is released when the decoder is finalized.
+func (d *Decoder) SetInstanceData(data interface{}) { gst.SetGoElementDataUnsafe(d.Unsafe(), data) }
+
+// InstanceData returns the value stored with SetInstanceData, or nil if there is none.
+func (d *Decoder) InstanceData() interface{} { return gst.GoElementDataUnsafe(d.Unsafe()) }
+
+// SetOutputState creates a new output state with the given format and dimensions, copying the other
+// fields of reference, e.g. its framerate and pixel aspect ratio, which may be nil. It can be adjusted
+// before it is used for negotiation. nil is returned on failure.
+func (d *Decoder) SetOutputState(format Format, width, height uint, reference *CodecState) *CodecState {
+	var ref *C.GstVideoCodecState
+	if reference != nil {
+		ref = reference.Instance()
+	}
+	return takeCodecState(C.gst_video_decoder_set_output_state(
+		d.Instance(),
+		C.GstVideoFormat(format),
+		C.guint(width),
+		C.guint(height),
+		ref,
+	))
+}
+
+// GetOutputState returns the current output state, or nil if it is not set.
+func (d *Decoder) GetOutputState() *CodecState {
+	return takeCodecState(C.gst_video_decoder_get_output_state(d.Instance()))
+}
+
+// FinishFrame pushes the output buffer of the frame downstream, in presentation order, and removes the
+// frame from the pending frames. The frame is taken.
+func (d *Decoder) FinishFrame(frame *CodecFrame) gst.FlowReturn {
+	return gst.FlowReturn(C.gst_video_decoder_finish_frame(d.Instance(), frame.Instance()))
+}
+
+// DropFrame drops the frame, e.g. because it could not be decoded or is late, and posts a QoS message
+// about it. The frame is taken.
+func (d *Decoder) DropFrame(frame *CodecFrame) gst.FlowReturn {
+	return gst.FlowReturn(C.gst_video_decoder_drop_frame(d.Instance(), frame.Instance()))
+}
+
+// ReleaseFrame removes the frame from the pending frames without pushing it or posting a QoS message,
+// e.g. when it was only decoded to serve as a reference. The frame is taken.
+func (d *Decoder) ReleaseFrame(frame *CodecFrame) {
+	C.gst_video_decoder_release_frame(d.Instance(), frame.Instance())
+}
+
+// GetFrame returns the pending frame with the given system frame number, or nil if there is none. Unref
+// it after usage.
+func (d *Decoder) GetFrame(frameNumber int) *CodecFrame {
+	return wrapCodecFrame(C.gst_video_decoder_get_frame(d.Instance(), C.int(frameNumber)))
+}
+
+// GetOldestFrame returns the oldest pending frame, or nil if there is none. Unref it after usage.
+func (d *Decoder) GetOldestFrame() *CodecFrame {
+	return wrapCodecFrame(C.gst_video_decoder_get_oldest_frame(d.Instance()))
+}
+
+// GetFrames returns all the pending frames. Unref each of them after usage.
+func (d *Decoder) GetFrames() []*CodecFrame {
+	return takeCodecFrameList(C.gst_video_decoder_get_frames(d.Instance()))
+}
+
+// AllocateOutputFrame allocates an output buffer for the frame from the negotiated pool, matching the
+// output state. The output state is negotiated first if needed.
+func (d *Decoder) AllocateOutputFrame(frame *CodecFrame) gst.FlowReturn {
+	return gst.FlowReturn(C.gst_video_decoder_allocate_output_frame(d.Instance(), frame.Instance()))
+}
+
+// AllocateOutputBuffer allocates a buffer from the negotiated pool matching the output state, or returns
+// nil on failure.
+func (d *Decoder) AllocateOutputBuffer() *gst.Buffer {
+	buf := C.gst_video_decoder_allocate_output_buffer(d.Instance())
+	if buf == nil {
+		return nil
+	}
+	return gst.FromGstBufferUnsafeFull(unsafe.Pointer(buf))
+}
+
+// SetLatency sets the minimum and maximum latency of the decoder, which is reported in latency queries.
+func (d *Decoder) SetLatency(min, max time.Duration) {
+	C.gst_video_decoder_set_latency(d.Instance(), durationToClockTime(min), durationToClockTime(max))
+}
+
+// SetPacketized sets whether the input is packetized into whole frames. This is the default, and the only
+// mode supported by decoders registered with RegisterDecoder.
+func (d *Decoder) SetPacketized(packetized bool) {
+	C.gst_video_decoder_set_packetized(d.Instance(), gboolean(packetized))
+}
+
+// SetNeedsFormat sets whether the decoder requires the caps of the input before it can decode, instead of
+// working out the format from the data.
+func (d *Decoder) SetNeedsFormat(enabled bool) {
+	C.gst_video_decoder_set_needs_format(d.Instance(), gboolean(enabled))
+}
+
+// MergeTags merges the given tags into the tags sent downstream with the given mode.
+func (d *Decoder) MergeTags(tags *gst.TagList, mode gst.TagMergeMode) {
+	var cTags *C.GstTagList
+	if tags != nil {
+		cTags = (*C.GstTagList)(unsafe.Pointer(tags.Instance()))
+	}
+	C.gst_video_decoder_merge_tags(d.Instance(), cTags, C.GstTagMergeMode(mode))
+}
+
+// RequestSyncPoint requests upstream elements to send a keyframe, e.g. after frame could not be decoded
+// because of a missing reference. This requires GStreamer 1.20, and returns an error with older versions.
+func (d *Decoder) RequestSyncPoint(frame *CodecFrame, flags RequestSyncPointFlags) error {
+	if !gobool(C.requestSyncPoint(d.Instance(), frame.Instance(), C.guint(flags))) {
+		return errors.New("Requesting sync points requires GStreamer 1.20")
+	}
+	return nil
+}
+
+// Negotiate negotiates with downstream elements the output state set with SetOutputState. It is called
+// automatically when an output frame is allocated or finished, so it rarely needs to be called explicitly.
+func (d *Decoder) Negotiate() bool { return gobool(C.gst_video_decoder_negotiate(d.Instance())) }
+
+// ParentNegotiate calls the default Negotiate implementation of the decoder.
+func (d *Decoder) ParentNegotiate() bool { return gobool(C.parentVideoDecoderNegotiate(d.Instance())) }
@@ -0,0 +1,102 @@
+package video
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tinyzimmer/go-gst/gst"
+	"github.com/tinyzimmer/go-gst/gst/app"
+)
+
+func TestDecoderDecodesThroughPipeline(t *testing.T) {
+	metadata := &gst.ElementMetadata{
+		LongName:    "Test decoder",
+		Klass:       "Codec/Decoder/Video",
+		Description: "Decodes one byte into a frame of that byte",
+		Author:      "go-gst",
+	}
+	sinkCaps, srcCaps := gst.NewCapsFromString("video/x-test"), gst.NewCapsFromString("video/x-raw, format=GRAY8")
+	// every input buffer holds a single byte, which is repeated over a 4x2 frame
+	cbs := &DecoderCallbacks{
+		StartFunc: func(self *Decoder) bool {
+			self.SetPacketized(true)
+			self.SetInstanceData(0)
+			return true
+		},
+		SetFormatFunc: func(self *Decoder, state *CodecState) bool {
+			return self.SetOutputState(FormatGray8, 4, 2, state) != nil
+		},
+		HandleFrameFunc: func(self *Decoder, frame *CodecFrame) gst.FlowReturn {
+			self.SetInstanceData(self.InstanceData().(int) + 1)
+			in := frame.InputBuffer().Bytes()
+			if ret := self.AllocateOutputFrame(frame); ret != gst.FlowOK {
+				self.DropFrame(frame)
+				return ret
+			}
+			frame.OutputBuffer().FillBytes(0, bytes.Repeat(in[:1], 8))
+			return self.FinishFrame(frame)
+		},
+	}
+	if err := RegisterDecoder(nil, "gotestvideodec", gst.RankNone, metadata, sinkCaps, srcCaps, cbs); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterDecoder(nil, "gotestvideodec", gst.RankNone, metadata, sinkCaps, srcCaps, cbs); err == nil {
+		t.Fatal("Expected an error when registering the same decoder twice")
+	}
+
+	before := gst.LiveCallbacks()
+	pipeline, err := gst.NewPipelineFromString("appsrc name=src caps=video/x-test format=time ! gotestvideodec name=dec ! appsink name=sink sync=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srcElem, _ := pipeline.GetElementByName("src")
+	decElem, _ := pipeline.GetElementByName("dec")
+	sinkElem, _ := pipeline.GetElementByName("sink")
+	if EncoderFromElement(decElem) != nil {
+		t.Fatal("Expected the decoder not to be a go encoder")
+	}
+	dec := DecoderFromElement(decElem)
+	if dec == nil {
+		t.Fatal("Expected the element to be a go decoder")
+	}
+	src, sink := app.SrcFromElement(srcElem), app.SinkFromElement(sinkElem)
+	if err := pipeline.SetState(gst.StatePlaying); err != nil {
+		t.Fatal(err)
+	}
+
+	input := []byte{1, 2, 3}
+	for i, b := range input {
+		buf := gst.NewBufferFromBytes([]byte{b})
+		buf.SetPresentationTimestamp(time.Duration(i) * 40 * time.Millisecond)
+		buf.SetDuration(40 * time.Millisecond)
+		if ret := src.PushBuffer(buf); ret != gst.FlowOK {
+			t.Fatalf("Could not push buffer %d: %s", i, ret)
+		}
+	}
+	src.EndStream()
+
+	for i, b := range input {
+		sample := sink.TryPullSample(5 * time.Second)
+		if sample == nil {
+			t.Fatalf("Expected decoded frame %d", i)
+		}
+		if got := sample.GetBuffer().Bytes(); !bytes.Equal(got, bytes.Repeat([]byte{b}, 8)) {
+			t.Errorf("Unexpected decoded frame %d: %v", i, got)
+		}
+		sample.Unref()
+	}
+	if data := dec.InstanceData(); data != len(input) {
+		t.Errorf("Expected HandleFrameFunc to be called for every buffer, got %v calls", data)
+	}
+
+	srcElem.Unref()
+	decElem.Unref()
+	sinkElem.Unref()
+	if err := pipeline.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gst.CheckCallbackLeaks(before, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,229 @@
+package video
+
+/*
+#include "gst.go.h"
+
+extern gboolean      goVideoEncoderStart       (GstVideoEncoder * enc);
+extern gboolean      goVideoEncoderStop        (GstVideoEncoder * enc);
+extern gboolean      goVideoEncoderSetFormat   (GstVideoEncoder * enc, GstVideoCodecState * state);
+extern GstFlowReturn goVideoEncoderHandleFrame (GstVideoEncoder * enc, GstVideoCodecFrame * frame);
+extern GstFlowReturn goVideoEncoderFinish      (GstVideoEncoder * enc);
+extern gboolean      goVideoEncoderFlush       (GstVideoEncoder * enc);
+extern gboolean      goVideoEncoderNegotiate   (GstVideoEncoder * enc);
+
+#define GO_VENC_PARENT_CLASS (GST_VIDEO_ENCODER_CLASS(g_type_class_peek(GST_TYPE_VIDEO_ENCODER)))
+
+gboolean parentVideoEncoderNegotiate (GstVideoEncoder * enc) { return GO_VENC_PARENT_CLASS->negotiate(enc); }
+
+void setVideoEncoderHeaders (GstVideoEncoder * enc, GstBuffer ** headers, gint n)
+{
+	GList * list = NULL;
+	gint i;
+	for (i = 0; i < n; i++)
+		list = g_list_append(list, headers[i]);
+	gst_video_encoder_set_headers(enc, list);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// EncoderCallbacks are the functions implementing an Encoder registered with RegisterEncoder. Any of them
+// can be nil, in which case the default GstVideoEncoder implementation is used, though every encoder
+// needs at least SetFormatFunc and HandleFrameFunc.
+type EncoderCallbacks struct {
+	// StartFunc is called when the encoder starts processing, before any data is passed to it. It is the
+	// place to allocate the resources of the encoder, e.g. with SetInstanceData.
+	StartFunc func(self *Encoder) bool
+	// StopFunc is called when the encoder stops processing. It is the place to free the resources of the
+	// encoder.
+	StopFunc func(self *Encoder) bool
+	// SetFormatFunc is called with the state of the raw input whenever it changes. The encoder should
+	// configure itself accordingly and call SetOutputState with the caps of the encoded stream.
+	SetFormatFunc func(self *Encoder, state *CodecState) bool
+	// HandleFrameFunc encodes the given frame. The function owns the frame, and must pass it on to
+	// FinishFrame once encoded, with the encoded data set as its output buffer. Encoders with a delay can
+	// keep frames and finish them later, retrieving them with GetOldestFrame or GetFrame. A keyframe must
+	// be produced when the frame IsForceKeyframe.
+	HandleFrameFunc func(self *Encoder, frame *CodecFrame) gst.FlowReturn
+	// DrainFunc is called at the end of the stream. The encoder should finish all the frames it still
+	// holds on to.
+	DrainFunc func(self *Encoder) gst.FlowReturn
+	// FlushFunc is called when the encoder should drop all the frames it holds on to, e.g. on a seek.
+	FlushFunc func(self *Encoder) bool
+	// NegotiateFunc negotiates with downstream elements. The default implementation, which can be called
+	// with ParentNegotiate, uses the output state set with SetOutputState.
+	NegotiateFunc func(self *Encoder) bool
+}
+
+// classInit installs the implemented virtual methods on the class of a registered encoder.
+func (e *EncoderCallbacks) classInit(klass unsafe.Pointer) {
+	class := (*C.GstVideoEncoderClass)(klass)
+	if e.StartFunc != nil {
+		class.start = (*[0]byte)(C.goVideoEncoderStart)
+	}
+	if e.StopFunc != nil {
+		class.stop = (*[0]byte)(C.goVideoEncoderStop)
+	}
+	if e.SetFormatFunc != nil {
+		class.set_format = (*[0]byte)(C.goVideoEncoderSetFormat)
+	}
+	if e.HandleFrameFunc != nil {
+		class.handle_frame = (*[0]byte)(C.goVideoEncoderHandleFrame)
+	}
+	if e.DrainFunc != nil {
+		class.finish = (*[0]byte)(C.goVideoEncoderFinish)
+	}
+	if e.FlushFunc != nil {
+		class.flush = (*[0]byte)(C.goVideoEncoderFlush)
+	}
+	if e.NegotiateFunc != nil {
+		class.negotiate = (*[0]byte)(C.goVideoEncoderNegotiate)
+	}
+}
+
+// Encoder is a go representation of a GstVideoEncoder. It is passed to the EncoderCallbacks of an
+// encoder registered with RegisterEncoder. The base class takes care of timestamps, latency, tags,
+// allocation and force-key-unit events.
+type Encoder struct{ *gst.Element }
+
+// RegisterEncoder registers a new encoder element with the given name and rank, implemented by the given
+// callbacks. sinkCaps are the caps of the raw video it accepts, and srcCaps the caps of the encoded video
+// it produces. plugin may be nil to register the element statically, as is usual for applications. Once
+// registered, the element can be created by name like any other, e.g. with gst.NewElement, and is picked
+// up by encodebin when its rank is high enough.
+//
+//   video.RegisterEncoder(nil, "myimageenc", gst.RankPrimary, &gst.ElementMetadata{
+//       LongName:    "Image sequence encoder",
+//       Klass:       "Codec/Encoder/Video",
+//       Description: "Encodes every frame as a separate image",
+//       Author:      "Jane Doe <jane@example.com>",
+//   }, gst.NewCapsFromString("video/x-raw, format=RGBA"),
+//       gst.NewCapsFromString("video/x-my-images"),
+//       &video.EncoderCallbacks{
+//           SetFormatFunc: func(self *video.Encoder, state *video.CodecState) bool {
+//               return self.SetOutputState(gst.NewCapsFromString("video/x-my-images"), state) != nil
+//           },
+//           HandleFrameFunc: func(self *video.Encoder, frame *video.CodecFrame) gst.FlowReturn {
+//               frame.SetOutputBuffer(gst.NewBufferFromBytes(encode(frame.InputBuffer().Bytes())))
+//               frame.SetFlag(video.CodecFrameFlagSyncPoint)
+//               return self.FinishFrame(frame)
+//           },
+//       })
+func RegisterEncoder(plugin *gst.Plugin, name string, rank gst.Rank, metadata *gst.ElementMetadata, sinkCaps, srcCaps *gst.Caps, cbs *EncoderCallbacks) error {
+	return gst.RegisterGoElementUnsafe(plugin, name, rank, glib.Type(C.GST_TYPE_VIDEO_ENCODER), metadata, sinkCaps, srcCaps, cbs, cbs.classInit)
+}
+
+// EncoderFromElement returns the Encoder of the given element, or nil if it is not an encoder registered
+// with RegisterEncoder.
+func EncoderFromElement(elem *gst.Element) *Encoder {
+	if _, ok := gst.GoElementCallbacksUnsafe(elem.Unsafe()).(*EncoderCallbacks); !ok {
+		return nil
+	}
+	return &Encoder{elem}
+}
+
+func wrapCEncoder(enc *C.GstVideoEncoder) *Encoder {
+	return &Encoder{gst.FromGstElementUnsafe(unsafe.Pointer(enc))}
+}
+
+func getEncoderCallbacks(enc *C.GstVideoEncoder) *EncoderCallbacks {
+	return gst.GoElementCallbacksUnsafe(unsafe.Pointer(enc)).(*EncoderCallbacks)
+}
+
+// Instance returns the underlying GstVideoEncoder instance.
+func (e *Encoder) Instance() *C.GstVideoEncoder { return (*C.GstVideoEncoder)(e.Unsafe()) }
+
+// SetInstanceData stores the given value with this encoder instance, e.g. the state of the codec. Any
+// previous value is released, and the last one is released when the encoder is finalized.
+func (e *Encoder) SetInstanceData(data interface{}) { gst.SetGoElementDataUnsafe(e.Unsafe(), data) }
+
+// InstanceData returns the value stored with SetInstanceData, or nil if there is none.
+func (e *Encoder) InstanceData() interface{} { return gst.GoElementDataUnsafe(e.Unsafe()) }
+
+// SetOutputState creates a new output state with the given caps, copying the fields of reference, e.g.
+// its framerate and pixel aspect ratio, which may be nil. The caps should only contain the fixed codec
+// specific fields. It can be adjusted before it is used for negotiation. nil is returned on failure.
+func (e *Encoder) SetOutputState(caps *gst.Caps, reference *CodecState) *CodecState {
+	var ref *C.GstVideoCodecState
+	if reference != nil {
+		ref = reference.Instance()
+	}
+	return takeCodecState(C.gst_video_encoder_set_output_state(e.Instance(), C.gst_caps_ref(fromCoreCaps(caps)), ref))
+}
+
+// GetOutputState returns the current output state, or nil if it is not set.
+func (e *Encoder) GetOutputState() *CodecState {
+	return takeCodecState(C.gst_video_encoder_get_output_state(e.Instance()))
+}
+
+// FinishFrame pushes the output buffer of the frame downstream, and removes the frame from the pending
+// frames. The frame is taken. Frames must be finished in the order they were handed to HandleFrameFunc.
+// A frame without an output buffer is dropped.
+func (e *Encoder) FinishFrame(frame *CodecFrame) gst.FlowReturn {
+	return gst.FlowReturn(C.gst_video_encoder_finish_frame(e.Instance(), frame.Instance()))
+}
+
+// GetFrame returns the pending frame with the given system frame number, or nil if there is none. Unref
+// it after usage.
+func (e *Encoder) GetFrame(frameNumber int) *CodecFrame {
+	return wrapCodecFrame(C.gst_video_encoder_get_frame(e.Instance(), C.int(frameNumber)))
+}
+
+// GetOldestFrame returns the oldest pending frame, or nil if there is none. Unref it after usage.
+func (e *Encoder) GetOldestFrame() *CodecFrame {
+	return wrapCodecFrame(C.gst_video_encoder_get_oldest_frame(e.Instance()))
+}
+
+// GetFrames returns all the pending frames. Unref each of them after usage.
+func (e *Encoder) GetFrames() []*CodecFrame {
+	return takeCodecFrameList(C.gst_video_encoder_get_frames(e.Instance()))
+}
+
+// AllocateOutputFrame allocates an output buffer of the given size for the frame from the negotiated
+// allocator.
+func (e *Encoder) AllocateOutputFrame(frame *CodecFrame, size int64) gst.FlowReturn {
+	return gst.FlowReturn(C.gst_video_encoder_allocate_output_frame(e.Instance(), frame.Instance(), C.gsize(size)))
+}
+
+// SetLatency sets the minimum and maximum latency of the encoder, which is reported in latency queries.
+func (e *Encoder) SetLatency(min, max time.Duration) {
+	C.gst_video_encoder_set_latency(e.Instance(), durationToClockTime(min), durationToClockTime(max))
+}
+
+// SetHeaders sets the headers pushed downstream before the first encoded buffer, e.g. codec setup data.
+// The buffers are taken.
+func (e *Encoder) SetHeaders(headers []*gst.Buffer) error {
+	if len(headers) == 0 {
+		return errors.New("At least one header buffer is required")
+	}
+	cHeaders := make([]*C.GstBuffer, len(headers))
+	for i, header := range headers {
+		cHeaders[i] = (*C.GstBuffer)(header.TransferUnsafe())
+	}
+	C.setVideoEncoderHeaders(e.Instance(), &cHeaders[0], C.gint(len(cHeaders)))
+	return nil
+}
+
+// MergeTags merges the given tags into the tags sent downstream with the given mode.
+func (e *Encoder) MergeTags(tags *gst.TagList, mode gst.TagMergeMode) {
+	var cTags *C.GstTagList
+	if tags != nil {
+		cTags = (*C.GstTagList)(unsafe.Pointer(tags.Instance()))
+	}
+	C.gst_video_encoder_merge_tags(e.Instance(), cTags, C.GstTagMergeMode(mode))
+}
+
+// Negotiate negotiates with downstream elements the output state set with SetOutputState. It is called
+// automatically before the first frame is finished, so it rarely needs to be called explicitly.
+func (e *Encoder) Negotiate() bool { return gobool(C.gst_video_encoder_negotiate(e.Instance())) }
+
+// ParentNegotiate calls the default Negotiate implementation of the encoder.
+func (e *Encoder) ParentNegotiate() bool { return gobool(C.parentVideoEncoderNegotiate(e.Instance())) }
@@ -0,0 +1,87 @@
+package video
+
+/*
+#include "gst.go.h"
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// NewDownstreamForceKeyUnitEvent creates a new downstream force key unit event. It is sent by encoders
+// along with the keyframe they produced in response to an upstream force key unit event, or on their
+// own, so that downstream elements, e.g. muxers, can start a new segment or file at the keyframe.
+// timestamp, streamTime and runningTime are the times of the keyframe, or negative durations if they
+// are not known. allHeaders tells whether the stream headers are sent along with the keyframe, and count
+// is the number of the key unit event.
+func NewDownstreamForceKeyUnitEvent(timestamp, streamTime, runningTime time.Duration, allHeaders bool, count uint) *gst.Event {
+	return gst.FromGstEventUnsafeFull(unsafe.Pointer(C.gst_video_event_new_downstream_force_key_unit(
+		durationToClockTime(timestamp),
+		durationToClockTime(streamTime),
+		durationToClockTime(runningTime),
+		gboolean(allHeaders),
+		C.guint(count),
+	)))
+}
+
+// NewUpstreamForceKeyUnitEvent creates a new upstream force key unit event. It is sent upstream, e.g. by
+// a sink or muxer, to request an encoder to produce a keyframe. runningTime is the running time at
+// which the keyframe should be produced, or a negative duration to produce it as soon as possible.
+// allHeaders requests the stream headers to be sent along with the keyframe, and count is the number of
+// the key unit event, which the encoder passes on in its downstream event.
+func NewUpstreamForceKeyUnitEvent(runningTime time.Duration, allHeaders bool, count uint) *gst.Event {
+	return gst.FromGstEventUnsafeFull(unsafe.Pointer(C.gst_video_event_new_upstream_force_key_unit(
+		durationToClockTime(runningTime),
+		gboolean(allHeaders),
+		C.guint(count),
+	)))
+}
+
+// IsForceKeyUnitEvent returns true if the given event is an upstream or downstream force key unit event.
+func IsForceKeyUnitEvent(event *gst.Event) bool {
+	return gobool(C.gst_video_event_is_force_key_unit(fromCoreEvent(event)))
+}
+
+// ParseDownstreamForceKeyUnitEvent parses a downstream force key unit event. ok is false if the event is
+// not one. Unknown times are returned as negative durations.
+func ParseDownstreamForceKeyUnitEvent(event *gst.Event) (timestamp, streamTime, runningTime time.Duration, allHeaders bool, count uint, ok bool) {
+	var ts, st, rt C.GstClockTime
+	var headers C.gboolean
+	var cCount C.guint
+	if !gobool(C.gst_video_event_parse_downstream_force_key_unit(fromCoreEvent(event), &ts, &st, &rt, &headers, &cCount)) {
+		return
+	}
+	return clockTimeToDuration(ts), clockTimeToDuration(st), clockTimeToDuration(rt), gobool(headers), uint(cCount), true
+}
+
+// ParseUpstreamForceKeyUnitEvent parses an upstream force key unit event. ok is false if the event is not
+// one. An unset running time is returned as a negative duration.
+func ParseUpstreamForceKeyUnitEvent(event *gst.Event) (runningTime time.Duration, allHeaders bool, count uint, ok bool) {
+	var rt C.GstClockTime
+	var headers C.gboolean
+	var cCount C.guint
+	if !gobool(C.gst_video_event_parse_upstream_force_key_unit(fromCoreEvent(event), &rt, &headers, &cCount)) {
+		return
+	}
+	return clockTimeToDuration(rt), gobool(headers), uint(cCount), true
+}
+
+// NewStillFrameEvent creates a new still frame event. It is sent downstream by elements that are about
+// to output a still frame, e.g. a DVD menu, with inStill set to true, and with inStill set to false once
+// playback continues. Sinks should not wait for the clock while in a still frame.
+func NewStillFrameEvent(inStill bool) *gst.Event {
+	return gst.FromGstEventUnsafeFull(unsafe.Pointer(C.gst_video_event_new_still_frame(gboolean(inStill))))
+}
+
+// ParseStillFrameEvent parses a still frame event. ok is false if the event is not one.
+func ParseStillFrameEvent(event *gst.Event) (inStill, ok bool) {
+	var still C.gboolean
+	if !gobool(C.gst_video_event_parse_still_frame(fromCoreEvent(event), &still)) {
+		return false, false
+	}
+	return gobool(still), true
+}
@@ -0,0 +1,97 @@
+package video
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+func TestDownstreamForceKeyUnitEvent(t *testing.T) {
+	event := NewDownstreamForceKeyUnitEvent(time.Second, 2*time.Second, -1, true, 3)
+	if !IsForceKeyUnitEvent(event) {
+		t.Fatal("Expected a force key unit event")
+	}
+	timestamp, streamTime, runningTime, allHeaders, count, ok := ParseDownstreamForceKeyUnitEvent(event)
+	if !ok {
+		t.Fatal("Could not parse the downstream force key unit event")
+	}
+	if timestamp != time.Second || streamTime != 2*time.Second || runningTime >= 0 || !allHeaders || count != 3 {
+		t.Errorf("Unexpected values: %v %v %v %v %d", timestamp, streamTime, runningTime, allHeaders, count)
+	}
+	if _, _, _, ok := ParseUpstreamForceKeyUnitEvent(event); ok {
+		t.Error("Expected a downstream event not to parse as an upstream one")
+	}
+}
+
+func TestUpstreamForceKeyUnitEvent(t *testing.T) {
+	event := NewUpstreamForceKeyUnitEvent(5*time.Second, false, 7)
+	if !IsForceKeyUnitEvent(event) {
+		t.Fatal("Expected a force key unit event")
+	}
+	runningTime, allHeaders, count, ok := ParseUpstreamForceKeyUnitEvent(event)
+	if !ok {
+		t.Fatal("Could not parse the upstream force key unit event")
+	}
+	if runningTime != 5*time.Second || allHeaders || count != 7 {
+		t.Errorf("Unexpected values: %v %v %d", runningTime, allHeaders, count)
+	}
+	if _, _, _, _, _, ok := ParseDownstreamForceKeyUnitEvent(event); ok {
+		t.Error("Expected an upstream event not to parse as a downstream one")
+	}
+}
+
+func TestStillFrameEvent(t *testing.T) {
+	for _, still := range []bool{true, false} {
+		inStill, ok := ParseStillFrameEvent(NewStillFrameEvent(still))
+		if !ok || inStill != still {
+			t.Errorf("Expected a still frame event with %v, got %v %v", still, inStill, ok)
+		}
+	}
+	if _, ok := ParseStillFrameEvent(NewUpstreamForceKeyUnitEvent(-1, false, 0)); ok {
+		t.Error("Expected a force key unit event not to parse as a still frame event")
+	}
+	if IsForceKeyUnitEvent(NewStillFrameEvent(true)) {
+		t.Error("Expected a still frame event not to be a force key unit event")
+	}
+}
+
+func TestNavigationEvents(t *testing.T) {
+	event := ToNavigationEvent(gst.NewNavigationEvent(gst.NewStructureFromString(
+		"application/x-gst-navigation, event=(string)mouse-button-press, button=(int)1, pointer_x=(double)10.5, pointer_y=(double)20",
+	)))
+	if event == nil || event.GetType() != NavigationEventMouseButtonPress {
+		t.Fatal("Expected a mouse button press event")
+	}
+	button, x, y, ok := event.ParseMouseButtonEvent()
+	if !ok || button != 1 || x != 10.5 || y != 20 {
+		t.Errorf("Unexpected mouse button event: %d %v %v %v", button, x, y, ok)
+	}
+	if _, ok := event.ParseKeyEvent(); ok {
+		t.Error("Expected a mouse event not to parse as a key event")
+	}
+
+	event = ToNavigationEvent(gst.NewNavigationEvent(gst.NewStructureFromString(
+		"application/x-gst-navigation, event=(string)key-press, key=(string)Return",
+	)))
+	if event == nil || event.GetType() != NavigationEventKeyPress {
+		t.Fatal("Expected a key press event")
+	}
+	if key, ok := event.ParseKeyEvent(); !ok || key != "Return" {
+		t.Errorf("Unexpected key event: %q %v", key, ok)
+	}
+
+	event = ToNavigationEvent(gst.NewNavigationEvent(gst.NewStructureFromString(
+		"application/x-gst-navigation, event=(string)command, command-code=(uint)24",
+	)))
+	if event == nil || event.GetType() != NavigationEventCommand {
+		t.Fatal("Expected a command event")
+	}
+	if cmd, ok := event.ParseCommand(); !ok || cmd != NavigationCommandActivate {
+		t.Errorf("Unexpected command: %v %v", cmd, ok)
+	}
+
+	if ToNavigationEvent(NewStillFrameEvent(true)) != nil {
+		t.Error("Expected a still frame event not to be a navigation event")
+	}
+}
@@ -0,0 +1,444 @@
+package video
+
+/*
+#include "gst.go.h"
+
+GstVideoFrame *    newVideoFrame        (void)                     { return g_new0(GstVideoFrame, 1); }
+GstVideoInfo *     frameInfo            (GstVideoFrame * f)        { return &f->info; }
+GstVideoFrameFlags frameFlags           (GstVideoFrame * f)        { return GST_VIDEO_FRAME_FLAGS(f); }
+gint               frameID              (GstVideoFrame * f)        { return f->id; }
+GstVideoFormat     frameFormat          (GstVideoFrame * f)        { return GST_VIDEO_FRAME_FORMAT(f); }
+gint               frameWidth           (GstVideoFrame * f)        { return GST_VIDEO_FRAME_WIDTH(f); }
+gint               frameHeight          (GstVideoFrame * f)        { return GST_VIDEO_FRAME_HEIGHT(f); }
+guint              frameNPlanes         (GstVideoFrame * f)        { return GST_VIDEO_FRAME_N_PLANES(f); }
+guint              frameNComponents     (GstVideoFrame * f)        { return GST_VIDEO_FRAME_N_COMPONENTS(f); }
+gpointer           framePlaneData       (GstVideoFrame * f, gint p) { return GST_VIDEO_FRAME_PLANE_DATA(f, p); }
+gint               framePlaneStride     (GstVideoFrame * f, gint p) { return GST_VIDEO_FRAME_PLANE_STRIDE(f, p); }
+gsize              framePlaneOffset     (GstVideoFrame * f, gint p) { return GST_VIDEO_FRAME_PLANE_OFFSET(f, p); }
+gpointer           frameCompData        (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_DATA(f, c); }
+gint               frameCompStride      (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_STRIDE(f, c); }
+gint               frameCompPStride     (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_PSTRIDE(f, c); }
+gint               frameCompWidth       (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_WIDTH(f, c); }
+gint               frameCompHeight      (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_HEIGHT(f, c); }
+gint               frameCompDepth       (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_DEPTH(f, c); }
+gint               frameCompPlane       (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_PLANE(f, c); }
+gsize              frameCompOffset      (GstVideoFrame * f, gint c) { return GST_VIDEO_FRAME_COMP_OFFSET(f, c); }
+
+gsize framePlaneSize (GstVideoFrame * f, gint p)
+{
+	guint c;
+	for (c = 0; c < GST_VIDEO_FRAME_N_COMPONENTS(f); c++) {
+		if (GST_VIDEO_FRAME_COMP_PLANE(f, c) == p)
+			return GST_VIDEO_FRAME_PLANE_STRIDE(f, p) * GST_VIDEO_FRAME_COMP_HEIGHT(f, c);
+	}
+	// planes without components, e.g. palettes, span the rest of their memory
+	if (f->map[p].memory != NULL)
+		return f->map[p].size;
+	return f->map[0].size - ((guint8 *) GST_VIDEO_FRAME_PLANE_DATA(f, p) - f->map[0].data);
+}
+
+GstBuffer * convertVideoFrame (GstVideoFrame * src, GstVideoFormat format, gboolean jfif)
+{
+	GstVideoInfo info;
+	GstVideoFrame dest;
+	GstVideoConverter * conv;
+	GstBuffer * buf;
+
+	gst_video_info_set_format(&info, format, GST_VIDEO_FRAME_WIDTH(src), GST_VIDEO_FRAME_HEIGHT(src));
+	if (jfif) {
+		info.colorimetry.range = GST_VIDEO_COLOR_RANGE_0_255;
+		info.colorimetry.matrix = GST_VIDEO_COLOR_MATRIX_BT601;
+	}
+	buf = gst_buffer_new_allocate(NULL, GST_VIDEO_INFO_SIZE(&info), NULL);
+	if (!gst_video_frame_map(&dest, &info, buf, GST_MAP_WRITE)) {
+		gst_buffer_unref(buf);
+		return NULL;
+	}
+	conv = gst_video_converter_new(&src->info, &info, NULL);
+	if (conv == NULL) {
+		gst_video_frame_unmap(&dest);
+		gst_buffer_unref(buf);
+		return NULL;
+	}
+	gst_video_converter_frame(conv, src, &dest);
+	gst_video_converter_free(conv);
+	gst_video_frame_unmap(&dest);
+	return buf;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// FrameFlags are extra flags describing the contents of a Frame.
+type FrameFlags int
+
+// Type castings
+const (
+	FrameFlagNone          FrameFlags = C.GST_VIDEO_FRAME_FLAG_NONE            // (0) – no flags
+	FrameFlagInterlaced    FrameFlags = C.GST_VIDEO_FRAME_FLAG_INTERLACED      // (1) – The video frame is interlaced. In mixed interlace-mode, this flag specifies if the frame is interlaced or progressive.
+	FrameFlagTFF           FrameFlags = C.GST_VIDEO_FRAME_FLAG_TFF             // (2) – The video frame has the top field first
+	FrameFlagRFF           FrameFlags = C.GST_VIDEO_FRAME_FLAG_RFF             // (4) – The video frame has the repeat flag
+	FrameFlagOneField      FrameFlags = C.GST_VIDEO_FRAME_FLAG_ONEFIELD        // (8) – The video frame has one field
+	FrameFlagMultipleView  FrameFlags = C.GST_VIDEO_FRAME_FLAG_MULTIPLE_VIEW   // (16) – The video contains one or more non-mono views
+	FrameFlagFirstInBundle FrameFlags = C.GST_VIDEO_FRAME_FLAG_FIRST_IN_BUNDLE // (32) – The video frame is the first in a set of corresponding views provided as sequential frames.
+)
+
+// maxFrameBytes is the largest number of bytes a slice over a mapped plane can hold.
+const maxFrameBytes = 1 << 30
+
+// Frame is a go representation of a GstVideoFrame. It is a gst.Buffer mapped for access to the pixels of
+// each plane as described by an Info. When the buffer has a VideoMeta, e.g. because it was produced by a
+// hardware decoder with padded planes, the offsets and strides of the meta are used instead of the ones
+// of the info.
+//
+// The slices and images returned from a frame point directly at the mapped memory, unless stated
+// otherwise. They are only valid until Unmap is called.
+//
+//   frame, err := video.MapFrame(info, buffer, gst.MapRead)
+//   if err != nil {
+//       panic(err)
+//   }
+//   defer frame.Unmap()
+//
+//   img, err := frame.Image()
+//   if err != nil {
+//       panic(err)
+//   }
+//   png.Encode(out, img)
+type Frame struct {
+	ptr *C.GstVideoFrame
+}
+
+// MapFrame maps the buffer with the given info and flags. Unmap after usage.
+func MapFrame(info *Info, buffer *gst.Buffer, flags gst.MapFlags) (*Frame, error) {
+	ptr := C.newVideoFrame()
+	if !gobool(C.gst_video_frame_map(
+		ptr,
+		info.instance(),
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		C.GstMapFlags(flags),
+	)) {
+		C.g_free((C.gpointer)(unsafe.Pointer(ptr)))
+		return nil, errors.New("Failed to map video frame")
+	}
+	return &Frame{ptr: ptr}, nil
+}
+
+// MapSample maps the buffer of the sample using the video info of its caps. Unmap after usage.
+func MapSample(sample *gst.Sample, flags gst.MapFlags) (*Frame, error) {
+	cSample := fromCoreSample(sample)
+	caps := C.gst_sample_get_caps(cSample)
+	buffer := C.gst_sample_get_buffer(cSample)
+	if caps == nil || buffer == nil {
+		return nil, errors.New("Sample has no caps or buffer")
+	}
+	info := NewInfo()
+	if !gobool(C.gst_video_info_from_caps(info.instance(), caps)) {
+		return nil, errors.New("Sample does not contain raw video")
+	}
+	return MapFrame(info, gst.FromGstBufferUnsafe(unsafe.Pointer(buffer)), flags)
+}
+
+// Unmap unmaps the frame. The slices and images returned from the frame can no longer be used afterwards.
+func (f *Frame) Unmap() {
+	if f.ptr == nil {
+		return
+	}
+	C.gst_video_frame_unmap(f.ptr)
+	C.g_free((C.gpointer)(unsafe.Pointer(f.ptr)))
+	f.ptr = nil
+}
+
+// Instance returns the underlying GstVideoFrame instance.
+func (f *Frame) Instance() *C.GstVideoFrame { return f.ptr }
+
+// Info returns a copy of the info the frame was mapped with, including the offsets and strides of the
+// buffer's VideoMeta if it has one.
+func (f *Frame) Info() *Info { return FromGstVideoInfoUnsafe(unsafe.Pointer(C.frameInfo(f.ptr))) }
+
+// Buffer returns the gst.Buffer that is mapped.
+func (f *Frame) Buffer() *gst.Buffer { return gst.FromGstBufferUnsafe(unsafe.Pointer(f.ptr.buffer)) }
+
+// Flags returns the flags of the frame.
+func (f *Frame) Flags() FrameFlags { return FrameFlags(C.frameFlags(f.ptr)) }
+
+// ID returns the id of the frame, which is the id of its VideoMeta for multiview buffers.
+func (f *Frame) ID() int { return int(C.frameID(f.ptr)) }
+
+// Format returns the format of the frame.
+func (f *Frame) Format() Format { return Format(C.frameFormat(f.ptr)) }
+
+// Width returns the width of the frame in pixels.
+func (f *Frame) Width() int { return int(C.frameWidth(f.ptr)) }
+
+// Height returns the height of the frame in pixels.
+func (f *Frame) Height() int { return int(C.frameHeight(f.ptr)) }
+
+// NumPlanes returns the number of planes of the frame.
+func (f *Frame) NumPlanes() int { return int(C.frameNPlanes(f.ptr)) }
+
+// NumComponents returns the number of components of the frame.
+func (f *Frame) NumComponents() int { return int(C.frameNComponents(f.ptr)) }
+
+func (f *Frame) checkPlane(plane int) {
+	if plane < 0 || plane >= f.NumPlanes() {
+		panic(fmt.Sprintf("Plane %d out of range for frame with %d planes", plane, f.NumPlanes()))
+	}
+}
+
+func (f *Frame) checkComponent(comp int) {
+	if comp < 0 || comp >= f.NumComponents() {
+		panic(fmt.Sprintf("Component %d out of range for frame with %d components", comp, f.NumComponents()))
+	}
+}
+
+// PlaneData returns the bytes of the given plane, which are PlaneStride bytes per row.
+func (f *Frame) PlaneData(plane int) []byte {
+	f.checkPlane(plane)
+	size := int(C.framePlaneSize(f.ptr, C.gint(plane)))
+	return (*[maxFrameBytes]byte)(C.framePlaneData(f.ptr, C.gint(plane)))[:size:size]
+}
+
+// PlaneStride returns the number of bytes per row of the given plane.
+func (f *Frame) PlaneStride(plane int) int {
+	f.checkPlane(plane)
+	return int(C.framePlaneStride(f.ptr, C.gint(plane)))
+}
+
+// PlaneOffset returns the offset in bytes of the given plane in the buffer.
+func (f *Frame) PlaneOffset(plane int) int64 {
+	f.checkPlane(plane)
+	return int64(C.framePlaneOffset(f.ptr, C.gint(plane)))
+}
+
+// ComponentData returns the bytes of the plane of the given component, starting at its first value. Values
+// of the component are ComponentPixelStride bytes apart, and rows ComponentStride bytes apart.
+func (f *Frame) ComponentData(comp int) []byte {
+	f.checkComponent(comp)
+	plane := f.ComponentPlane(comp)
+	offset := int(C.frameCompOffset(f.ptr, C.gint(comp)))
+	return f.PlaneData(plane)[offset:]
+}
+
+// ComponentPlane returns the plane holding the given component.
+func (f *Frame) ComponentPlane(comp int) int {
+	f.checkComponent(comp)
+	return int(C.frameCompPlane(f.ptr, C.gint(comp)))
+}
+
+// ComponentStride returns the number of bytes per row of the given component.
+func (f *Frame) ComponentStride(comp int) int {
+	f.checkComponent(comp)
+	return int(C.frameCompStride(f.ptr, C.gint(comp)))
+}
+
+// ComponentPixelStride returns the number of bytes between two values of the given component in a row.
+func (f *Frame) ComponentPixelStride(comp int) int {
+	f.checkComponent(comp)
+	return int(C.frameCompPStride(f.ptr, C.gint(comp)))
+}
+
+// ComponentWidth returns the width of the given component, taking subsampling into account.
+func (f *Frame) ComponentWidth(comp int) int {
+	f.checkComponent(comp)
+	return int(C.frameCompWidth(f.ptr, C.gint(comp)))
+}
+
+// ComponentHeight returns the height of the given component, taking subsampling into account.
+func (f *Frame) ComponentHeight(comp int) int {
+	f.checkComponent(comp)
+	return int(C.frameCompHeight(f.ptr, C.gint(comp)))
+}
+
+// ComponentDepth returns the number of bits of the given component.
+func (f *Frame) ComponentDepth(comp int) int {
+	f.checkComponent(comp)
+	return int(C.frameCompDepth(f.ptr, C.gint(comp)))
+}
+
+// CopyTo copies the pixels of this frame into dest, which must be mapped for writing with the same
+// format and dimensions.
+func (f *Frame) CopyTo(dest *Frame) error {
+	if !gobool(C.gst_video_frame_copy(dest.ptr, f.ptr)) {
+		return errors.New("Failed to copy video frame")
+	}
+	return nil
+}
+
+// imageBytes returns the bytes of the given plane that are needed for an image of the frame's height,
+// i.e. without any padding after the last row.
+func (f *Frame) imageBytes(plane, rowBytes, height int) []byte {
+	data := f.PlaneData(plane)
+	if height == 0 {
+		return data[:0]
+	}
+	return data[:(height-1)*f.PlaneStride(plane)+rowBytes]
+}
+
+// convert returns a copy of the frame converted to the given format. With jfif set, the full range
+// BT.601 colorimetry expected by image.YCbCr is used.
+func (f *Frame) convert(format Format, jfif bool) (*Frame, error) {
+	buf := C.convertVideoFrame(f.ptr, C.GstVideoFormat(format), gboolean(jfif))
+	if buf == nil {
+		return nil, fmt.Errorf("Cannot convert %s frame to %s", f.Format(), format)
+	}
+	defer C.gst_buffer_unref(buf)
+	info := NewInfo().WithFormat(format, uint(f.Width()), uint(f.Height()))
+	return MapFrame(info, gst.FromGstBufferUnsafe(unsafe.Pointer(buf)), gst.MapRead)
+}
+
+// Image returns the frame as the image type best matching its format, without copying the pixels if
+// possible. Frames in FormatRGBA are returned as *image.NRGBA, or *image.RGBA if their alpha is
+// premultiplied, frames in FormatGray8 as *image.Gray, and frames in FormatI420, FormatYV12, FormatY41B,
+// FormatY42B and FormatY444 as *image.YCbCr. Frames in any other format are converted to an *image.NRGBA.
+func (f *Frame) Image() (image.Image, error) {
+	switch f.Format() {
+	case FormatRGBA:
+		if f.premultiplied() {
+			return f.RGBA()
+		}
+		return f.NRGBA()
+	case FormatGray8:
+		return f.Gray()
+	case FormatI420, FormatYV12, FormatY41B, FormatY42B, FormatY444:
+		return f.YCbCr()
+	}
+	return f.NRGBA()
+}
+
+func (f *Frame) premultiplied() bool {
+	return Flags(C.frameInfo(f.ptr).flags)&FlagPremultipliedAlpha != 0
+}
+
+// NRGBA returns the frame as an *image.NRGBA. Frames in FormatRGBA without premultiplied alpha are
+// returned without copying, frames in any other format are converted.
+func (f *Frame) NRGBA() (*image.NRGBA, error) {
+	if f.Format() == FormatRGBA && !f.premultiplied() {
+		return &image.NRGBA{
+			Pix:    f.imageBytes(0, f.Width()*4, f.Height()),
+			Stride: f.PlaneStride(0),
+			Rect:   image.Rect(0, 0, f.Width(), f.Height()),
+		}, nil
+	}
+	conv, err := f.convert(FormatRGBA, false)
+	if err != nil {
+		return nil, err
+	}
+	defer conv.Unmap()
+	img := image.NewNRGBA(image.Rect(0, 0, f.Width(), f.Height()))
+	copyRows(img.Pix, img.Stride, conv.PlaneData(0), conv.PlaneStride(0), f.Width()*4, f.Height())
+	return img, nil
+}
+
+// RGBA returns the frame as an *image.RGBA, whose colors are premultiplied by their alpha. Frames in
+// FormatRGBA with FlagPremultipliedAlpha are returned without copying, frames in any other format are
+// converted.
+func (f *Frame) RGBA() (*image.RGBA, error) {
+	if f.Format() == FormatRGBA && f.premultiplied() {
+		return &image.RGBA{
+			Pix:    f.imageBytes(0, f.Width()*4, f.Height()),
+			Stride: f.PlaneStride(0),
+			Rect:   image.Rect(0, 0, f.Width(), f.Height()),
+		}, nil
+	}
+	nrgba, err := f.NRGBA()
+	if err != nil {
+		return nil, err
+	}
+	img := image.NewRGBA(nrgba.Rect)
+	for y := 0; y < nrgba.Rect.Dy(); y++ {
+		src := nrgba.Pix[y*nrgba.Stride : y*nrgba.Stride+nrgba.Rect.Dx()*4]
+		dst := img.Pix[y*img.Stride : y*img.Stride+img.Rect.Dx()*4]
+		for x := 0; x < len(src); x += 4 {
+			a := uint32(src[x+3])
+			dst[x] = uint8(uint32(src[x]) * a / 0xff)
+			dst[x+1] = uint8(uint32(src[x+1]) * a / 0xff)
+			dst[x+2] = uint8(uint32(src[x+2]) * a / 0xff)
+			dst[x+3] = src[x+3]
+		}
+	}
+	return img, nil
+}
+
+// Gray returns the frame as an *image.Gray. Frames in FormatGray8 are returned without copying, frames in
+// any other format are converted.
+func (f *Frame) Gray() (*image.Gray, error) {
+	if f.Format() == FormatGray8 {
+		return &image.Gray{
+			Pix:    f.imageBytes(0, f.Width(), f.Height()),
+			Stride: f.PlaneStride(0),
+			Rect:   image.Rect(0, 0, f.Width(), f.Height()),
+		}, nil
+	}
+	conv, err := f.convert(FormatGray8, false)
+	if err != nil {
+		return nil, err
+	}
+	defer conv.Unmap()
+	img := image.NewGray(image.Rect(0, 0, f.Width(), f.Height()))
+	copyRows(img.Pix, img.Stride, conv.PlaneData(0), conv.PlaneStride(0), f.Width(), f.Height())
+	return img, nil
+}
+
+// YCbCr returns the frame as an *image.YCbCr, which holds full range BT.601 colors. Frames in FormatI420,
+// FormatYV12, FormatY41B, FormatY42B and FormatY444 with that colorimetry, and whose chroma planes share a
+// stride, are returned without copying. Any other frame, including the usual limited range video, is
+// converted to full range BT.601 FormatI420.
+func (f *Frame) YCbCr() (*image.YCbCr, error) {
+	if ratio, ok := subsampleRatio(f.Format()); ok && f.isJFIF() && f.ComponentStride(1) == f.ComponentStride(2) {
+		return &image.YCbCr{
+			Y:              f.imageBytes(f.ComponentPlane(0), f.ComponentWidth(0), f.ComponentHeight(0)),
+			Cb:             f.imageBytes(f.ComponentPlane(1), f.ComponentWidth(1), f.ComponentHeight(1)),
+			Cr:             f.imageBytes(f.ComponentPlane(2), f.ComponentWidth(2), f.ComponentHeight(2)),
+			YStride:        f.ComponentStride(0),
+			CStride:        f.ComponentStride(1),
+			SubsampleRatio: ratio,
+			Rect:           image.Rect(0, 0, f.Width(), f.Height()),
+		}, nil
+	}
+	conv, err := f.convert(FormatI420, true)
+	if err != nil {
+		return nil, err
+	}
+	defer conv.Unmap()
+	img := image.NewYCbCr(image.Rect(0, 0, f.Width(), f.Height()), image.YCbCrSubsampleRatio420)
+	copyRows(img.Y, img.YStride, conv.ComponentData(0), conv.ComponentStride(0), conv.ComponentWidth(0), conv.ComponentHeight(0))
+	copyRows(img.Cb, img.CStride, conv.ComponentData(1), conv.ComponentStride(1), conv.ComponentWidth(1), conv.ComponentHeight(1))
+	copyRows(img.Cr, img.CStride, conv.ComponentData(2), conv.ComponentStride(2), conv.ComponentWidth(2), conv.ComponentHeight(2))
+	return img, nil
+}
+
+// isJFIF returns true if the colorimetry of the frame is the full range BT.601 expected by image.YCbCr.
+func (f *Frame) isJFIF() bool {
+	colorimetry := f.Info().Colorimetry()
+	return colorimetry.Range == ColorRange0255 && colorimetry.Matrix == ColorMatrixBT601
+}
+
+func subsampleRatio(format Format) (image.YCbCrSubsampleRatio, bool) {
+	switch format {
+	case FormatI420, FormatYV12:
+		return image.YCbCrSubsampleRatio420, true
+	case FormatY41B:
+		return image.YCbCrSubsampleRatio411, true
+	case FormatY42B:
+		return image.YCbCrSubsampleRatio422, true
+	case FormatY444:
+		return image.YCbCrSubsampleRatio444, true
+	}
+	return 0, false
+}
+
+// copyRows copies height rows of rowBytes bytes from src to dst, with the given strides.
+func copyRows(dst []byte, dstStride int, src []byte, srcStride int, rowBytes, height int) {
+	for y := 0; y < height; y++ {
+		copy(dst[y*dstStride:y*dstStride+rowBytes], src[y*srcStride:y*srcStride+rowBytes])
+	}
+}
@@ -0,0 +1,58 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// mapI420Frame maps a 16x16 I420 frame with the given colorimetry, whose luma is set to 16 and chroma to 128.
+func mapI420Frame(t *testing.T, colorimetry string) *Frame {
+	t.Helper()
+	info := NewInfo().FromCaps(gst.NewCapsFromString("video/x-raw, format=I420, width=16, height=16, colorimetry=" + colorimetry))
+	data := make([]byte, info.Size())
+	for i := range data {
+		data[i] = 128
+	}
+	for i := 0; i < 16*16; i++ {
+		data[i] = 16
+	}
+	frame, err := MapFrame(info, gst.NewBufferFromBytes(data), gst.MapRead)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return frame
+}
+
+func TestFrameYCbCrSharesFullRange(t *testing.T) {
+	frame := mapI420Frame(t, "jpeg")
+	defer frame.Unmap()
+
+	img, err := frame.YCbCr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &img.Y[0] != &frame.ComponentData(0)[0] {
+		t.Error("Expected a full range frame to be returned without copying")
+	}
+	if img.Y[0] != 16 {
+		t.Errorf("Expected the luma to be kept, got %d", img.Y[0])
+	}
+}
+
+func TestFrameYCbCrConvertsLimitedRange(t *testing.T) {
+	frame := mapI420Frame(t, "bt601")
+	defer frame.Unmap()
+
+	img, err := frame.YCbCr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &img.Y[0] == &frame.ComponentData(0)[0] {
+		t.Fatal("Expected a limited range frame to be converted")
+	}
+	// limited range black is full range black
+	if img.Y[0] > 1 {
+		t.Errorf("Expected the luma to be expanded to full range, got %d", img.Y[0])
+	}
+}
@@ -177,6 +177,17 @@ func NewInfo() *Info {
 	return wrapInfo(C.gst_video_info_new())
 }
 
+// FromGstVideoInfoUnsafe returns a copy of the given C GstVideoInfo in the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstVideoInfoUnsafe(info unsafe.Pointer) *Info {
+	return wrapInfo(C.gst_video_info_copy((*C.GstVideoInfo)(info)))
+}
+
+// Copy returns a copy of this info.
+func (i *Info) Copy() *Info {
+	return wrapInfo(C.gst_video_info_copy(i.instance()))
+}
+
 // FromCaps parses the caps and updates this info.
 func (i *Info) FromCaps(caps *gst.Caps) *Info {
 	C.gst_video_info_from_caps(i.instance(), fromCoreCaps(caps))
@@ -382,5 +393,5 @@ func (i *Info) WithPAR(f gst.GFraction) *Info {
 // ToCaps returns the caps representation of this video info.
 func (i *Info) ToCaps() *gst.Caps {
 	caps := C.gst_video_info_to_caps(i.instance())
-	return gst.FromGstCapsUnsafe(unsafe.Pointer(caps))
+	return gst.FromGstCapsUnsafeFull(unsafe.Pointer(caps))
 }
@@ -0,0 +1,431 @@
+package video
+
+/*
+#include "gst.go.h"
+
+GstVideoOverlayCompositionMeta * getOverlayCompositionMeta (GstBuffer * buffer)
+{
+	return gst_buffer_get_video_overlay_composition_meta(buffer);
+}
+
+gboolean overlayCompositionIsWritable (GstVideoOverlayComposition * comp)
+{
+	return gst_mini_object_is_writable(GST_MINI_OBJECT_CAST(comp));
+}
+
+gint overlayPixelsStride (GstBuffer * pixels)
+{
+	GstVideoMeta * meta = gst_buffer_get_video_meta(pixels);
+	return meta == NULL ? 0 : meta->stride[0];
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"runtime"
+	"unsafe"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+// OverlayFormatFlags are flags describing the pixels of an OverlayRectangle.
+type OverlayFormatFlags int
+
+// Type castings
+const (
+	OverlayFormatFlagNone               OverlayFormatFlags = C.GST_VIDEO_OVERLAY_FORMAT_FLAG_NONE                // (0) – no flags
+	OverlayFormatFlagPremultipliedAlpha OverlayFormatFlags = C.GST_VIDEO_OVERLAY_FORMAT_FLAG_PREMULTIPLIED_ALPHA // (1) – RGB are premultiplied by A/255.
+	OverlayFormatFlagGlobalAlpha        OverlayFormatFlags = C.GST_VIDEO_OVERLAY_FORMAT_FLAG_GLOBAL_ALPHA        // (2) – a global-alpha value != 1 is set.
+)
+
+// The formats of the pixels of an OverlayRectangle.
+const (
+	OverlayFormatRGB Format = C.GST_VIDEO_OVERLAY_COMPOSITION_FORMAT_RGB // ARGB in native endianness, i.e. FormatBGRA on little endian and FormatARGB on big endian systems
+	OverlayFormatYUV Format = C.GST_VIDEO_OVERLAY_COMPOSITION_FORMAT_YUV // FormatAYUV
+)
+
+// OverlayRectangle is a go representation of a GstVideoOverlayRectangle. It holds the pixels of an
+// overlay, e.g. a logo or a subtitle, along with the rectangle of the video it should be rendered to.
+// The pixels are scaled to the size of the render rectangle when blending.
+type OverlayRectangle struct {
+	ptr *C.GstVideoOverlayRectangle
+}
+
+func wrapOverlayRectangle(rect *C.GstVideoOverlayRectangle) *OverlayRectangle {
+	if rect == nil {
+		return nil
+	}
+	return takeOverlayRectangle(C.gst_video_overlay_rectangle_ref(rect))
+}
+
+func takeOverlayRectangle(rect *C.GstVideoOverlayRectangle) *OverlayRectangle {
+	if rect == nil {
+		return nil
+	}
+	r := &OverlayRectangle{ptr: rect}
+	runtime.SetFinalizer(r, func(r *OverlayRectangle) { C.gst_video_overlay_rectangle_unref(r.ptr) })
+	return r
+}
+
+// NewOverlayRectangle creates a new OverlayRectangle from the pixels in the given buffer, which are
+// width by height pixels of OverlayFormatRGB or OverlayFormatYUV. If the buffer does not have a VideoMeta
+// describing the pixels, one is added, which requires the buffer to be writable. An error is returned if
+// the buffer already has a VideoMeta with another format or size. The pixels are rendered to the given
+// rectangle of the video.
+func NewOverlayRectangle(pixels *gst.Buffer, format Format, width, height uint, render image.Rectangle, flags OverlayFormatFlags) (*OverlayRectangle, error) {
+	if format != OverlayFormatRGB && format != OverlayFormatYUV {
+		return nil, fmt.Errorf("Overlay rectangles cannot hold pixels of format %s", format)
+	}
+	if render.Empty() || width == 0 || height == 0 {
+		return nil, errors.New("Overlay rectangles cannot be empty")
+	}
+	cPixels := (*C.GstBuffer)(unsafe.Pointer(pixels.Instance()))
+	if meta := C.gst_buffer_get_video_meta(cPixels); meta != nil {
+		if Format(meta.format) != format || uint(meta.width) != width || uint(meta.height) != height {
+			return nil, fmt.Errorf("The video meta of the buffer describes %dx%d pixels of format %s, expected %dx%d pixels of format %s",
+				uint(meta.width), uint(meta.height), Format(meta.format), width, height, format)
+		}
+	} else {
+		if int64(C.gst_buffer_get_size(cPixels)) < int64(width*height*4) {
+			return nil, errors.New("The buffer is too small for the given size")
+		}
+		if !pixels.IsWritable() {
+			return nil, errors.New("Cannot add a video meta to a buffer that is not writable")
+		}
+		C.gst_buffer_add_video_meta(cPixels, C.GST_VIDEO_FRAME_FLAG_NONE, C.GstVideoFormat(format), C.guint(width), C.guint(height))
+	}
+	rect := C.gst_video_overlay_rectangle_new_raw(
+		cPixels,
+		C.gint(render.Min.X), C.gint(render.Min.Y),
+		C.guint(render.Dx()), C.guint(render.Dy()),
+		C.GstVideoOverlayFormatFlags(flags),
+	)
+	if rect == nil {
+		return nil, errors.New("Failed to create overlay rectangle")
+	}
+	return takeOverlayRectangle(rect), nil
+}
+
+// NewOverlayRectangleFromImage creates a new OverlayRectangle holding the pixels of the given image,
+// which are rendered to the given rectangle of the video. The pixels of an *image.RGBA are used with
+// premultiplied alpha, the ones of any other image are converted to non-premultiplied colors.
+func NewOverlayRectangleFromImage(img image.Image, render image.Rectangle) (*OverlayRectangle, error) {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil, errors.New("Overlay rectangles cannot be empty")
+	}
+	width, height := bounds.Dx(), bounds.Dy()
+	data := make([]byte, width*height*4)
+	flags := OverlayFormatFlagNone
+	switch src := img.(type) {
+	case *image.RGBA:
+		flags = OverlayFormatFlagPremultipliedAlpha
+		for y := 0; y < height; y++ {
+			row := src.Pix[src.PixOffset(bounds.Min.X, bounds.Min.Y+y):]
+			for x := 0; x < width; x++ {
+				setOverlayPixel(data[(y*width+x)*4:], row[x*4], row[x*4+1], row[x*4+2], row[x*4+3])
+			}
+		}
+	case *image.NRGBA:
+		for y := 0; y < height; y++ {
+			row := src.Pix[src.PixOffset(bounds.Min.X, bounds.Min.Y+y):]
+			for x := 0; x < width; x++ {
+				setOverlayPixel(data[(y*width+x)*4:], row[x*4], row[x*4+1], row[x*4+2], row[x*4+3])
+			}
+		}
+	default:
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+				setOverlayPixel(data[(y*width+x)*4:], c.R, c.G, c.B, c.A)
+			}
+		}
+	}
+	// the rectangle keeps its own reference to the pixels
+	pixels := gst.NewBufferFromBytes(data)
+	defer pixels.Unref()
+	return NewOverlayRectangle(pixels, OverlayFormatRGB, uint(width), uint(height), render, flags)
+}
+
+// setOverlayPixel writes the given color to pix in OverlayFormatRGB.
+func setOverlayPixel(pix []byte, r, g, b, a uint8) {
+	if OverlayFormatRGB == FormatBGRA {
+		pix[0], pix[1], pix[2], pix[3] = b, g, r, a
+		return
+	}
+	pix[0], pix[1], pix[2], pix[3] = a, r, g, b
+}
+
+// Instance returns the underlying GstVideoOverlayRectangle instance.
+func (r *OverlayRectangle) Instance() *C.GstVideoOverlayRectangle { return r.ptr }
+
+// Copy returns a copy of the rectangle, which can be modified without affecting compositions holding
+// the original.
+func (r *OverlayRectangle) Copy() *OverlayRectangle {
+	return takeOverlayRectangle(C.gst_video_overlay_rectangle_copy(r.ptr))
+}
+
+// Seqnum returns the sequence number of the rectangle, which changes whenever it is modified.
+func (r *OverlayRectangle) Seqnum() uint {
+	return uint(C.gst_video_overlay_rectangle_get_seqnum(r.ptr))
+}
+
+// RenderRectangle returns the rectangle of the video the pixels are rendered to.
+func (r *OverlayRectangle) RenderRectangle() image.Rectangle {
+	var x, y C.gint
+	var width, height C.guint
+	C.gst_video_overlay_rectangle_get_render_rectangle(r.ptr, &x, &y, &width, &height)
+	return image.Rect(int(x), int(y), int(x)+int(width), int(y)+int(height))
+}
+
+// SetRenderRectangle sets the rectangle of the video the pixels are rendered to. The rectangle must not
+// be part of a composition that is attached to a buffer.
+func (r *OverlayRectangle) SetRenderRectangle(render image.Rectangle) {
+	C.gst_video_overlay_rectangle_set_render_rectangle(
+		r.ptr,
+		C.gint(render.Min.X), C.gint(render.Min.Y),
+		C.guint(render.Dx()), C.guint(render.Dy()),
+	)
+}
+
+// Flags returns the flags describing the pixels of the rectangle.
+func (r *OverlayRectangle) Flags() OverlayFormatFlags {
+	return OverlayFormatFlags(C.gst_video_overlay_rectangle_get_flags(r.ptr))
+}
+
+// GlobalAlpha returns the alpha, from 0.0 to 1.0, applied to all pixels of the rectangle.
+func (r *OverlayRectangle) GlobalAlpha() float32 {
+	return float32(C.gst_video_overlay_rectangle_get_global_alpha(r.ptr))
+}
+
+// SetGlobalAlpha sets the alpha, from 0.0 to 1.0, applied to all pixels of the rectangle. The rectangle
+// must not be part of a composition that is attached to a buffer.
+func (r *OverlayRectangle) SetGlobalAlpha(alpha float32) {
+	C.gst_video_overlay_rectangle_set_global_alpha(r.ptr, C.gfloat(alpha))
+}
+
+// PixelsRaw returns the pixels of the rectangle, scaled to the size of the render rectangle, in the
+// format they were created with. flags tells whether the returned pixels should have premultiplied alpha
+// and the global alpha applied. The buffer has a VideoMeta describing them.
+func (r *OverlayRectangle) PixelsRaw(flags OverlayFormatFlags) *gst.Buffer {
+	return wrapOverlayPixels(C.gst_video_overlay_rectangle_get_pixels_raw(r.ptr, C.GstVideoOverlayFormatFlags(flags)))
+}
+
+// PixelsARGB is like PixelsRaw, but returns the pixels in OverlayFormatRGB.
+func (r *OverlayRectangle) PixelsARGB(flags OverlayFormatFlags) *gst.Buffer {
+	return wrapOverlayPixels(C.gst_video_overlay_rectangle_get_pixels_argb(r.ptr, C.GstVideoOverlayFormatFlags(flags)))
+}
+
+// PixelsAYUV is like PixelsRaw, but returns the pixels in OverlayFormatYUV.
+func (r *OverlayRectangle) PixelsAYUV(flags OverlayFormatFlags) *gst.Buffer {
+	return wrapOverlayPixels(C.gst_video_overlay_rectangle_get_pixels_ayuv(r.ptr, C.GstVideoOverlayFormatFlags(flags)))
+}
+
+// PixelsUnscaledRaw is like PixelsRaw, but returns the pixels in the size they were created with.
+func (r *OverlayRectangle) PixelsUnscaledRaw(flags OverlayFormatFlags) *gst.Buffer {
+	return wrapOverlayPixels(C.gst_video_overlay_rectangle_get_pixels_unscaled_raw(r.ptr, C.GstVideoOverlayFormatFlags(flags)))
+}
+
+// PixelsUnscaledARGB is like PixelsARGB, but returns the pixels in the size they were created with.
+func (r *OverlayRectangle) PixelsUnscaledARGB(flags OverlayFormatFlags) *gst.Buffer {
+	return wrapOverlayPixels(C.gst_video_overlay_rectangle_get_pixels_unscaled_argb(r.ptr, C.GstVideoOverlayFormatFlags(flags)))
+}
+
+// PixelsUnscaledAYUV is like PixelsAYUV, but returns the pixels in the size they were created with.
+func (r *OverlayRectangle) PixelsUnscaledAYUV(flags OverlayFormatFlags) *gst.Buffer {
+	return wrapOverlayPixels(C.gst_video_overlay_rectangle_get_pixels_unscaled_ayuv(r.ptr, C.GstVideoOverlayFormatFlags(flags)))
+}
+
+func wrapOverlayPixels(pixels *C.GstBuffer) *gst.Buffer {
+	if pixels == nil {
+		return nil
+	}
+	return gst.FromGstBufferUnsafe(unsafe.Pointer(pixels))
+}
+
+// Image returns a copy of the pixels of the rectangle, in the size they were created with.
+func (r *OverlayRectangle) Image() (*image.NRGBA, error) {
+	pixels := C.gst_video_overlay_rectangle_get_pixels_unscaled_argb(r.ptr, C.GST_VIDEO_OVERLAY_FORMAT_FLAG_NONE)
+	if pixels == nil {
+		return nil, errors.New("Failed to get the pixels of the overlay rectangle")
+	}
+	meta := C.gst_buffer_get_video_meta(pixels)
+	if meta == nil {
+		return nil, errors.New("The pixels of the overlay rectangle have no video meta")
+	}
+	width, height := int(meta.width), int(meta.height)
+	stride := int(C.overlayPixelsStride(pixels))
+	var mapInfo C.GstMapInfo
+	if !gobool(C.gst_buffer_map(pixels, &mapInfo, C.GST_MAP_READ)) {
+		return nil, errors.New("Failed to map the pixels of the overlay rectangle")
+	}
+	defer C.gst_buffer_unmap(pixels, &mapInfo)
+	data := C.GoBytes(unsafe.Pointer(mapInfo.data), C.int(mapInfo.size))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pix := data[y*stride+x*4:]
+			dst := img.Pix[y*img.Stride+x*4:]
+			if OverlayFormatRGB == FormatBGRA {
+				dst[0], dst[1], dst[2], dst[3] = pix[2], pix[1], pix[0], pix[3]
+			} else {
+				dst[0], dst[1], dst[2], dst[3] = pix[1], pix[2], pix[3], pix[0]
+			}
+		}
+	}
+	return img, nil
+}
+
+// OverlayComposition is a go representation of a GstVideoOverlayComposition. It holds the
+// OverlayRectangles to render onto a frame. It can either be blended into the frame directly, or attached
+// to its buffer with AddOverlayCompositionMeta for a downstream element, e.g. a sink, to render it.
+//
+//   logo, err := video.NewOverlayRectangleFromImage(img, image.Rect(16, 16, 144, 80))
+//   if err != nil {
+//       panic(err)
+//   }
+//   logo.SetGlobalAlpha(0.8)
+//
+//   composition, err := video.NewOverlayComposition(logo)
+//   if err != nil {
+//       panic(err)
+//   }
+//
+//   frame, err := video.MapFrame(info, buffer, gst.MapRead|gst.MapWrite)
+//   if err != nil {
+//       panic(err)
+//   }
+//   defer frame.Unmap()
+//   if err := composition.Blend(frame); err != nil {
+//       panic(err)
+//   }
+type OverlayComposition struct {
+	ptr *C.GstVideoOverlayComposition
+}
+
+func wrapOverlayComposition(comp *C.GstVideoOverlayComposition) *OverlayComposition {
+	if comp == nil {
+		return nil
+	}
+	return takeOverlayComposition(C.gst_video_overlay_composition_ref(comp))
+}
+
+func takeOverlayComposition(comp *C.GstVideoOverlayComposition) *OverlayComposition {
+	if comp == nil {
+		return nil
+	}
+	c := &OverlayComposition{ptr: comp}
+	runtime.SetFinalizer(c, func(c *OverlayComposition) { C.gst_video_overlay_composition_unref(c.ptr) })
+	return c
+}
+
+// NewOverlayComposition creates a new OverlayComposition holding the given rectangles. At least one
+// rectangle must be given.
+func NewOverlayComposition(rects ...*OverlayRectangle) (*OverlayComposition, error) {
+	if len(rects) == 0 {
+		return nil, errors.New("An overlay composition needs at least one rectangle")
+	}
+	comp := takeOverlayComposition(C.gst_video_overlay_composition_new(rects[0].ptr))
+	for _, rect := range rects[1:] {
+		if err := comp.AddRectangle(rect); err != nil {
+			return nil, err
+		}
+	}
+	return comp, nil
+}
+
+// Instance returns the underlying GstVideoOverlayComposition instance.
+func (c *OverlayComposition) Instance() *C.GstVideoOverlayComposition { return c.ptr }
+
+// AddRectangle adds the given rectangle to the composition. Compositions can only be modified while
+// they are not shared, e.g. attached to a buffer. Use Copy to get a composition that can be modified.
+func (c *OverlayComposition) AddRectangle(rect *OverlayRectangle) error {
+	if !gobool(C.overlayCompositionIsWritable(c.ptr)) {
+		return errors.New("Cannot add a rectangle to a shared overlay composition")
+	}
+	C.gst_video_overlay_composition_add_rectangle(c.ptr, rect.ptr)
+	return nil
+}
+
+// NumRectangles returns the number of rectangles in the composition.
+func (c *OverlayComposition) NumRectangles() uint {
+	return uint(C.gst_video_overlay_composition_n_rectangles(c.ptr))
+}
+
+// GetRectangle returns the rectangle at the given index, or nil if it is out of range.
+func (c *OverlayComposition) GetRectangle(idx uint) *OverlayRectangle {
+	return wrapOverlayRectangle(C.gst_video_overlay_composition_get_rectangle(c.ptr, C.guint(idx)))
+}
+
+// Rectangles returns all the rectangles in the composition.
+func (c *OverlayComposition) Rectangles() []*OverlayRectangle {
+	out := make([]*OverlayRectangle, c.NumRectangles())
+	for i := range out {
+		out[i] = c.GetRectangle(uint(i))
+	}
+	return out
+}
+
+// Seqnum returns the sequence number of the composition, which changes whenever it is modified.
+func (c *OverlayComposition) Seqnum() uint {
+	return uint(C.gst_video_overlay_composition_get_seqnum(c.ptr))
+}
+
+// Copy returns a copy of the composition holding copies of its rectangles, which can be modified.
+func (c *OverlayComposition) Copy() *OverlayComposition {
+	return takeOverlayComposition(C.gst_video_overlay_composition_copy(c.ptr))
+}
+
+// Blend blends the rectangles of the composition into the given frame, which must be mapped for reading
+// and writing.
+func (c *OverlayComposition) Blend(frame *Frame) error {
+	if !gobool(C.gst_video_overlay_composition_blend(c.ptr, frame.ptr)) {
+		return errors.New("Failed to blend the overlay composition into the frame")
+	}
+	return nil
+}
+
+// OverlayCompositionMeta is a go representation of a GstVideoOverlayCompositionMeta. It attaches an
+// OverlayComposition to a buffer, for downstream elements supporting it to render.
+type OverlayCompositionMeta struct {
+	ptr *C.GstVideoOverlayCompositionMeta
+}
+
+// AddOverlayCompositionMeta attaches the composition to the buffer, which must be writable. The
+// composition should not be modified afterwards.
+func AddOverlayCompositionMeta(buffer *gst.Buffer, comp *OverlayComposition) (*OverlayCompositionMeta, error) {
+	meta := C.gst_buffer_add_video_overlay_composition_meta(
+		(*C.GstBuffer)(unsafe.Pointer(buffer.Instance())),
+		comp.ptr,
+	)
+	if meta == nil {
+		return nil, errors.New("Failed to add overlay composition meta")
+	}
+	return &OverlayCompositionMeta{ptr: meta}, nil
+}
+
+// GetOverlayCompositionMeta returns the OverlayCompositionMeta on the buffer, or nil if it has none.
+func GetOverlayCompositionMeta(buffer *gst.Buffer) *OverlayCompositionMeta {
+	meta := C.getOverlayCompositionMeta((*C.GstBuffer)(unsafe.Pointer(buffer.Instance())))
+	if meta == nil {
+		return nil
+	}
+	return &OverlayCompositionMeta{ptr: meta}
+}
+
+// Instance returns the underlying GstVideoOverlayCompositionMeta instance.
+func (m *OverlayCompositionMeta) Instance() *C.GstVideoOverlayCompositionMeta { return m.ptr }
+
+// Meta returns the parent Meta instance, e.g. for removing it from the buffer.
+func (m *OverlayCompositionMeta) Meta() *gst.Meta {
+	return gst.FromGstMetaUnsafe(unsafe.Pointer(&m.ptr.meta))
+}
+
+// Overlay returns the composition attached to the buffer.
+func (m *OverlayCompositionMeta) Overlay() *OverlayComposition {
+	return wrapOverlayComposition(m.ptr.overlay)
+}
@@ -0,0 +1,44 @@
+package video
+
+import (
+	"image"
+	"testing"
+
+	"github.com/tinyzimmer/go-gst/gst"
+)
+
+func TestNewOverlayRectangleChecksVideoMeta(t *testing.T) {
+	pixels := gst.NewBufferFromBytes(make([]byte, 4*4*4))
+	render := image.Rect(0, 0, 4, 4)
+
+	// the first rectangle adds a video meta describing the pixels
+	if _, err := NewOverlayRectangle(pixels, OverlayFormatRGB, 4, 4, render, OverlayFormatFlagNone); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewOverlayRectangle(pixels, OverlayFormatRGB, 4, 4, render, OverlayFormatFlagNone); err != nil {
+		t.Errorf("Expected the video meta to be reused, got %s", err)
+	}
+	if _, err := NewOverlayRectangle(pixels, OverlayFormatRGB, 2, 8, render, OverlayFormatFlagNone); err == nil {
+		t.Error("Expected an error for a size not matching the video meta")
+	}
+	if _, err := NewOverlayRectangle(pixels, OverlayFormatYUV, 4, 4, render, OverlayFormatFlagNone); err == nil {
+		t.Error("Expected an error for a format not matching the video meta")
+	}
+}
+
+func TestNewOverlayRectangleFromImageOwnsPixels(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	rect, err := NewOverlayRectangleFromImage(img, image.Rect(0, 0, 2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the unscaled pixels are the buffer the rectangle was created with, which must only be
+	// referenced by the rectangle
+	pixels := rect.PixelsUnscaledRaw(OverlayFormatFlagNone)
+	if pixels == nil {
+		t.Fatal("Expected the rectangle to hold pixels")
+	}
+	if !pixels.IsWritable() {
+		t.Error("Expected the rectangle to hold the only reference to its pixels")
+	}
+}
@@ -0,0 +1,345 @@
+package video
+
+/*
+#include <stdlib.h>
+#include <string.h>
+#include "gst.go.h"
+
+void setVideoConfigDouble (GstStructure * config, const gchar * name, gdouble value)
+{
+	gst_structure_set(config, name, G_TYPE_DOUBLE, value, NULL);
+}
+
+void setVideoConfigInt (GstStructure * config, const gchar * name, gint value)
+{
+	gst_structure_set(config, name, G_TYPE_INT, value, NULL);
+}
+
+// scalerPixelBytes returns the number of bytes taken by a pixel in the lines handled by a scaler of format.
+gint scalerPixelBytes (GstVideoFormat format)
+{
+	const GstVideoFormatInfo * finfo;
+
+	switch (format) {
+	case GST_VIDEO_FORMAT_YUY2:
+	case GST_VIDEO_FORMAT_YVYU:
+	case GST_VIDEO_FORMAT_UYVY:
+	case GST_VIDEO_FORMAT_NV12:
+	case GST_VIDEO_FORMAT_NV21:
+	case GST_VIDEO_FORMAT_NV16:
+	case GST_VIDEO_FORMAT_NV61:
+	case GST_VIDEO_FORMAT_NV24:
+		return 2;
+	default:
+		finfo = gst_video_format_get_info(format);
+		return finfo == NULL ? 0 : GST_VIDEO_FORMAT_INFO_PSTRIDE(finfo, 0);
+	}
+}
+
+gpointer * allocScalerLines (guint n)                             { return g_new0(gpointer, n); }
+void       setScalerLine    (gpointer * lines, guint i, gpointer line) { lines[i] = line; }
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// ResamplerMethod is the method used by a Scaler or Converter to resample pixels.
+type ResamplerMethod int
+
+// Type castings
+const (
+	ResamplerMethodNearest ResamplerMethod = C.GST_VIDEO_RESAMPLER_METHOD_NEAREST // (0) – Duplicates the samples when upsampling and drops when downsampling
+	ResamplerMethodLinear  ResamplerMethod = C.GST_VIDEO_RESAMPLER_METHOD_LINEAR  // (1) – Uses linear interpolation to reconstruct missing samples and averaging to downsample
+	ResamplerMethodCubic   ResamplerMethod = C.GST_VIDEO_RESAMPLER_METHOD_CUBIC   // (2) – Uses cubic interpolation
+	ResamplerMethodSinc    ResamplerMethod = C.GST_VIDEO_RESAMPLER_METHOD_SINC    // (3) – Uses sinc interpolation
+	ResamplerMethodLanczos ResamplerMethod = C.GST_VIDEO_RESAMPLER_METHOD_LANCZOS // (4) – Uses lanczos interpolation
+)
+
+// ScalerFlags are extra flags passed to NewScaler.
+type ScalerFlags int
+
+// Type castings
+const (
+	ScalerFlagNone       ScalerFlags = C.GST_VIDEO_SCALER_FLAG_NONE       // (0) – no flags
+	ScalerFlagInterlaced ScalerFlags = C.GST_VIDEO_SCALER_FLAG_INTERLACED // (1) – Set up a scaler for interlaced content
+)
+
+// ScalerConfig holds the options of the resampler used by a Scaler. Options that are not set keep their
+// defaults. It can be built by chaining the setters to NewScalerConfig.
+type ScalerConfig struct {
+	cubicB    *float64
+	cubicC    *float64
+	envelope  *float64
+	sharpness *float64
+	sharpen   *float64
+	maxTaps   *int
+}
+
+// NewScalerConfig returns a new empty ScalerConfig.
+func NewScalerConfig() *ScalerConfig { return &ScalerConfig{} }
+
+// WithCubicB sets the B parameter of ResamplerMethodCubic, from 0.0 to 1.0. The default is 1/3.
+func (c *ScalerConfig) WithCubicB(b float64) *ScalerConfig {
+	c.cubicB = &b
+	return c
+}
+
+// WithCubicC sets the C parameter of ResamplerMethodCubic, from 0.0 to 1.0. The default is 1/3.
+func (c *ScalerConfig) WithCubicC(cc float64) *ScalerConfig {
+	c.cubicC = &cc
+	return c
+}
+
+// WithEnvelope sets the size of the filter envelope of ResamplerMethodLanczos, from 1.0 to 5.0. The
+// default is 2.0.
+func (c *ScalerConfig) WithEnvelope(envelope float64) *ScalerConfig {
+	c.envelope = &envelope
+	return c
+}
+
+// WithSharpness sets the sharpness of the filter of ResamplerMethodLanczos, from 0.5 to 1.5. The default
+// is 1.0.
+func (c *ScalerConfig) WithSharpness(sharpness float64) *ScalerConfig {
+	c.sharpness = &sharpness
+	return c
+}
+
+// WithSharpen sets how much ResamplerMethodLanczos sharpens the output, from 0.0 to 1.0. The default is
+// 0.0.
+func (c *ScalerConfig) WithSharpen(sharpen float64) *ScalerConfig {
+	c.sharpen = &sharpen
+	return c
+}
+
+// WithMaxTaps sets the maximum number of taps of the filter, from 1 to 128. The default is 128.
+func (c *ScalerConfig) WithMaxTaps(taps int) *ScalerConfig {
+	c.maxTaps = &taps
+	return c
+}
+
+// toStructure returns a new GstStructure holding the options, or nil if c is nil.
+func (c *ScalerConfig) toStructure() *C.GstStructure {
+	if c == nil {
+		return nil
+	}
+	name := C.CString("GstVideoScaler")
+	defer C.free(unsafe.Pointer(name))
+	config := C.gst_structure_new_empty(name)
+	for opt, val := range map[string]*float64{
+		C.GST_VIDEO_RESAMPLER_OPT_CUBIC_B:   c.cubicB,
+		C.GST_VIDEO_RESAMPLER_OPT_CUBIC_C:   c.cubicC,
+		C.GST_VIDEO_RESAMPLER_OPT_ENVELOPE:  c.envelope,
+		C.GST_VIDEO_RESAMPLER_OPT_SHARPNESS: c.sharpness,
+		C.GST_VIDEO_RESAMPLER_OPT_SHARPEN:   c.sharpen,
+	} {
+		if val != nil {
+			setVideoConfigDouble(config, opt, *val)
+		}
+	}
+	if c.maxTaps != nil {
+		setVideoConfigInt(config, C.GST_VIDEO_RESAMPLER_OPT_MAX_TAPS, *c.maxTaps)
+	}
+	return config
+}
+
+func setVideoConfigDouble(config *C.GstStructure, name string, value float64) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.setVideoConfigDouble(config, cName, C.gdouble(value))
+}
+
+func setVideoConfigInt(config *C.GstStructure, name string, value int) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.setVideoConfigInt(config, cName, C.gint(value))
+}
+
+// Scaler is a go representation of a GstVideoScaler. It scales lines of pixels in one direction, from
+// inSize to outSize pixels. Two scalers, one for each direction, can be combined with Scale2D to scale a
+// whole plane.
+//
+// Scalers work on packed formats, e.g. FormatGray8, FormatRGBA, FormatAYUV or FormatYUY2, and on the
+// interleaved chroma plane of the NV formats, e.g. FormatNV12. Use a Converter to scale whole frames of
+// any format.
+type Scaler struct {
+	ptr     *C.GstVideoScaler
+	inSize  uint
+	outSize uint
+}
+
+// NewScaler creates a new Scaler with the given method and flags, scaling from inSize to outSize pixels.
+// taps may be 0 to use the default number of taps of the method. config may be nil to use the defaults.
+func NewScaler(method ResamplerMethod, flags ScalerFlags, taps, inSize, outSize uint, config *ScalerConfig) (*Scaler, error) {
+	cConfig := config.toStructure()
+	if cConfig != nil {
+		defer C.gst_structure_free(cConfig)
+	}
+	scale := C.gst_video_scaler_new(
+		C.GstVideoResamplerMethod(method),
+		C.GstVideoScalerFlags(flags),
+		C.guint(taps),
+		C.guint(inSize),
+		C.guint(outSize),
+		cConfig,
+	)
+	if scale == nil {
+		return nil, errors.New("Could not create a scaler for the given parameters")
+	}
+	scaler := &Scaler{ptr: scale, inSize: inSize, outSize: outSize}
+	runtime.SetFinalizer(scaler, (*Scaler).Free)
+	return scaler, nil
+}
+
+// Free frees the resources of the scaler. It cannot be used afterwards.
+func (s *Scaler) Free() {
+	if s.ptr == nil {
+		return
+	}
+	C.gst_video_scaler_free(s.ptr)
+	s.ptr = nil
+}
+
+// InSize returns the number of input pixels of the scaler.
+func (s *Scaler) InSize() uint { return s.inSize }
+
+// OutSize returns the number of output pixels of the scaler.
+func (s *Scaler) OutSize() uint { return s.outSize }
+
+// GetMaxTaps returns the maximum number of taps, i.e. input pixels, used to compute an output pixel.
+func (s *Scaler) GetMaxTaps() uint { return uint(C.gst_video_scaler_get_max_taps(s.ptr)) }
+
+// GetCoeff returns the coefficients used to compute the output pixel at outOffset, and the offset of the
+// first input pixel they apply to. For vertical scaling, these are the lines to pass to Vertical.
+func (s *Scaler) GetCoeff(outOffset uint) (coeffs []float64, inOffset uint) {
+	var cInOffset, nTaps C.guint
+	ptr := C.gst_video_scaler_get_coeff(s.ptr, C.guint(outOffset), &cInOffset, &nTaps)
+	if ptr == nil {
+		return nil, 0
+	}
+	coeffs = make([]float64, int(nTaps))
+	copy(coeffs, (*[1 << 16]float64)(unsafe.Pointer(ptr))[:int(nTaps):int(nTaps)])
+	return coeffs, uint(cInOffset)
+}
+
+func pixelBytes(format Format) (int, error) {
+	size := int(C.scalerPixelBytes(C.GstVideoFormat(format)))
+	if size == 0 {
+		return 0, fmt.Errorf("Format %s cannot be scaled", format)
+	}
+	return size, nil
+}
+
+// Horizontal scales the line of InSize pixels in src and writes width pixels of the result to the output
+// line in dest, starting at pixel destOffset. dest holds the output line from its first pixel, so it must
+// be at least destOffset+width pixels long, and destOffset+width cannot exceed OutSize.
+func (s *Scaler) Horizontal(format Format, src, dest []byte, destOffset, width uint) error {
+	size, err := pixelBytes(format)
+	if err != nil {
+		return err
+	}
+	if width == 0 || destOffset+width > s.outSize {
+		return fmt.Errorf("Cannot write %d pixels at offset %d of an output line of %d pixels", width, destOffset, s.outSize)
+	}
+	if len(src) < int(s.inSize)*size || len(dest) < int(destOffset+width)*size {
+		return errors.New("The lines are too short for the scaler")
+	}
+	C.gst_video_scaler_horizontal(
+		s.ptr,
+		C.GstVideoFormat(format),
+		(C.gpointer)(unsafe.Pointer(&src[0])),
+		(C.gpointer)(unsafe.Pointer(&dest[0])),
+		C.guint(destOffset),
+		C.guint(width),
+	)
+	return nil
+}
+
+// Vertical combines the given lines of width pixels into the output line at destOffset and writes it to
+// dest. srcLines must hold the lines starting at the input offset returned by GetCoeff for destOffset,
+// one for every tap. destOffset must be lower than OutSize.
+func (s *Scaler) Vertical(format Format, srcLines [][]byte, dest []byte, destOffset, width uint) error {
+	size, err := pixelBytes(format)
+	if err != nil {
+		return err
+	}
+	if destOffset >= s.outSize {
+		return fmt.Errorf("Output line %d is out of the %d lines of the scaler", destOffset, s.outSize)
+	}
+	lineSize := int(width) * size
+	if len(dest) < lineSize || width == 0 {
+		return errors.New("The output line is too short for the scaler")
+	}
+	if len(srcLines) < int(s.GetMaxTaps()) {
+		return fmt.Errorf("Expected %d input lines, got %d", s.GetMaxTaps(), len(srcLines))
+	}
+	// the lines are copied to C memory, since go memory cannot be passed in an array of pointers
+	data := C.malloc(C.size_t(lineSize * len(srcLines)))
+	defer C.free(data)
+	lines := C.allocScalerLines(C.guint(len(srcLines)))
+	defer C.g_free((C.gpointer)(unsafe.Pointer(lines)))
+	for i, line := range srcLines {
+		if len(line) < lineSize {
+			return errors.New("The input lines are too short for the scaler")
+		}
+		ptr := unsafe.Pointer(uintptr(data) + uintptr(i*lineSize))
+		C.memcpy(ptr, unsafe.Pointer(&line[0]), C.size_t(lineSize))
+		C.setScalerLine(lines, C.guint(i), (C.gpointer)(ptr))
+	}
+	C.gst_video_scaler_vertical(
+		s.ptr,
+		C.GstVideoFormat(format),
+		lines,
+		(C.gpointer)(unsafe.Pointer(&dest[0])),
+		C.guint(destOffset),
+		C.guint(width),
+	)
+	return nil
+}
+
+// Scale2D scales the plane of pixels in src into the rectangle of width by height pixels at x, y of the
+// output plane in dest, using hscale horizontally and vscale vertically. Either scaler may be nil to keep
+// the size in that direction. The rectangle must fit in the output size of the scalers. The strides are the
+// number of bytes per row of the planes.
+func Scale2D(hscale, vscale *Scaler, format Format, src []byte, srcStride int, dest []byte, destStride int, x, y, width, height uint) error {
+	size, err := pixelBytes(format)
+	if err != nil {
+		return err
+	}
+	inWidth, inHeight := x+width, y+height
+	var cHscale, cVscale *C.GstVideoScaler
+	if hscale != nil {
+		cHscale, inWidth = hscale.ptr, hscale.inSize
+	}
+	if vscale != nil {
+		cVscale, inHeight = vscale.ptr, vscale.inSize
+	}
+	if width == 0 || height == 0 || inWidth == 0 || inHeight == 0 {
+		return errors.New("Cannot scale an empty plane")
+	}
+	if hscale != nil && x+width > hscale.outSize {
+		return fmt.Errorf("Cannot write %d pixels at column %d of an output plane of %d columns", width, x, hscale.outSize)
+	}
+	if vscale != nil && y+height > vscale.outSize {
+		return fmt.Errorf("Cannot write %d lines at row %d of an output plane of %d rows", height, y, vscale.outSize)
+	}
+	if len(src) < (int(inHeight)-1)*srcStride+int(inWidth)*size {
+		return errors.New("The input plane is too small for the scalers")
+	}
+	if len(dest) < (int(y+height)-1)*destStride+int(x+width)*size {
+		return errors.New("The output plane is too small for the rectangle")
+	}
+	C.gst_video_scaler_2d(
+		cHscale, cVscale,
+		C.GstVideoFormat(format),
+		(C.gpointer)(unsafe.Pointer(&src[0])), C.gint(srcStride),
+		(C.gpointer)(unsafe.Pointer(&dest[0])), C.gint(destStride),
+		C.guint(x), C.guint(y),
+		C.guint(width), C.guint(height),
+	)
+	return nil
+}
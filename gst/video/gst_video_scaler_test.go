@@ -0,0 +1,87 @@
+package video
+
+import "testing"
+
+func TestScalerHorizontalBounds(t *testing.T) {
+	scaler, err := NewScaler(ResamplerMethodNearest, ScalerFlagNone, 0, 4, 8, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scaler.Free()
+
+	src := []byte{1, 2, 3, 4}
+	dest := make([]byte, 8)
+	if err := scaler.Horizontal(FormatGray8, src, dest, 4, 4); err != nil {
+		t.Fatal(err)
+	}
+	if dest[3] != 0 || dest[7] != 4 {
+		t.Errorf("Expected the pixels to be written from the offset, got %v", dest)
+	}
+
+	// dest must hold the pixels before the offset
+	if err := scaler.Horizontal(FormatGray8, src, make([]byte, 4), 4, 4); err == nil {
+		t.Error("Expected an error for an output line too short for the offset")
+	}
+	if err := scaler.Horizontal(FormatGray8, src, make([]byte, 16), 6, 4); err == nil {
+		t.Error("Expected an error for pixels past the output size")
+	}
+	if err := scaler.Horizontal(FormatGray8, src[:3], dest, 0, 8); err == nil {
+		t.Error("Expected an error for an input line too short")
+	}
+}
+
+func TestScalerVerticalBounds(t *testing.T) {
+	scaler, err := NewScaler(ResamplerMethodNearest, ScalerFlagNone, 0, 2, 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scaler.Free()
+
+	lines := make([][]byte, scaler.GetMaxTaps())
+	for i := range lines {
+		lines[i] = []byte{1, 2}
+	}
+	dest := make([]byte, 2)
+	if err := scaler.Vertical(FormatGray8, lines, dest, 3, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := scaler.Vertical(FormatGray8, lines, dest, 4, 2); err == nil {
+		t.Error("Expected an error for an output line past the output size")
+	}
+	if err := scaler.Vertical(FormatGray8, lines, dest[:1], 0, 2); err == nil {
+		t.Error("Expected an error for an output line too short")
+	}
+}
+
+func TestScale2DBounds(t *testing.T) {
+	hscale, err := NewScaler(ResamplerMethodNearest, ScalerFlagNone, 0, 2, 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer hscale.Free()
+	vscale, err := NewScaler(ResamplerMethodNearest, ScalerFlagNone, 0, 2, 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer vscale.Free()
+
+	src := []byte{1, 2, 3, 4}
+	// the output plane is larger than the scalers, so only the scaler sizes bound the rectangle
+	dest := make([]byte, 8*8)
+	if err := Scale2D(hscale, vscale, FormatGray8, src, 2, dest, 8, 0, 0, 4, 4); err != nil {
+		t.Fatal(err)
+	}
+	if dest[0] != 1 || dest[3*8+3] != 4 {
+		t.Errorf("Expected the plane to be scaled, got %v", dest[:4*8])
+	}
+
+	if err := Scale2D(hscale, vscale, FormatGray8, src, 2, dest, 8, 2, 0, 4, 4); err == nil {
+		t.Error("Expected an error for columns past the output size of the horizontal scaler")
+	}
+	if err := Scale2D(hscale, vscale, FormatGray8, src, 2, dest, 8, 0, 1, 4, 4); err == nil {
+		t.Error("Expected an error for rows past the output size of the vertical scaler")
+	}
+	if err := Scale2D(hscale, nil, FormatGray8, src, 2, dest, 8, 0, 0, 4, 2); err != nil {
+		t.Errorf("Expected the height to be kept without a vertical scaler: %s", err)
+	}
+}
@@ -0,0 +1,144 @@
+package video
+
+/*
+#include <stdlib.h>
+#include "gst.go.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+// TimeCodeFlags are flags describing a TimeCode.
+type TimeCodeFlags int
+
+// Type castings
+const (
+	TimeCodeFlagsNone       TimeCodeFlags = C.GST_VIDEO_TIME_CODE_FLAGS_NONE       // (0) – No flags
+	TimeCodeFlagsDropFrame  TimeCodeFlags = C.GST_VIDEO_TIME_CODE_FLAGS_DROP_FRAME // (1) – Whether we have drop frame rate
+	TimeCodeFlagsInterlaced TimeCodeFlags = C.GST_VIDEO_TIME_CODE_FLAGS_INTERLACED // (2) – Whether we have interlaced video
+)
+
+// TimeCode is a go representation of a GstVideoTimeCode. It is a SMPTE time code of hours, minutes,
+// seconds and frames at a given frame rate.
+type TimeCode struct {
+	ptr *C.GstVideoTimeCode
+}
+
+// FromGstVideoTimeCodeUnsafe returns a copy of the given C GstVideoTimeCode in the go type.
+// This is meant for internal usage and is exported for visibility to other packages.
+func FromGstVideoTimeCodeUnsafe(tc unsafe.Pointer) *TimeCode {
+	if tc == nil {
+		return nil
+	}
+	return takeTimeCode(C.gst_video_time_code_copy((*C.GstVideoTimeCode)(tc)))
+}
+
+func takeTimeCode(tc *C.GstVideoTimeCode) *TimeCode {
+	if tc == nil {
+		return nil
+	}
+	t := &TimeCode{ptr: tc}
+	runtime.SetFinalizer(t, func(t *TimeCode) { C.gst_video_time_code_free(t.ptr) })
+	return t
+}
+
+// NewTimeCode returns a new TimeCode at the given frame rate. fieldCount is 1 or 2 for interlaced video,
+// and 0 otherwise. The time code has no latest daily jam.
+func NewTimeCode(fpsN, fpsD uint, flags TimeCodeFlags, hours, minutes, seconds, frames, fieldCount uint) *TimeCode {
+	return takeTimeCode(C.gst_video_time_code_new(
+		C.guint(fpsN), C.guint(fpsD),
+		nil,
+		C.GstVideoTimeCodeFlags(flags),
+		C.guint(hours), C.guint(minutes), C.guint(seconds), C.guint(frames),
+		C.guint(fieldCount),
+	))
+}
+
+// NewTimeCodeFromString parses a time code of the form "hh:mm:ss:ff", or "hh:mm:ss;ff" for drop frame
+// time codes. The returned time code has no frame rate, so it is only useful for reading its fields.
+func NewTimeCodeFromString(str string) (*TimeCode, error) {
+	cStr := C.CString(str)
+	defer C.free(unsafe.Pointer(cStr))
+	tc := C.gst_video_time_code_new_from_string(cStr)
+	if tc == nil {
+		return nil, errors.New("Invalid time code string")
+	}
+	return takeTimeCode(tc), nil
+}
+
+// Instance returns the underlying GstVideoTimeCode instance.
+func (t *TimeCode) Instance() *C.GstVideoTimeCode { return t.ptr }
+
+// Copy returns a copy of the time code.
+func (t *TimeCode) Copy() *TimeCode { return takeTimeCode(C.gst_video_time_code_copy(t.ptr)) }
+
+// FPS returns the frame rate of the time code as a fraction.
+func (t *TimeCode) FPS() (n, d uint) {
+	return uint(t.ptr.config.fps_n), uint(t.ptr.config.fps_d)
+}
+
+// Flags returns the flags of the time code.
+func (t *TimeCode) Flags() TimeCodeFlags { return TimeCodeFlags(t.ptr.config.flags) }
+
+// Hours returns the hours of the time code.
+func (t *TimeCode) Hours() uint { return uint(t.ptr.hours) }
+
+// Minutes returns the minutes of the time code.
+func (t *TimeCode) Minutes() uint { return uint(t.ptr.minutes) }
+
+// Seconds returns the seconds of the time code.
+func (t *TimeCode) Seconds() uint { return uint(t.ptr.seconds) }
+
+// Frames returns the frames of the time code.
+func (t *TimeCode) Frames() uint { return uint(t.ptr.frames) }
+
+// FieldCount returns the field of the time code, 1 or 2 for interlaced video and 0 otherwise.
+func (t *TimeCode) FieldCount() uint { return uint(t.ptr.field_count) }
+
+// String returns the time code in the form "hh:mm:ss:ff", or "hh:mm:ss;ff" for drop frame time codes.
+func (t *TimeCode) String() string {
+	str := C.gst_video_time_code_to_string(t.ptr)
+	defer C.g_free((C.gpointer)(unsafe.Pointer(str)))
+	return C.GoString(str)
+}
+
+// IsValid returns true if the time code is valid for its frame rate and flags.
+func (t *TimeCode) IsValid() bool { return gobool(C.gst_video_time_code_is_valid(t.ptr)) }
+
+// IncrementFrame adds one frame to the time code.
+func (t *TimeCode) IncrementFrame() { C.gst_video_time_code_increment_frame(t.ptr) }
+
+// AddFrames adds the given number of frames, which may be negative, to the time code.
+func (t *TimeCode) AddFrames(frames int64) { C.gst_video_time_code_add_frames(t.ptr, C.gint64(frames)) }
+
+// AddInterval returns a new time code with the given interval added to this one, or an error if the
+// result is not a valid time code.
+func (t *TimeCode) AddInterval(hours, minutes, seconds, frames uint) (*TimeCode, error) {
+	interval := C.gst_video_time_code_interval_new(C.guint(hours), C.guint(minutes), C.guint(seconds), C.guint(frames))
+	defer C.gst_video_time_code_interval_free(interval)
+	tc := C.gst_video_time_code_add_interval(t.ptr, interval)
+	if tc == nil {
+		return nil, errors.New("The interval does not result in a valid time code")
+	}
+	return takeTimeCode(tc), nil
+}
+
+// FramesSinceDailyJam returns the number of frames since midnight of the time code.
+func (t *TimeCode) FramesSinceDailyJam() uint64 {
+	return uint64(C.gst_video_time_code_frames_since_daily_jam(t.ptr))
+}
+
+// NsSinceDailyJam returns the number of nanoseconds since midnight of the time code.
+func (t *TimeCode) NsSinceDailyJam() uint64 {
+	return uint64(C.gst_video_time_code_nsec_since_daily_jam(t.ptr))
+}
+
+// Compare returns -1 if this time code is earlier than other, 1 if it is later and 0 if they are
+// equal. Both time codes must have the same frame rate.
+func (t *TimeCode) Compare(other *TimeCode) int {
+	return int(C.gst_video_time_code_compare(t.ptr, other.ptr))
+}